@@ -57,14 +57,29 @@ type AgentConfigReference struct {
 	Name string `json:"name"`
 }
 
+// AgentConfigStatus defines the observed state of AgentConfig.
+type AgentConfigStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the AgentConfig.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // AgentConfig is the Schema for the agentconfigs API.
 type AgentConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec AgentConfigSpec `json:"spec,omitempty"`
+	Spec   AgentConfigSpec   `json:"spec,omitempty"`
+	Status AgentConfigStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,28 @@
+package v1alpha1
+
+// Condition types reported in the status of Axon resources.
+const (
+	// ConditionReady indicates whether the resource is ready. For a Task it
+	// is True once the Task has succeeded and False once it has failed or
+	// been cancelled.
+	ConditionReady = "Ready"
+	// ConditionWorkspaceResolved indicates whether the referenced Workspace
+	// was found.
+	ConditionWorkspaceResolved = "WorkspaceResolved"
+	// ConditionCredentialsResolved indicates whether the git credentials of
+	// the Workspace could be resolved.
+	ConditionCredentialsResolved = "CredentialsResolved"
+	// ConditionJobCreated indicates whether the Job for the current attempt
+	// of a Task was created.
+	ConditionJobCreated = "JobCreated"
+	// ConditionCompleted indicates whether a Task has reached a terminal
+	// phase.
+	ConditionCompleted = "Completed"
+	// ConditionSourceHealthy indicates whether the last discovery cycle of
+	// a TaskSpawner succeeded.
+	ConditionSourceHealthy = "SourceHealthy"
+	// ConditionThrottled indicates whether a TaskSpawner skipped items in
+	// its last cycle because a rate or budget limit was reached. Its reason
+	// names the limit.
+	ConditionThrottled = "Throttled"
+)
@@ -21,14 +21,65 @@ type TaskPhase string
 const (
 	// TaskPhasePending means the Task has been accepted but not yet started.
 	TaskPhasePending TaskPhase = "Pending"
+	// TaskPhaseWaiting means the Task is waiting for the Tasks it depends on
+	// to succeed before its Job is created.
+	TaskPhaseWaiting TaskPhase = "Waiting"
 	// TaskPhaseRunning means the Task is currently running.
 	TaskPhaseRunning TaskPhase = "Running"
+	// TaskPhaseRetrying means the last attempt failed and the Task is
+	// waiting for its backoff to elapse before starting the next attempt.
+	TaskPhaseRetrying TaskPhase = "Retrying"
 	// TaskPhaseSucceeded means the Task has completed successfully.
 	TaskPhaseSucceeded TaskPhase = "Succeeded"
 	// TaskPhaseFailed means the Task has failed.
 	TaskPhaseFailed TaskPhase = "Failed"
+	// TaskPhaseCancelled means the Task was stopped because spec.cancel was set.
+	TaskPhaseCancelled TaskPhase = "Cancelled"
 )
 
+// FailureReason describes why an attempt of a Task failed.
+// +kubebuilder:validation:Enum=Error;OOMKilled;DeadlineExceeded
+type FailureReason string
+
+const (
+	// FailureReasonError means the agent container exited with a non-zero
+	// exit code (e.g. because of an API overload or rate-limit error).
+	FailureReasonError FailureReason = "Error"
+	// FailureReasonOOMKilled means the agent container was killed because
+	// it ran out of memory.
+	FailureReasonOOMKilled FailureReason = "OOMKilled"
+	// FailureReasonDeadlineExceeded means the attempt was terminated because
+	// it exceeded podOverrides.activeDeadlineSeconds.
+	FailureReasonDeadlineExceeded FailureReason = "DeadlineExceeded"
+)
+
+// RetryPolicy defines how failed attempts of a Task are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. The Task is marked Failed once this many attempts have failed.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int32 `json:"maxAttempts"`
+
+	// BackoffSeconds is the delay before the first retry. The delay doubles
+	// with each subsequent retry, up to MaxBackoffSeconds.
+	// Defaults to 10 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BackoffSeconds *int32 `json:"backoffSeconds,omitempty"`
+
+	// MaxBackoffSeconds caps the delay between retries.
+	// Defaults to 300 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+
+	// RetryOn lists the failure reasons that are retried. If empty, every
+	// failure is retried.
+	// +optional
+	RetryOn []FailureReason `json:"retryOn,omitempty"`
+}
+
 // SecretReference refers to a Secret containing credentials.
 type SecretReference struct {
 	// Name is the name of the secret.
@@ -67,6 +118,12 @@ type PodOverrides struct {
 	// NodeSelector constrains agent pods to nodes matching the given labels.
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the agent pod,
+	// so that the scheduler places more important Tasks first when the
+	// cluster is short of resources.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // TaskSpec defines the desired state of Task.
@@ -116,6 +173,54 @@ type TaskSpec struct {
 	// PodOverrides allows customizing the agent pod configuration.
 	// +optional
 	PodOverrides *PodOverrides `json:"podOverrides,omitempty"`
+
+	// DependsOn lists the names of Tasks in the same namespace that must
+	// succeed before this Task is started. While any dependency is still
+	// running the Task stays in the Waiting phase; if any dependency fails
+	// or is cancelled, or the dependencies form a cycle, this Task fails.
+	// When set, the prompt is rendered as a Go text/template with the
+	// outputs of each dependency available as
+	// {{ (index .Deps "<task-name>").Outputs }}, along with its .Results,
+	// .Branch, .CommitSHA and .PullRequestURLs.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// RetryPolicy controls whether failed attempts are retried. Each attempt
+	// runs in a fresh Job. If unset, the Task fails on the first failure.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Cancel stops the Task. When set on a Task that has not finished, the
+	// controller suspends its Job, which deletes the running Pods, and moves
+	// the Task to the Cancelled phase while keeping its status.
+	// A cancelled Task cannot be resumed.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+// TaskAttempt records a single attempt to run a Task.
+type TaskAttempt struct {
+	// Attempt is the 1-based number of this attempt.
+	Attempt int32 `json:"attempt"`
+
+	// JobName is the name of the Job created for this attempt.
+	JobName string `json:"jobName"`
+
+	// StartTime is when the Job for this attempt was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when this attempt finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Phase is the final phase of this attempt (Succeeded, Failed, or Cancelled).
+	// +optional
+	Phase TaskPhase `json:"phase,omitempty"`
+
+	// Reason is why this attempt failed.
+	// +optional
+	Reason FailureReason `json:"reason,omitempty"`
 }
 
 // TaskStatus defines the observed state of Task.
@@ -144,10 +249,60 @@ type TaskStatus struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 
-	// Outputs contains URLs and references produced by the agent
+	// Outputs contains the raw output lines produced by the agent
 	// (e.g. branch names, PR URLs).
 	// +optional
 	Outputs []string `json:"outputs,omitempty"`
+
+	// Results contains the key/value results emitted by the agent, parsed
+	// from "key: value" lines and JSON objects in the outputs block.
+	// +optional
+	Results map[string]string `json:"results,omitempty"`
+
+	// Branch is the git branch the agent worked on.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// CommitSHA is the SHA of the last commit on Branch.
+	// +optional
+	CommitSHA string `json:"commitSHA,omitempty"`
+
+	// PullRequestURLs are the URLs of the pull requests opened or updated
+	// by the agent.
+	// +optional
+	PullRequestURLs []string `json:"pullRequestURLs,omitempty"`
+
+	// CostUSD is the total cost in US dollars reported by the agent, as a
+	// decimal string (e.g. "0.1234"). Only agents that report cost set it.
+	// +optional
+	CostUSD string `json:"costUSD,omitempty"`
+
+	// NumTurns is the number of agent turns reported by the agent.
+	// +optional
+	NumTurns int32 `json:"numTurns,omitempty"`
+
+	// InputTokens is the number of input tokens, including cached tokens,
+	// reported by the agent.
+	// +optional
+	InputTokens int64 `json:"inputTokens,omitempty"`
+
+	// OutputTokens is the number of output tokens reported by the agent.
+	// +optional
+	OutputTokens int64 `json:"outputTokens,omitempty"`
+
+	// Attempts records each attempt to run the Task, oldest first.
+	// +optional
+	Attempts []TaskAttempt `json:"attempts,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the Task.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -14,6 +14,11 @@ const (
 	TaskSpawnerPhaseRunning TaskSpawnerPhase = "Running"
 	// TaskSpawnerPhaseFailed means the spawner has failed.
 	TaskSpawnerPhaseFailed TaskSpawnerPhase = "Failed"
+	// TaskSpawnerPhaseSuspended means the spawner is not creating new tasks because spec.suspend is set.
+	TaskSpawnerPhaseSuspended TaskSpawnerPhase = "Suspended"
+	// TaskSpawnerPhaseThrottled means the spawner skipped items because a
+	// rate or budget limit was reached.
+	TaskSpawnerPhaseThrottled TaskSpawnerPhase = "Throttled"
 )
 
 // When defines the conditions that trigger task spawning.
@@ -23,21 +28,86 @@ type When struct {
 	// +optional
 	GitHubIssues *GitHubIssues `json:"githubIssues,omitempty"`
 
+	// GitHubPullRequests discovers pull requests, with their reviews and
+	// review comments, from a GitHub repository.
+	// +optional
+	GitHubPullRequests *GitHubPullRequests `json:"githubPullRequests,omitempty"`
+
+	// GitLabIssues discovers issues and merge requests from a GitLab project.
+	// +optional
+	GitLabIssues *GitLabIssues `json:"gitlabIssues,omitempty"`
+
+	// Jira discovers issues matching a JQL query from a Jira instance.
+	// +optional
+	Jira *Jira `json:"jira,omitempty"`
+
 	// Cron triggers task spawning on a cron schedule.
 	// +optional
 	Cron *Cron `json:"cron,omitempty"`
+
+	// HTTPJSON discovers items from an HTTP API returning JSON, such as an
+	// internal ticketing or alerting system.
+	// +optional
+	HTTPJSON *HTTPJSON `json:"httpJSON,omitempty"`
+
+	// KubernetesEvents discovers objects of the TaskSpawner's namespace in
+	// a given state, such as Pods in CrashLoopBackOff or Deployments whose
+	// rollout failed.
+	// +optional
+	KubernetesEvents *KubernetesEvents `json:"kubernetesEvents,omitempty"`
 }
 
-// Cron triggers task spawning on a cron schedule.
+// ConcurrencyPolicy describes how a cron TaskSpawner treats a scheduled
+// run while Tasks from earlier runs are still running.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyAllow creates the Task of a run even if earlier
+	// Tasks are still running.
+	ConcurrencyPolicyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyPolicyForbid skips a run while earlier Tasks are still
+	// running.
+	ConcurrencyPolicyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyPolicyReplace cancels the Tasks that are still running and
+	// creates the Task of the new run.
+	ConcurrencyPolicyReplace ConcurrencyPolicy = "Replace"
+)
+
+// Cron triggers task spawning on a cron schedule. Like a Kubernetes
+// CronJob, runs missed while the spawner was unavailable are not replayed:
+// only the most recent one is started.
 type Cron struct {
 	// Schedule is a cron expression (e.g., "0 9 * * 1" for every Monday at 9am).
 	// +kubebuilder:validation:Required
 	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA name of the time zone the schedule is evaluated
+	// in (e.g. "Asia/Seoul"), so that runs follow its daylight saving time
+	// changes. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a run while Tasks from
+	// earlier runs are still running: "Allow" creates a Task anyway,
+	// "Forbid" skips the run and "Replace" cancels the running Tasks.
+	// Defaults to Allow.
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline in seconds for starting a run
+	// that was missed for any reason, such as the spawner being down.
+	// Runs that are later than this are skipped. When unset, missed runs
+	// have no deadline.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
 }
 
 // GitHubIssues discovers issues from a GitHub repository.
 // The repository owner and name are derived from the workspace's repo URL
-// specified in taskTemplate.workspaceRef.
+// specified in taskTemplate.workspaceRef, unless repos or org is set.
 // If the workspace has a secretRef, it is used for GitHub API authentication.
 type GitHubIssues struct {
 	// Types specifies which item types to discover: "issues", "pulls", or both.
@@ -59,6 +129,344 @@ type GitHubIssues struct {
 	// +kubebuilder:default=open
 	// +optional
 	State string `json:"state,omitempty"`
+
+	// Authors, if set, only selects issues opened by one of these logins.
+	// Like excludeAuthors and authorAssociations, it also applies to
+	// comments: comments by other users are left out of {{.Comments}} and
+	// do not re-trigger Tasks.
+	// +optional
+	Authors []string `json:"authors,omitempty"`
+
+	// ExcludeAuthors filters out issues opened by any of these logins.
+	// +optional
+	ExcludeAuthors []string `json:"excludeAuthors,omitempty"`
+
+	// AuthorAssociations, if set, only selects issues whose author has one
+	// of these associations with the repository, as reported by GitHub
+	// (e.g. OWNER, MEMBER, COLLABORATOR). Use it to keep anyone who can
+	// open an issue on a public repository from starting agents. GitHub
+	// only reports MEMBER for private organization members if the token
+	// can see the membership.
+	// +kubebuilder:validation:items:Enum=OWNER;MEMBER;COLLABORATOR;CONTRIBUTOR;FIRST_TIME_CONTRIBUTOR;FIRST_TIMER;NONE
+	// +optional
+	AuthorAssociations []string `json:"authorAssociations,omitempty"`
+
+	// Repos lists the repositories to discover issues from instead of the
+	// workspace's repository.
+	// +optional
+	Repos []GitHubRepository `json:"repos,omitempty"`
+
+	// Org discovers issues from the repositories of a GitHub organization
+	// instead of the workspace's repository.
+	// +optional
+	Org *GitHubOrganization `json:"org,omitempty"`
+
+	// Webhook enables receiving GitHub webhook deliveries for issues,
+	// issue_comment, pull_request and pull_request_review events. The
+	// spawner serves the endpoint on port 8082 through a Service named
+	// after the TaskSpawner and creates Tasks as soon as deliveries arrive.
+	// The repository is still polled every pollInterval as a fallback.
+	// +optional
+	Webhook *GitHubWebhook `json:"webhook,omitempty"`
+}
+
+// GitHubWebhook configures the GitHub webhook receiver of a spawner.
+type GitHubWebhook struct {
+	// SecretRef references a Secret whose GITHUB_WEBHOOK_SECRET key holds
+	// the secret configured on the GitHub webhook. Deliveries whose
+	// X-Hub-Signature-256 header does not match are rejected.
+	// +kubebuilder:validation:Required
+	SecretRef SecretReference `json:"secretRef"`
+}
+
+// GitHubPullRequests discovers pull requests from a GitHub repository.
+// The repository owner and name are derived from the workspace's repo URL
+// specified in taskTemplate.workspaceRef unless repos or org is set, and
+// spawned Tasks check out the head branch of the pull request unless it
+// comes from a fork.
+// If the workspace has a secretRef, it is used for GitHub API authentication.
+type GitHubPullRequests struct {
+	// Labels filters pull requests by labels.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// ExcludeLabels filters out pull requests that have any of these labels.
+	// +optional
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+
+	// State filters pull requests by state (open, closed, all). Defaults to open.
+	// +kubebuilder:validation:Enum=open;closed;all
+	// +kubebuilder:default=open
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ReviewState filters pull requests by review state, derived from the
+	// latest review of each reviewer: changes_requested if any reviewer
+	// requested changes, approved if any approved and none requested
+	// changes. When unset, pull requests are selected regardless of reviews.
+	// +kubebuilder:validation:Enum=approved;changes_requested
+	// +optional
+	ReviewState string `json:"reviewState,omitempty"`
+
+	// Draft filters pull requests by draft status. When unset, both draft
+	// and ready pull requests are selected.
+	// +optional
+	Draft *bool `json:"draft,omitempty"`
+
+	// BaseBranch filters pull requests by the branch they target.
+	// +optional
+	BaseBranch string `json:"baseBranch,omitempty"`
+
+	// Author filters pull requests by the login of the user who opened them.
+	// +optional
+	Author string `json:"author,omitempty"`
+
+	// Repos lists the repositories to discover pull requests from instead
+	// of the workspace's repository.
+	// +optional
+	Repos []GitHubRepository `json:"repos,omitempty"`
+
+	// Org discovers pull requests from the repositories of a GitHub
+	// organization instead of the workspace's repository.
+	// +optional
+	Org *GitHubOrganization `json:"org,omitempty"`
+}
+
+// GitHubRepository is a GitHub repository discovered by a TaskSpawner.
+// Tasks for its items work in WorkspaceRef if set. Otherwise they work in
+// the workspace of taskTemplate.workspaceRef if it is this repository, and
+// in a Workspace named after the TaskSpawner and the repository that the
+// spawner creates from it otherwise, with the same secretRef and files and
+// checking out the repository's default branch.
+// The repository must be hosted on the same GitHub host as the workspace.
+type GitHubRepository struct {
+	// Name is the repository as "owner/name".
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`
+	Name string `json:"name"`
+
+	// WorkspaceRef references the Workspace Tasks for items of this
+	// repository work in.
+	// +optional
+	WorkspaceRef *WorkspaceReference `json:"workspaceRef,omitempty"`
+}
+
+// GitHubOrganization selects the repositories of a GitHub organization.
+// Archived repositories are skipped. Tasks for their items work in a
+// Workspace resolved as for a GitHubRepository without workspaceRef.
+type GitHubOrganization struct {
+	// Name is the login of the organization.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	Name string `json:"name"`
+
+	// RepoPattern is a regular expression that the names of the
+	// repositories must match (e.g. "^service-"). When unset, all
+	// repositories of the organization are selected.
+	// +optional
+	RepoPattern string `json:"repoPattern,omitempty"`
+}
+
+// GitLabIssues discovers issues and merge requests from a GitLab project.
+// The GitLab host and, unless Project is set, the project path are derived
+// from the workspace's repo URL specified in taskTemplate.workspaceRef.
+// If the workspace has a secretRef, its GITLAB_TOKEN key is used for GitLab
+// API authentication.
+type GitLabIssues struct {
+	// Project is the path of the GitLab project (e.g. "group/subgroup/project").
+	// Defaults to the path of the workspace's repo URL.
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// Types specifies which item types to discover: "issues", "merge_requests", or both.
+	// +kubebuilder:validation:items:Enum=issues;merge_requests
+	// +kubebuilder:default={"issues"}
+	// +optional
+	Types []string `json:"types,omitempty"`
+
+	// Labels filters issues and merge requests by labels.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// ExcludeLabels filters out items that have any of these labels (client-side).
+	// +optional
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+
+	// State filters items by state (opened, closed, all). Defaults to opened.
+	// +kubebuilder:validation:Enum=opened;closed;all
+	// +kubebuilder:default=opened
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// JiraDeployment is the kind of Jira instance a Jira source searches.
+type JiraDeployment string
+
+const (
+	// JiraDeploymentCloud is a Jira Cloud site, searched with the
+	// /rest/api/3/search/jql API.
+	JiraDeploymentCloud JiraDeployment = "Cloud"
+	// JiraDeploymentServer is a Jira Server or Data Center instance,
+	// searched with the /rest/api/2/search API.
+	JiraDeploymentServer JiraDeployment = "Server"
+)
+
+// Jira discovers issues matching a JQL query from a Jira instance.
+type Jira struct {
+	// BaseURL is the URL of the Jira instance (e.g. "https://example.atlassian.net").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	BaseURL string `json:"baseURL"`
+
+	// Deployment is the kind of Jira instance: "Cloud", or "Server" for
+	// Jira Server and Data Center. Defaults to Cloud when baseURL is an
+	// atlassian.net site and to Server otherwise.
+	// +kubebuilder:validation:Enum=Cloud;Server
+	// +optional
+	Deployment JiraDeployment `json:"deployment,omitempty"`
+
+	// JQL is the query selecting the issues to work on
+	// (e.g. "project = PROJ AND labels = axon AND status = 'To Do'").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	JQL string `json:"jql"`
+
+	// SecretRef references a Secret containing a JIRA_TOKEN key used to
+	// authenticate with the Jira REST API. If the Secret also contains a
+	// JIRA_USER key, the token is sent as an API token with basic
+	// authentication (Jira Cloud); otherwise it is sent as a bearer
+	// personal access token (Jira Server and Data Center).
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
+
+// HTTPJSON discovers items by polling a URL that returns JSON. Paths are
+// JSONPath expressions as in kubectl, with or without the enclosing braces
+// (e.g. "{.data.tickets}" or ".data.tickets").
+type HTTPJSON struct {
+	// URL is the URL polled for items (e.g.
+	// "https://tickets.example.com/api/tickets?status=open").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SecretRef references a Secret whose keys and values are sent as
+	// request headers (e.g. an "Authorization" key with value
+	// "Bearer <token>"). They are only sent to pages with the scheme and
+	// host of URL.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// ItemsPath selects the items in a response, as an array or several
+	// values (e.g. ".data" or ".data[*]"). Defaults to the whole response,
+	// which is then expected to be an array.
+	// +optional
+	ItemsPath string `json:"itemsPath,omitempty"`
+
+	// NextPath selects the URL of the next page in a response (e.g.
+	// ".links.next"), which may be relative to the URL of the response.
+	// Pages are fetched until it is missing or empty, up to 10 pages. When
+	// unset, only the first page is fetched.
+	// +optional
+	NextPath string `json:"nextPath,omitempty"`
+
+	// Fields maps the fields of an item to those of the work item.
+	// +kubebuilder:validation:Required
+	Fields HTTPJSONFields `json:"fields"`
+
+	// Extra maps names to paths in an item, whose values are available in
+	// the prompt template as {{.Extra.<name>}} (e.g. "severity":
+	// ".severity"). Objects and arrays are rendered as JSON.
+	// +optional
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// HTTPJSONFields maps the fields of an item of an HTTPJSON source to those
+// of the work item, as paths relative to the item.
+type HTTPJSONFields struct {
+	// ID selects the unique ID of an item (e.g. ".id"). Task names use it
+	// lowercased, with characters not allowed in Task names replaced by
+	// "-", truncated to 30 characters and followed by a hash of the ID.
+	// Items without an ID are skipped.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// Title selects the title of an item.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Body selects the description of an item.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// URL selects the link to an item.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Labels selects the labels of an item, either an array or several
+	// values (e.g. ".tags[*].name").
+	// +optional
+	Labels string `json:"labels,omitempty"`
+}
+
+// KubernetesEvents discovers objects of a kind in the namespace of the
+// TaskSpawner that match a label selector and a field predicate, checking
+// them on every poll. Each matching object is an item, with its YAML, its
+// recent Events and the logs of its containers available to the prompt.
+// An object gets a single Task until its metadata.generation changes.
+// When Kind is "Event", each matching Event is an item for the object it
+// involves, and the object gets a single Task per Event reason.
+//
+// The spawner can read Pods, their logs, Events, Deployments, ReplicaSets,
+// StatefulSets, DaemonSets and Jobs; other kinds require an additional
+// Role bound to the axon-spawner ServiceAccount.
+type KubernetesEvents struct {
+	// APIVersion is the API version of the objects (e.g. "v1" or
+	// "apps/v1").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the objects (e.g. "Pod", "Deployment" or
+	// "Event").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// LabelSelector selects the objects by label. When unset, all objects
+	// of the kind are considered.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Predicate selects the objects in the state of interest. When unset,
+	// every object selected by LabelSelector is an item.
+	// +optional
+	Predicate *FieldPredicate `json:"predicate,omitempty"`
+
+	// TailLines is the number of lines of logs of each container included
+	// with an item. The logs of the previous instance of a container that
+	// restarted are used. Logs are read from the object if it is a Pod, or
+	// from up to 3 Pods matching its spec.selector. Defaults to 50; zero
+	// disables logs.
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TailLines *int32 `json:"tailLines,omitempty"`
+}
+
+// FieldPredicate matches objects by the values of a field.
+type FieldPredicate struct {
+	// Path is a JSONPath expression as in kubectl, with or without the
+	// enclosing braces, selecting values of the object (e.g.
+	// ".status.containerStatuses[*].state.waiting.reason").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Values are the values to match (e.g. ["CrashLoopBackOff"]). An
+	// object matches if any value selected by Path is one of them, or, if
+	// Values is empty, if Path selects any non-empty value.
+	// +optional
+	Values []string `json:"values,omitempty"`
 }
 
 // TaskTemplate defines the template for spawned Tasks.
@@ -83,7 +491,8 @@ type TaskTemplate struct {
 	Image string `json:"image,omitempty"`
 
 	// WorkspaceRef references the Workspace that defines the repository.
-	// Required when using githubIssues source; optional for other sources.
+	// Required when using githubIssues, githubPullRequests or gitlabIssues
+	// source; optional for other sources.
 	// When set, spawned Tasks inherit this workspace reference.
 	// +optional
 	WorkspaceRef *WorkspaceReference `json:"workspaceRef,omitempty"`
@@ -112,10 +521,16 @@ type TaskTemplate struct {
 	// PodOverrides allows customizing the agent pod configuration for spawned Tasks.
 	// +optional
 	PodOverrides *PodOverrides `json:"podOverrides,omitempty"`
+
+	// RetryPolicy controls whether failed attempts of spawned Tasks are retried.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 // TaskSpawnerSpec defines the desired state of TaskSpawner.
 // +kubebuilder:validation:XValidation:rule="!has(self.when.githubIssues) || has(self.taskTemplate.workspaceRef)",message="taskTemplate.workspaceRef is required when using githubIssues source"
+// +kubebuilder:validation:XValidation:rule="!has(self.when.githubPullRequests) || has(self.taskTemplate.workspaceRef)",message="taskTemplate.workspaceRef is required when using githubPullRequests source"
+// +kubebuilder:validation:XValidation:rule="!has(self.when.gitlabIssues) || has(self.taskTemplate.workspaceRef)",message="taskTemplate.workspaceRef is required when using gitlabIssues source"
 type TaskSpawnerSpec struct {
 	// When defines the conditions that trigger task spawning.
 	// +kubebuilder:validation:Required
@@ -136,6 +551,165 @@ type TaskSpawnerSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	MaxConcurrency *int32 `json:"maxConcurrency,omitempty"`
+
+	// MaxTasksPerHour limits the number of Tasks created in any 60 minutes.
+	// When the limit is reached, the spawner sets the Throttled condition
+	// and skips creating new Tasks until older ones fall out of the window.
+	// Tasks are counted from status.recentTasks, so Tasks deleted by
+	// ttlSecondsAfterFinished still count. If unset or zero, there is no
+	// hourly limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	MaxTasksPerHour *int32 `json:"maxTasksPerHour,omitempty"`
+
+	// MaxTasksPerDay limits the number of Tasks created in any 24 hours,
+	// like MaxTasksPerHour. If unset or zero, there is no daily limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	MaxTasksPerDay *int32 `json:"maxTasksPerDay,omitempty"`
+
+	// DailyBudgetUSD limits the cost in US dollars of the Tasks created in
+	// any 24 hours (e.g. "25" or "7.50"), as reported by their agents in
+	// status.costUSD. The spawner records the cost in status.recentTasks
+	// when it polls, so ttlSecondsAfterFinished should leave finished
+	// Tasks for at least one poll interval. Once the budget is reached,
+	// the spawner sets the Throttled condition and skips creating new
+	// Tasks. Running Tasks are not stopped, so the budget may be exceeded
+	// by their cost. If unset or zero, there is no budget.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	DailyBudgetUSD string `json:"dailyBudgetUSD,omitempty"`
+
+	// Suspend stops the spawner from discovering work items and creating
+	// new Tasks. Existing Tasks are not affected. Defaults to false.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Retrigger creates a new Task for an item that already has one when
+	// the item sees new activity after its previous Task finished. New
+	// Tasks are named after the first one with an attempt suffix (e.g.
+	// "<spawner>-42-2"). Only supported by the githubIssues and
+	// githubPullRequests sources. When unset, an item gets a single Task.
+	// +optional
+	Retrigger *RetriggerPolicy `json:"retrigger,omitempty"`
+
+	// Reporting reports the progress of spawned Tasks back to the issue or
+	// pull request they were created for. Only supported by the
+	// githubIssues and githubPullRequests sources.
+	// +optional
+	Reporting *Reporting `json:"reporting,omitempty"`
+
+	// Priority orders the items discovered in a cycle, so that the most
+	// important ones get Tasks first when maxConcurrency is reached, and
+	// sets the PriorityClass of their pods. When unset, items are handled
+	// in the order the source returns them.
+	// +optional
+	Priority *Priority `json:"priority,omitempty"`
+}
+
+// Priority orders.
+const (
+	// PriorityOrderOldest handles the items created first first.
+	PriorityOrderOldest = "oldest"
+	// PriorityOrderNewest handles the items created last first.
+	PriorityOrderNewest = "newest"
+	// PriorityOrderRecentlyUpdated handles the items updated last first.
+	PriorityOrderRecentlyUpdated = "recentlyUpdated"
+	// PriorityOrderReactions handles the items with the most reactions
+	// first.
+	PriorityOrderReactions = "reactions"
+)
+
+// Priority defines how discovered items are ordered. Items are ordered by
+// the highest weight of their labels, then by Order.
+type Priority struct {
+	// Labels assigns weights to labels (e.g. "priority/p0"). Items without
+	// any of these labels have a weight of 0.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Labels []LabelPriority `json:"labels,omitempty"`
+
+	// Order orders items of the same weight: "oldest" or "newest" by
+	// creation time, "recentlyUpdated" by last update time, or
+	// "reactions" by number of reactions (upvotes on GitLab). "reactions"
+	// is only supported with the githubIssues and gitlabIssues sources,
+	// since the other sources do not report reactions. When unset, items
+	// of the same weight keep the order of the source.
+	// +kubebuilder:validation:Enum=oldest;newest;recentlyUpdated;reactions
+	// +optional
+	Order string `json:"order,omitempty"`
+}
+
+// LabelPriority is the priority of the items with a label.
+type LabelPriority struct {
+	// Name is the label.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Weight is the weight of the items with the label. Items of higher
+	// weight are handled first.
+	Weight int32 `json:"weight"`
+
+	// PriorityClassName is the PriorityClass of the pods of the Tasks for
+	// items with the label, overriding
+	// taskTemplate.podOverrides.priorityClassName. The class of the label
+	// with the highest weight applies.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// Reporting defines how the progress of spawned Tasks is reported back to
+// their GitHub issue or pull request. The spawner posts a single status
+// comment per item with the Task name, phase, cost and pull request URLs,
+// and updates it as the Task progresses. It uses the Workspace's GitHub
+// token or GitHub App, which needs write access to issues and pull
+// requests.
+type Reporting struct {
+	// InProgressLabel is added to the item while its Task is pending or
+	// running, and removed once the Task finishes (e.g. "axon/in-progress").
+	// +optional
+	InProgressLabel string `json:"inProgressLabel,omitempty"`
+
+	// DoneLabel is added to the item when its Task succeeds
+	// (e.g. "axon/done").
+	// +optional
+	DoneLabel string `json:"doneLabel,omitempty"`
+
+	// FailedLabel is added to the item when its Task fails or is cancelled
+	// (e.g. "axon/failed").
+	// +optional
+	FailedLabel string `json:"failedLabel,omitempty"`
+}
+
+// Retrigger activity types.
+const (
+	// RetriggerOnComments re-triggers on new comments.
+	RetriggerOnComments = "comments"
+	// RetriggerOnUpdates re-triggers on any update, such as new comments or
+	// edits of the title, body or labels.
+	RetriggerOnUpdates = "updates"
+)
+
+// RetriggerPolicy defines when a new Task is created for an item.
+// Comments by GitHub App bots, by the user the workspace token belongs to,
+// and by IgnoreAuthors never re-trigger, so that the agent's own comments
+// do not start a new Task.
+type RetriggerPolicy struct {
+	// On selects the activity that re-triggers: "comments" for a new
+	// comment, or "updates" for a new comment or any other update of the
+	// item, as reported by its updated_at. Defaults to comments.
+	// +kubebuilder:validation:Enum=comments;updates
+	// +kubebuilder:default=comments
+	// +optional
+	On string `json:"on,omitempty"`
+
+	// IgnoreAuthors lists additional logins whose comments do not
+	// re-trigger, such as other automation accounts.
+	// +optional
+	IgnoreAuthors []string `json:"ignoreAuthors,omitempty"`
 }
 
 // TaskSpawnerStatus defines the observed state of TaskSpawner.
@@ -167,6 +741,59 @@ type TaskSpawnerStatus struct {
 	// Message provides additional information about the current status.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// RateLimit is the GitHub API rate limit last reported to the spawner,
+	// for GitHub sources.
+	// +optional
+	RateLimit *GitHubRateLimit `json:"rateLimit,omitempty"`
+
+	// RecentTasks records the Tasks created in the last 24 hours, oldest
+	// first, for spawners with maxTasksPerHour, maxTasksPerDay or
+	// dailyBudgetUSD. The limits are enforced from it, so they hold after
+	// the Tasks are deleted. At most 1000 Tasks are kept.
+	// +optional
+	// +kubebuilder:validation:MaxItems=1000
+	RecentTasks []SpawnedTask `json:"recentTasks,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the TaskSpawner.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SpawnedTask records a Task created by a spawner.
+type SpawnedTask struct {
+	// Name is the name of the Task.
+	Name string `json:"name"`
+
+	// CreationTime is when the Task was created.
+	CreationTime metav1.Time `json:"creationTime"`
+
+	// CostUSD is the cost of the Task last reported by its agent. It is
+	// kept once the Task is deleted.
+	// +optional
+	CostUSD string `json:"costUSD,omitempty"`
+}
+
+// GitHubRateLimit reports the GitHub API rate limit of a spawner's token.
+// When fewer than 100 requests remain, the spawner stops polling until the
+// rate limit resets.
+type GitHubRateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	// +optional
+	Limit int `json:"limit,omitempty"`
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int `json:"remaining"`
+
+	// ResetTime is when the current window ends.
+	// +optional
+	ResetTime *metav1.Time `json:"resetTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -16,6 +16,14 @@ type WorkspaceFile struct {
 	Content string `json:"content"`
 }
 
+// Git hosting providers supported by Workspace.
+const (
+	// WorkspaceProviderGitHub is GitHub or GitHub Enterprise Server.
+	WorkspaceProviderGitHub = "github"
+	// WorkspaceProviderGitLab is GitLab.com or a self-hosted GitLab.
+	WorkspaceProviderGitLab = "gitlab"
+)
+
 // WorkspaceSpec defines the desired state of Workspace.
 type WorkspaceSpec struct {
 	// Repo is the git repository URL to clone.
@@ -28,8 +36,17 @@ type WorkspaceSpec struct {
 	// +optional
 	Ref string `json:"ref,omitempty"`
 
+	// Provider is the git hosting provider of Repo (github or gitlab).
+	// Defaults to gitlab when the repo host is gitlab.com or starts with
+	// "gitlab.", and to github otherwise.
+	// +kubebuilder:validation:Enum=github;gitlab
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
 	// SecretRef references a Secret containing a GITHUB_TOKEN key for git
-	// authentication and GitHub CLI (gh) operations.
+	// authentication and GitHub CLI (gh) operations. For GitLab
+	// repositories the Secret must contain a GITLAB_TOKEN key instead,
+	// which is used for git authentication and the GitLab CLI (glab).
 	// +optional
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
 
@@ -41,14 +58,29 @@ type WorkspaceSpec struct {
 	Files []WorkspaceFile `json:"files,omitempty"`
 }
 
+// WorkspaceStatus defines the observed state of Workspace.
+type WorkspaceStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the Workspace.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // Workspace is the Schema for the workspaces API.
 type Workspace struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec WorkspaceSpec `json:"spec,omitempty"`
+	Spec   WorkspaceSpec   `json:"spec,omitempty"`
+	Status WorkspaceStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -64,6 +96,11 @@ type WorkspaceList struct {
 type WorkspaceReference struct {
 	// Name is the name of the Workspace resource.
 	Name string `json:"name"`
+
+	// Ref overrides the git reference of the Workspace to checkout
+	// (branch, tag, or commit SHA).
+	// +optional
+	Ref string `json:"ref,omitempty"`
 }
 
 func init() {
@@ -21,7 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,6 +32,7 @@ func (in *AgentConfig) DeepCopyInto(out *AgentConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentConfig.
@@ -120,6 +122,28 @@ func (in *AgentConfigSpec) DeepCopy() *AgentConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentConfigStatus) DeepCopyInto(out *AgentConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentConfigStatus.
+func (in *AgentConfigStatus) DeepCopy() *AgentConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentDefinition) DeepCopyInto(out *AgentDefinition) {
 	*out = *in
@@ -154,6 +178,11 @@ func (in *Credentials) DeepCopy() *Credentials {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cron) DeepCopyInto(out *Cron) {
 	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cron.
@@ -166,6 +195,26 @@ func (in *Cron) DeepCopy() *Cron {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldPredicate) DeepCopyInto(out *FieldPredicate) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldPredicate.
+func (in *FieldPredicate) DeepCopy() *FieldPredicate {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldPredicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitHubIssues) DeepCopyInto(out *GitHubIssues) {
 	*out = *in
@@ -184,6 +233,38 @@ func (in *GitHubIssues) DeepCopyInto(out *GitHubIssues) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Authors != nil {
+		in, out := &in.Authors, &out.Authors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeAuthors != nil {
+		in, out := &in.ExcludeAuthors, &out.ExcludeAuthors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthorAssociations != nil {
+		in, out := &in.AuthorAssociations, &out.AuthorAssociations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repos != nil {
+		in, out := &in.Repos, &out.Repos
+		*out = make([]GitHubRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Org != nil {
+		in, out := &in.Org, &out.Org
+		*out = new(GitHubOrganization)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(GitHubWebhook)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubIssues.
@@ -196,6 +277,256 @@ func (in *GitHubIssues) DeepCopy() *GitHubIssues {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubOrganization) DeepCopyInto(out *GitHubOrganization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubOrganization.
+func (in *GitHubOrganization) DeepCopy() *GitHubOrganization {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubOrganization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubPullRequests) DeepCopyInto(out *GitHubPullRequests) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLabels != nil {
+		in, out := &in.ExcludeLabels, &out.ExcludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Draft != nil {
+		in, out := &in.Draft, &out.Draft
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Repos != nil {
+		in, out := &in.Repos, &out.Repos
+		*out = make([]GitHubRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Org != nil {
+		in, out := &in.Org, &out.Org
+		*out = new(GitHubOrganization)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPullRequests.
+func (in *GitHubPullRequests) DeepCopy() *GitHubPullRequests {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubPullRequests)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubRateLimit) DeepCopyInto(out *GitHubRateLimit) {
+	*out = *in
+	if in.ResetTime != nil {
+		in, out := &in.ResetTime, &out.ResetTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRateLimit.
+func (in *GitHubRateLimit) DeepCopy() *GitHubRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubRepository) DeepCopyInto(out *GitHubRepository) {
+	*out = *in
+	if in.WorkspaceRef != nil {
+		in, out := &in.WorkspaceRef, &out.WorkspaceRef
+		*out = new(WorkspaceReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRepository.
+func (in *GitHubRepository) DeepCopy() *GitHubRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubWebhook) DeepCopyInto(out *GitHubWebhook) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhook.
+func (in *GitHubWebhook) DeepCopy() *GitHubWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabIssues) DeepCopyInto(out *GitLabIssues) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLabels != nil {
+		in, out := &in.ExcludeLabels, &out.ExcludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIssues.
+func (in *GitLabIssues) DeepCopy() *GitLabIssues {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabIssues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPJSON) DeepCopyInto(out *HTTPJSON) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	out.Fields = in.Fields
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPJSON.
+func (in *HTTPJSON) DeepCopy() *HTTPJSON {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPJSON)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPJSONFields) DeepCopyInto(out *HTTPJSONFields) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPJSONFields.
+func (in *HTTPJSONFields) DeepCopy() *HTTPJSONFields {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPJSONFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Jira) DeepCopyInto(out *Jira) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jira.
+func (in *Jira) DeepCopy() *Jira {
+	if in == nil {
+		return nil
+	}
+	out := new(Jira)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEvents) DeepCopyInto(out *KubernetesEvents) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Predicate != nil {
+		in, out := &in.Predicate, &out.Predicate
+		*out = new(FieldPredicate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEvents.
+func (in *KubernetesEvents) DeepCopy() *KubernetesEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelPriority) DeepCopyInto(out *LabelPriority) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelPriority.
+func (in *LabelPriority) DeepCopy() *LabelPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginSpec) DeepCopyInto(out *PluginSpec) {
 	*out = *in
@@ -226,7 +557,7 @@ func (in *PodOverrides) DeepCopyInto(out *PodOverrides) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ActiveDeadlineSeconds != nil {
@@ -236,7 +567,7 @@ func (in *PodOverrides) DeepCopyInto(out *PodOverrides) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -260,6 +591,91 @@ func (in *PodOverrides) DeepCopy() *PodOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Priority) DeepCopyInto(out *Priority) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]LabelPriority, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Priority.
+func (in *Priority) DeepCopy() *Priority {
+	if in == nil {
+		return nil
+	}
+	out := new(Priority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reporting) DeepCopyInto(out *Reporting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reporting.
+func (in *Reporting) DeepCopy() *Reporting {
+	if in == nil {
+		return nil
+	}
+	out := new(Reporting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetriggerPolicy) DeepCopyInto(out *RetriggerPolicy) {
+	*out = *in
+	if in.IgnoreAuthors != nil {
+		in, out := &in.IgnoreAuthors, &out.IgnoreAuthors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetriggerPolicy.
+func (in *RetriggerPolicy) DeepCopy() *RetriggerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetriggerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]FailureReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -290,6 +706,22 @@ func (in *SkillDefinition) DeepCopy() *SkillDefinition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpawnedTask) DeepCopyInto(out *SpawnedTask) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpawnedTask.
+func (in *SpawnedTask) DeepCopy() *SpawnedTask {
+	if in == nil {
+		return nil
+	}
+	out := new(SpawnedTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
@@ -317,6 +749,29 @@ func (in *Task) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskAttempt) DeepCopyInto(out *TaskAttempt) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttempt.
+func (in *TaskAttempt) DeepCopy() *TaskAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskList) DeepCopyInto(out *TaskList) {
 	*out = *in
@@ -418,6 +873,31 @@ func (in *TaskSpawnerSpec) DeepCopyInto(out *TaskSpawnerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxTasksPerHour != nil {
+		in, out := &in.MaxTasksPerHour, &out.MaxTasksPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTasksPerDay != nil {
+		in, out := &in.MaxTasksPerDay, &out.MaxTasksPerDay
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retrigger != nil {
+		in, out := &in.Retrigger, &out.Retrigger
+		*out = new(RetriggerPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reporting != nil {
+		in, out := &in.Reporting, &out.Reporting
+		*out = new(Reporting)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(Priority)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpawnerSpec.
@@ -437,6 +917,25 @@ func (in *TaskSpawnerStatus) DeepCopyInto(out *TaskSpawnerStatus) {
 		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
 		*out = (*in).DeepCopy()
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(GitHubRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecentTasks != nil {
+		in, out := &in.RecentTasks, &out.RecentTasks
+		*out = make([]SpawnedTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpawnerStatus.
@@ -473,6 +972,16 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 		*out = new(PodOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpec.
@@ -501,6 +1010,32 @@ func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PullRequestURLs != nil {
+		in, out := &in.PullRequestURLs, &out.PullRequestURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]TaskAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
@@ -537,6 +1072,11 @@ func (in *TaskTemplate) DeepCopyInto(out *TaskTemplate) {
 		*out = new(PodOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplate.
@@ -557,10 +1097,35 @@ func (in *When) DeepCopyInto(out *When) {
 		*out = new(GitHubIssues)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitHubPullRequests != nil {
+		in, out := &in.GitHubPullRequests, &out.GitHubPullRequests
+		*out = new(GitHubPullRequests)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLabIssues != nil {
+		in, out := &in.GitLabIssues, &out.GitLabIssues
+		*out = new(GitLabIssues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jira != nil {
+		in, out := &in.Jira, &out.Jira
+		*out = new(Jira)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cron != nil {
 		in, out := &in.Cron, &out.Cron
 		*out = new(Cron)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPJSON != nil {
+		in, out := &in.HTTPJSON, &out.HTTPJSON
+		*out = new(HTTPJSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesEvents != nil {
+		in, out := &in.KubernetesEvents, &out.KubernetesEvents
+		*out = new(KubernetesEvents)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -580,6 +1145,7 @@ func (in *Workspace) DeepCopyInto(out *Workspace) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workspace.
@@ -686,3 +1252,25 @@ func (in *WorkspaceSpec) DeepCopy() *WorkspaceSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStatus.
+func (in *WorkspaceStatus) DeepCopy() *WorkspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
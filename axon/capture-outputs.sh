@@ -1,7 +1,10 @@
 #!/bin/bash
-# Captures deterministic outputs (branch, PRs) from the workspace after
-# the agent finishes. Emits structured markers to stdout for the controller
-# to parse from Pod logs.
+# Captures deterministic outputs (branch, commit, PRs) from the workspace
+# after the agent finishes. Emits structured markers to stdout and to the
+# container termination log for the controller to parse.
+#
+# Each line between the markers is either a "key: value" pair, a JSON
+# object of results, or a bare pull request URL.
 
 OUTPUTS=""
 
@@ -10,6 +13,10 @@ if git rev-parse --is-inside-work-tree >/dev/null 2>&1; then
     BRANCH=$(git branch --show-current 2>/dev/null)
     if [ -n "$BRANCH" ]; then
         OUTPUTS="branch: $BRANCH"
+        COMMIT=$(git rev-parse HEAD 2>/dev/null)
+        if [ -n "$COMMIT" ]; then
+            OUTPUTS="$OUTPUTS"$'\n'"commit: $COMMIT"
+        fi
         # Query PRs for this branch (requires GH_TOKEN / GITHUB_TOKEN)
         if command -v gh >/dev/null 2>&1; then
             PR_URLS=$(timeout 10 gh pr list --head "$BRANCH" --json url --jq '.[].url' 2>/dev/null)
@@ -20,8 +27,22 @@ if git rev-parse --is-inside-work-tree >/dev/null 2>&1; then
     fi
 fi
 
+# Append results written by the agent, one "key: value" pair or JSON
+# object per line
+if [ -n "${AXON_RESULTS_FILE:-}" ] && [ -s "$AXON_RESULTS_FILE" ]; then
+    while IFS= read -r line || [ -n "$line" ]; do
+        if [ -n "$line" ]; then
+            OUTPUTS="${OUTPUTS:+$OUTPUTS$'\n'}$line"
+        fi
+    done < "$AXON_RESULTS_FILE"
+fi
+
 if [ -n "$OUTPUTS" ]; then
-    echo "---AXON_OUTPUTS_START---"
-    echo "$OUTPUTS"
-    echo "---AXON_OUTPUTS_END---"
+    BLOCK="---AXON_OUTPUTS_START---"$'\n'"$OUTPUTS"$'\n'"---AXON_OUTPUTS_END---"
+    echo "$BLOCK"
+    # The controller reads the termination message first and falls back to
+    # the Pod logs when it is missing or truncated.
+    if [ -w /dev/termination-log ]; then
+        echo "$BLOCK" > /dev/termination-log
+    fi
 fi
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+// Reasons for the Events recorded when applying the cron policy.
+const (
+	reasonRunSkipped   = "RunSkipped"
+	reasonTaskReplaced = "TaskReplaced"
+)
+
+// applyCronPolicy selects the cron runs to create Tasks for among items,
+// the runs due since the last discovery, oldest first. Like a Kubernetes
+// CronJob, only the most recent run is started, and only if it is within
+// the starting deadline. The concurrency policy is then applied against
+// tasks, the existing Tasks of ts. It returns the selected runs and the
+// number of running Tasks cancelled to make room for them.
+func applyCronPolicy(ctx context.Context, cl client.Client, recorder events.EventRecorder, ts *axonv1alpha1.TaskSpawner, items []source.WorkItem, tasks []axonv1alpha1.Task, now time.Time) ([]source.WorkItem, int, error) {
+	log := ctrl.Log.WithName("spawner")
+	if len(items) == 0 {
+		return nil, 0, nil
+	}
+
+	cronSpec := ts.Spec.When.Cron
+	latest := items[len(items)-1]
+	if missed := len(items) - 1; missed > 0 {
+		log.Info("Skipping missed cron runs", "count", missed, "latest", latest.Time)
+	}
+
+	if cronSpec.StartingDeadlineSeconds != nil {
+		scheduled, err := time.Parse(time.RFC3339, latest.Time)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parsing time of cron run %s: %w", latest.ID, err)
+		}
+		if now.Sub(scheduled) > time.Duration(*cronSpec.StartingDeadlineSeconds)*time.Second {
+			log.Info("Skipping cron run past its starting deadline", "scheduled", latest.Time)
+			recorder.Eventf(ts, nil, corev1.EventTypeWarning, reasonRunSkipped, "Schedule", "Skipped run scheduled at %s: missed its starting deadline", latest.Time)
+			return nil, 0, nil
+		}
+	}
+
+	var running []*axonv1alpha1.Task
+	for i := range tasks {
+		if !isTaskFinished(&tasks[i]) {
+			running = append(running, &tasks[i])
+		}
+	}
+	if len(running) == 0 {
+		return []source.WorkItem{latest}, 0, nil
+	}
+
+	switch cronSpec.ConcurrencyPolicy {
+	case axonv1alpha1.ConcurrencyPolicyForbid:
+		log.Info("Skipping cron run while earlier Tasks are running", "scheduled", latest.Time, "running", len(running))
+		recorder.Eventf(ts, nil, corev1.EventTypeNormal, reasonRunSkipped, "Schedule", "Skipped run scheduled at %s: %d Tasks still running", latest.Time, len(running))
+		return nil, 0, nil
+	case axonv1alpha1.ConcurrencyPolicyReplace:
+		for _, t := range running {
+			if t.Spec.Cancel {
+				continue
+			}
+			patch := client.MergeFrom(t.DeepCopy())
+			t.Spec.Cancel = true
+			if err := cl.Patch(ctx, t, patch); err != nil {
+				return nil, 0, fmt.Errorf("cancelling Task %s: %w", t.Name, err)
+			}
+			log.Info("Cancelled Task to replace it with a new cron run", "task", t.Name, "scheduled", latest.Time)
+			recorder.Eventf(ts, t, corev1.EventTypeNormal, reasonTaskReplaced, "Cancel", "Cancelled Task %s for run scheduled at %s", t.Name, latest.Time)
+		}
+		return []source.WorkItem{latest}, len(running), nil
+	}
+	return []source.WorkItem{latest}, 0, nil
+}
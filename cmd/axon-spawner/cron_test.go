@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/events"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+func newCronTaskSpawner(policy axonv1alpha1.ConcurrencyPolicy, startingDeadlineSeconds *int64) *axonv1alpha1.TaskSpawner {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{
+		Schedule:                "* * * * *",
+		ConcurrencyPolicy:       policy,
+		StartingDeadlineSeconds: startingDeadlineSeconds,
+	}}
+	ts.Spec.TaskTemplate.WorkspaceRef = nil
+	return ts
+}
+
+// cronItems returns the work items of the runs scheduled ago before now,
+// oldest first.
+func cronItems(ago ...time.Duration) []source.WorkItem {
+	now := time.Now().UTC()
+	var items []source.WorkItem
+	for _, d := range ago {
+		tick := now.Add(-d).Truncate(time.Minute)
+		items = append(items, source.WorkItem{
+			ID:       tick.Format("20060102-1504"),
+			Time:     tick.Format(time.RFC3339),
+			Schedule: "* * * * *",
+		})
+	}
+	return items
+}
+
+func TestRunCycleWithSource_CronStartsOnlyLatestMissedRun(t *testing.T) {
+	ts := newCronTaskSpawner("", nil)
+	cl, key := setupTest(t, ts)
+
+	items := cronItems(3*time.Minute, 2*time.Minute, time.Minute)
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, &fakeSource{items: items}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := listTaskNames(t, cl)
+	if len(names) != 1 || !names["spawner-"+items[2].ID] {
+		t.Errorf("Expected only the Task of the latest run, got %v", names)
+	}
+}
+
+func TestRunCycleWithSource_CronStartsLatestRunAfterLongOutage(t *testing.T) {
+	deadline := int64(120)
+	ts := newCronTaskSpawner("", &deadline)
+	cl, key := setupTest(t, ts)
+
+	// A day of missed minutely runs is more than the source returns.
+	before := time.Now().UTC().Truncate(time.Minute)
+	src := &source.CronSource{Schedule: "* * * * *", LastDiscoveryTime: before.Add(-24 * time.Hour)}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	after := time.Now().UTC().Truncate(time.Minute)
+
+	names := listTaskNames(t, cl)
+	if len(names) != 1 || !(names["spawner-"+before.Format("20060102-1504")] || names["spawner-"+after.Format("20060102-1504")]) {
+		t.Errorf("Expected only the Task of the current run, got %v", names)
+	}
+}
+
+func TestRunCycleWithSource_CronStartingDeadline(t *testing.T) {
+	deadline := int64(120)
+	tests := []struct {
+		name      string
+		ago       time.Duration
+		wantTasks int
+	}{
+		{name: "within deadline", ago: time.Minute, wantTasks: 1},
+		{name: "past deadline", ago: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newCronTaskSpawner("", &deadline)
+			cl, key := setupTest(t, ts)
+
+			src := &fakeSource{items: cronItems(tt.ago)}
+			if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if names := listTaskNames(t, cl); len(names) != tt.wantTasks {
+				t.Errorf("Expected %d Tasks, got %v", tt.wantTasks, names)
+			}
+		})
+	}
+}
+
+func TestRunCycleWithSource_CronConcurrencyPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         axonv1alpha1.ConcurrencyPolicy
+		phase          axonv1alpha1.TaskPhase
+		maxConcurrency *int32
+		wantTasks      int
+		wantCancel     bool
+	}{
+		{name: "allow", policy: axonv1alpha1.ConcurrencyPolicyAllow, phase: axonv1alpha1.TaskPhaseRunning, wantTasks: 2},
+		{name: "forbid", policy: axonv1alpha1.ConcurrencyPolicyForbid, phase: axonv1alpha1.TaskPhaseRunning, wantTasks: 1},
+		{name: "forbid after previous run finished", policy: axonv1alpha1.ConcurrencyPolicyForbid, phase: axonv1alpha1.TaskPhaseSucceeded, wantTasks: 2},
+		{name: "replace", policy: axonv1alpha1.ConcurrencyPolicyReplace, phase: axonv1alpha1.TaskPhaseRunning, wantTasks: 2, wantCancel: true},
+		// The cancelled Task does not count against maxConcurrency.
+		{name: "replace at max concurrency", policy: axonv1alpha1.ConcurrencyPolicyReplace, phase: axonv1alpha1.TaskPhaseRunning, maxConcurrency: int32Ptr(1), wantTasks: 2, wantCancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newCronTaskSpawner(tt.policy, nil)
+			ts.Spec.MaxConcurrency = tt.maxConcurrency
+			previous := newTask("spawner-previous", "default", "spawner", tt.phase)
+			cl, key := setupTest(t, ts, previous)
+
+			if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, &fakeSource{items: cronItems(time.Minute)}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if names := listTaskNames(t, cl); len(names) != tt.wantTasks {
+				t.Errorf("Expected %d Tasks, got %v", tt.wantTasks, names)
+			}
+			if got := getTask(t, cl, "spawner-previous").Spec.Cancel; got != tt.wantCancel {
+				t.Errorf("Expected cancel of the previous Task to be %v, got %v", tt.wantCancel, got)
+			}
+		})
+	}
+}
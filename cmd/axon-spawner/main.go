@@ -2,19 +2,28 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"time"
+	// Embed the time zone database so that cron time zones do not depend on
+	// the image.
+	_ "time/tzdata"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -25,6 +34,13 @@ import (
 
 var scheme = runtime.NewScheme()
 
+// Reasons for the Events recorded on the TaskSpawner.
+const (
+	reasonTaskCreated     = "TaskCreated"
+	reasonDiscoveryFailed = "DiscoveryFailed"
+	reasonRateLimited     = "RateLimited"
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(axonv1alpha1.AddToScheme(scheme))
@@ -33,21 +49,26 @@ func init() {
 func main() {
 	var name string
 	var namespace string
-	var githubOwner string
-	var githubRepo string
-	var githubAPIBaseURL string
-	var githubTokenFile string
+	var cfg sourceConfig
+	var metricsAddr string
+	var webhookAddr string
 
 	flag.StringVar(&name, "taskspawner-name", "", "Name of the TaskSpawner to manage")
 	flag.StringVar(&namespace, "taskspawner-namespace", "", "Namespace of the TaskSpawner")
-	flag.StringVar(&githubOwner, "github-owner", "", "GitHub repository owner")
-	flag.StringVar(&githubRepo, "github-repo", "", "GitHub repository name")
-	flag.StringVar(&githubAPIBaseURL, "github-api-base-url", "", "GitHub API base URL for enterprise servers (e.g. https://github.example.com/api/v3)")
-	flag.StringVar(&githubTokenFile, "github-token-file", "", "Path to file containing GitHub token (refreshed by sidecar)")
+	flag.StringVar(&cfg.githubOwner, "github-owner", "", "GitHub repository owner")
+	flag.StringVar(&cfg.githubRepo, "github-repo", "", "GitHub repository name")
+	flag.StringVar(&cfg.githubAPIBaseURL, "github-api-base-url", "", "GitHub API base URL for enterprise servers (e.g. https://github.example.com/api/v3)")
+	flag.StringVar(&cfg.githubTokenFile, "github-token-file", "", "Path to file containing GitHub token (refreshed by sidecar)")
+	flag.StringVar(&cfg.gitlabProject, "gitlab-project", "", "GitLab project path (e.g. group/subgroup/project)")
+	flag.StringVar(&cfg.gitlabAPIBaseURL, "gitlab-api-base-url", "", "GitLab API base URL for self-hosted instances (e.g. https://gitlab.example.com/api/v4)")
+	flag.StringVar(&cfg.httpHeadersDir, "http-headers-dir", "", "Directory with the request headers of an httpJSON source, one file per header")
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to. Set to 0 to disable.")
+	flag.StringVar(&webhookAddr, "webhook-bind-address", "0", "The address the GitHub webhook endpoint binds to. Set to 0 to disable. Requires GITHUB_WEBHOOK_SECRET.")
 
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
+	cfg.githubCache = source.NewGitHubCache()
 
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
@@ -58,25 +79,68 @@ func main() {
 		os.Exit(1)
 	}
 
-	cfg, err := ctrl.GetConfig()
+	restCfg, err := ctrl.GetConfig()
 	if err != nil {
 		log.Error(err, "unable to get kubeconfig")
 		os.Exit(1)
 	}
 
-	cl, err := client.New(cfg, client.Options{Scheme: scheme})
+	cl, err := client.New(restCfg, client.Options{Scheme: scheme})
 	if err != nil {
 		log.Error(err, "unable to create client")
 		os.Exit(1)
 	}
 
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		log.Error(err, "unable to create Kubernetes clientset")
+		os.Exit(1)
+	}
+	cfg.kubeClient = cl
+	cfg.kubeClientset = clientset
+
 	ctx := ctrl.SetupSignalHandler()
 	key := types.NamespacedName{Name: name, Namespace: namespace}
 
+	broadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: clientset.EventsV1()})
+	if err := broadcaster.StartRecordingToSinkWithContext(ctx); err != nil {
+		log.Error(err, "unable to start event recording")
+		os.Exit(1)
+	}
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme, "axon-spawner")
+
+	serveMetrics(ctx, metricsAddr)
+
+	var deliveries chan webhookDelivery
+	processor := &deliveryProcessor{
+		cl:       cl,
+		recorder: recorder,
+		key:      key,
+		buildSource: func(ts *axonv1alpha1.TaskSpawner) (source.Source, error) {
+			return buildSource(ts, cfg)
+		},
+	}
+	if webhookAddr != "0" {
+		secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+		if secret == "" {
+			log.Error(fmt.Errorf("GITHUB_WEBHOOK_SECRET is not set"), "unable to serve GitHub webhook")
+			os.Exit(1)
+		}
+		deliveries = make(chan webhookDelivery, webhookQueueSize)
+		serveWebhook(ctx, webhookAddr, &webhookHandler{
+			secret:     []byte(secret),
+			deliveries: deliveries,
+		})
+	}
+
+	go runReporter(ctx, cl, recorder, key, cfg)
+
 	log.Info("starting spawner", "taskspawner", key)
 
 	for {
-		if err := runCycle(ctx, cl, key, githubOwner, githubRepo, githubAPIBaseURL, githubTokenFile); err != nil {
+		err := runCycle(ctx, cl, recorder, key, cfg)
+		if err != nil {
 			log.Error(err, "discovery cycle failed")
 		}
 
@@ -84,33 +148,62 @@ func main() {
 		var ts axonv1alpha1.TaskSpawner
 		if err := cl.Get(ctx, key, &ts); err != nil {
 			log.Error(err, "unable to fetch TaskSpawner for poll interval")
-			sleepOrDone(ctx, 5*time.Minute)
+			if done := waitForNextCycle(ctx, 5*time.Minute, deliveries, processor); done {
+				return
+			}
 			continue
 		}
 
 		interval := parsePollInterval(ts.Spec.PollInterval)
+		var rateLimitErr *source.RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			interval = max(interval, time.Until(rateLimitErr.RateLimit.Reset))
+		}
 		log.Info("sleeping until next cycle", "interval", interval)
-		if done := sleepOrDone(ctx, interval); done {
+		if done := waitForNextCycle(ctx, interval, deliveries, processor); done {
 			return
 		}
 	}
 }
 
-func runCycle(ctx context.Context, cl client.Client, key types.NamespacedName, githubOwner, githubRepo, githubAPIBaseURL, githubTokenFile string) error {
+// sourceConfig holds the flags that locate the repository or project polled
+// by a TaskSpawner source, and the state its sources keep between cycles.
+type sourceConfig struct {
+	githubOwner      string
+	githubRepo       string
+	githubAPIBaseURL string
+	githubTokenFile  string
+	gitlabProject    string
+	gitlabAPIBaseURL string
+	// httpHeadersDir holds the request headers of an httpJSON source, one
+	// file per header.
+	httpHeadersDir string
+	// kubeClient and kubeClientset read the objects, Events and logs of a
+	// kubernetesEvents source.
+	kubeClient    client.Reader
+	kubeClientset kubernetes.Interface
+	// githubCache is shared by the GitHub sources of all cycles.
+	githubCache *source.GitHubCache
+}
+
+func runCycle(ctx context.Context, cl client.Client, recorder events.EventRecorder, key types.NamespacedName, cfg sourceConfig) error {
 	var ts axonv1alpha1.TaskSpawner
 	if err := cl.Get(ctx, key, &ts); err != nil {
 		return fmt.Errorf("fetching TaskSpawner: %w", err)
 	}
 
-	src, err := buildSource(&ts, githubOwner, githubRepo, githubAPIBaseURL, githubTokenFile)
+	src, err := buildSource(&ts, cfg)
 	if err != nil {
+		recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonDiscoveryFailed, "Discover", "Failed to build source: %v", err)
+		discoveryErrorsTotal.WithLabelValues(ts.Namespace, ts.Name).Inc()
+		markSourceUnhealthy(ctx, cl, key, fmt.Sprintf("Failed to build source: %v", err))
 		return fmt.Errorf("building source: %w", err)
 	}
 
-	return runCycleWithSource(ctx, cl, key, src)
+	return runCycleWithSource(ctx, cl, recorder, key, src)
 }
 
-func runCycleWithSource(ctx context.Context, cl client.Client, key types.NamespacedName, src source.Source) error {
+func runCycleWithSource(ctx context.Context, cl client.Client, recorder events.EventRecorder, key types.NamespacedName, src source.Source) error {
 	log := ctrl.Log.WithName("spawner")
 
 	var ts axonv1alpha1.TaskSpawner
@@ -118,12 +211,62 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 		return fmt.Errorf("fetching TaskSpawner: %w", err)
 	}
 
+	if ts.Spec.Suspend {
+		log.Info("TaskSpawner is suspended, skipping discovery")
+		if ts.Status.Phase == axonv1alpha1.TaskSpawnerPhaseSuspended {
+			return nil
+		}
+		ts.Status.Phase = axonv1alpha1.TaskSpawnerPhaseSuspended
+		ts.Status.Message = "Suspended"
+		if err := cl.Status().Update(ctx, &ts); err != nil {
+			return fmt.Errorf("updating TaskSpawner status: %w", err)
+		}
+		return nil
+	}
+
+	// Webhook deliveries carry a single item rather than the result of a
+	// discovery, so they do not update the discovery metrics and status.
+	_, delivery := src.(*webhookSource)
+
+	start := time.Now()
 	items, err := src.Discover(ctx)
+	if !delivery {
+		discoveryDurationSeconds.WithLabelValues(ts.Namespace, ts.Name).Observe(time.Since(start).Seconds())
+	}
+	observeSourceMetrics(&ts, src)
+	var rateLimitErr *source.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		log.Info("Skipping discovery until the GitHub API rate limit resets", "remaining", rateLimitErr.RateLimit.Remaining, "reset", rateLimitErr.RateLimit.Reset)
+		recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonRateLimited, "Discover", "%v", err)
+		markRateLimited(ctx, cl, key, rateLimitErr)
+		return fmt.Errorf("discovering items: %w", err)
+	}
 	if err != nil {
+		discoveryErrorsTotal.WithLabelValues(ts.Namespace, ts.Name).Inc()
+		recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonDiscoveryFailed, "Discover", "Failed to discover work items: %v", err)
+		markSourceUnhealthy(ctx, cl, key, fmt.Sprintf("Failed to discover work items: %v", err))
 		return fmt.Errorf("discovering items: %w", err)
 	}
+	if multi, ok := src.(repoErrorsSource); ok {
+		for _, repoErr := range multi.RepoErrors() {
+			log.Error(repoErr, "Skipping repository")
+			recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonDiscoveryFailed, "Discover", "Skipping repository: %v", repoErr)
+		}
+		if len(multi.RepoErrors()) > 0 {
+			discoveryErrorsTotal.WithLabelValues(ts.Namespace, ts.Name).Inc()
+		}
+	}
+	if skipping, ok := src.(skippedErrorsSource); ok {
+		for _, skipErr := range skipping.SkippedErrors() {
+			log.Error(skipErr, "Skipping Event")
+		}
+	}
 
 	log.Info("discovered items", "count", len(items))
+	if !delivery {
+		itemsDiscovered.WithLabelValues(ts.Namespace, ts.Name).Set(float64(len(items)))
+		lastDiscoveryTimestampSeconds.WithLabelValues(ts.Namespace, ts.Name).SetToCurrentTime()
+	}
 
 	// Build set of already-created Tasks by listing them from the API.
 	// This is resilient to spawner restarts (status may lag behind actual Tasks).
@@ -135,21 +278,45 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 		return fmt.Errorf("listing existing Tasks: %w", err)
 	}
 
-	existingTasks := make(map[string]bool)
+	existingTasks := make(map[string]*axonv1alpha1.Task)
 	activeTasks := 0
-	for _, t := range existingTaskList.Items {
-		existingTasks[t.Name] = true
-		if t.Status.Phase != axonv1alpha1.TaskPhaseSucceeded && t.Status.Phase != axonv1alpha1.TaskPhaseFailed {
+	for i := range existingTaskList.Items {
+		t := &existingTaskList.Items[i]
+		existingTasks[t.Name] = t
+		if !isTaskFinished(t) {
 			activeTasks++
 		}
 	}
 
 	var newItems []source.WorkItem
+	taskNames := make(map[string]string)
 	for _, item := range items {
 		taskName := fmt.Sprintf("%s-%s", ts.Name, item.ID)
-		if !existingTasks[taskName] {
-			newItems = append(newItems, item)
+		if _, ok := existingTasks[taskName]; ok {
+			if ts.Spec.Retrigger == nil {
+				continue
+			}
+			taskName = retriggerTaskName(ts.Spec.Retrigger, taskName, item, existingTasks)
+			if taskName == "" {
+				continue
+			}
+			log.Info("Item has new activity, re-triggering", "item", item.ID, "task", taskName)
 		}
+		taskNames[item.ID] = taskName
+		newItems = append(newItems, item)
+	}
+
+	if ts.Spec.Priority != nil {
+		prioritize(ts.Spec.Priority, newItems)
+	}
+
+	if ts.Spec.When.Cron != nil {
+		var cancelled int
+		newItems, cancelled, err = applyCronPolicy(ctx, cl, recorder, &ts, newItems, existingTaskList.Items, time.Now())
+		if err != nil {
+			return fmt.Errorf("applying cron policy: %w", err)
+		}
+		activeTasks -= cancelled
 	}
 
 	maxConcurrency := int32(0)
@@ -157,15 +324,27 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 		maxConcurrency = *ts.Spec.MaxConcurrency
 	}
 
+	recent := recentTasks(&ts, existingTaskList.Items, time.Now())
+	limit := remainingTasks(&ts, recent, time.Now())
+	var throttledReason, throttledMessage string
+
 	newTasksCreated := 0
-	for _, item := range newItems {
+	for i, item := range newItems {
 		// Enforce max concurrency limit
 		if maxConcurrency > 0 && int32(activeTasks) >= maxConcurrency {
 			log.Info("Max concurrency reached, skipping remaining items", "activeTasks", activeTasks, "maxConcurrency", maxConcurrency)
 			break
 		}
 
-		taskName := fmt.Sprintf("%s-%s", ts.Name, item.ID)
+		// Enforce rate and budget limits
+		if limit.remaining >= 0 && newTasksCreated >= limit.remaining {
+			throttledReason = limit.reason
+			throttledMessage = fmt.Sprintf("Throttled by %s: %s, skipped %d items", limit.reason, limit.message(newTasksCreated), len(newItems)-i)
+			log.Info("Rate or budget limit reached, skipping remaining items", "limit", limit.reason, "skipped", len(newItems)-i)
+			break
+		}
+
+		taskName := taskNames[item.ID]
 
 		prompt, err := source.RenderPrompt(ts.Spec.TaskTemplate.PromptTemplate, item)
 		if err != nil {
@@ -180,6 +359,7 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 				Labels: map[string]string{
 					"axon.io/taskspawner": ts.Name,
 				},
+				Annotations: taskAnnotations(item),
 			},
 			Spec: axonv1alpha1.TaskSpec{
 				Type:                    ts.Spec.TaskTemplate.Type,
@@ -188,13 +368,17 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 				Model:                   ts.Spec.TaskTemplate.Model,
 				Image:                   ts.Spec.TaskTemplate.Image,
 				TTLSecondsAfterFinished: ts.Spec.TaskTemplate.TTLSecondsAfterFinished,
-				PodOverrides:            ts.Spec.TaskTemplate.PodOverrides,
+				PodOverrides:            taskPodOverrides(&ts, item),
+				RetryPolicy:             ts.Spec.TaskTemplate.RetryPolicy,
 			},
 		}
 
-		if ts.Spec.TaskTemplate.WorkspaceRef != nil {
-			task.Spec.WorkspaceRef = ts.Spec.TaskTemplate.WorkspaceRef
+		workspaceRef, err := itemWorkspaceRef(ctx, cl, &ts, item)
+		if err != nil {
+			log.Error(err, "resolving Workspace", "item", item.ID)
+			continue
 		}
+		task.Spec.WorkspaceRef = workspaceRef
 
 		if ts.Spec.TaskTemplate.AgentConfigRef != nil {
 			task.Spec.AgentConfigRef = ts.Spec.TaskTemplate.AgentConfigRef
@@ -210,22 +394,72 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 		}
 
 		log.Info("Created Task", "task", taskName, "item", item.ID)
+		recorder.Eventf(&ts, task, corev1.EventTypeNormal, reasonTaskCreated, "Create", "Created Task %s for item %s", taskName, item.ID)
+		created := task.CreationTimestamp
+		if created.IsZero() {
+			created = metav1.Now()
+		}
+		recent = append(recent, axonv1alpha1.SpawnedTask{Name: taskName, CreationTime: created})
 		newTasksCreated++
 		activeTasks++
+		tasksCreatedTotal.WithLabelValues(ts.Namespace, ts.Name).Inc()
 	}
+	activeTasksGauge.WithLabelValues(ts.Namespace, ts.Name).Set(float64(activeTasks))
 
 	// Update status in a single batch
 	if err := cl.Get(ctx, key, &ts); err != nil {
 		return fmt.Errorf("re-fetching TaskSpawner for status update: %w", err)
 	}
 
-	now := metav1.Now()
 	ts.Status.Phase = axonv1alpha1.TaskSpawnerPhaseRunning
-	ts.Status.LastDiscoveryTime = &now
-	ts.Status.TotalDiscovered = len(items)
+	if throttledReason != "" {
+		ts.Status.Phase = axonv1alpha1.TaskSpawnerPhaseThrottled
+		if !meta.IsStatusConditionTrue(ts.Status.Conditions, axonv1alpha1.ConditionThrottled) {
+			recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonThrottled, "Create", "%s", throttledMessage)
+		}
+		meta.SetStatusCondition(&ts.Status.Conditions, metav1.Condition{
+			Type:               axonv1alpha1.ConditionThrottled,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: ts.Generation,
+			Reason:             throttledReason,
+			Message:            throttledMessage,
+		})
+	} else if hasTaskLimits(&ts) {
+		meta.SetStatusCondition(&ts.Status.Conditions, metav1.Condition{
+			Type:               axonv1alpha1.ConditionThrottled,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: ts.Generation,
+			Reason:             reasonWithinLimits,
+			Message:            "No rate or budget limit reached",
+		})
+	} else {
+		meta.RemoveStatusCondition(&ts.Status.Conditions, axonv1alpha1.ConditionThrottled)
+	}
 	ts.Status.TotalTasksCreated += newTasksCreated
+	ts.Status.RecentTasks = nil
+	if hasTaskLimits(&ts) {
+		ts.Status.RecentTasks = recent[max(len(recent)-maxRecentTasks, 0):]
+	}
+	if rl := rateLimitStatus(src); rl != nil {
+		ts.Status.RateLimit = rl
+	}
 	ts.Status.ActiveTasks = activeTasks
+	if !delivery {
+		now := metav1.Now()
+		ts.Status.LastDiscoveryTime = &now
+		ts.Status.TotalDiscovered = len(items)
+		meta.SetStatusCondition(&ts.Status.Conditions, metav1.Condition{
+			Type:               axonv1alpha1.ConditionSourceHealthy,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: ts.Generation,
+			Reason:             "DiscoverySucceeded",
+			Message:            fmt.Sprintf("Discovered %d items", len(items)),
+		})
+	}
 	ts.Status.Message = fmt.Sprintf("Discovered %d items, created %d tasks total", ts.Status.TotalDiscovered, ts.Status.TotalTasksCreated)
+	if throttledReason != "" {
+		ts.Status.Message = throttledMessage
+	}
 
 	if err := cl.Status().Update(ctx, &ts); err != nil {
 		return fmt.Errorf("updating TaskSpawner status: %w", err)
@@ -234,33 +468,167 @@ func runCycleWithSource(ctx context.Context, cl client.Client, key types.Namespa
 	return nil
 }
 
-func buildSource(ts *axonv1alpha1.TaskSpawner, owner, repo, apiBaseURL, tokenFile string) (source.Source, error) {
+// markRateLimited sets the SourceHealthy condition of the TaskSpawner to
+// False while discovery waits for the GitHub API rate limit to reset, and
+// records the rate limit in its status.
+func markRateLimited(ctx context.Context, cl client.Client, key types.NamespacedName, rateLimitErr *source.RateLimitError) {
+	var ts axonv1alpha1.TaskSpawner
+	if err := cl.Get(ctx, key, &ts); err != nil {
+		ctrl.Log.WithName("spawner").Error(err, "Unable to fetch TaskSpawner for status update")
+		return
+	}
+	ts.Status.RateLimit = githubRateLimit(rateLimitErr.RateLimit)
+	meta.SetStatusCondition(&ts.Status.Conditions, metav1.Condition{
+		Type:               axonv1alpha1.ConditionSourceHealthy,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: ts.Generation,
+		Reason:             reasonRateLimited,
+		Message:            rateLimitErr.Error(),
+	})
+	if err := cl.Status().Update(ctx, &ts); err != nil {
+		ctrl.Log.WithName("spawner").Error(err, "Unable to update TaskSpawner status")
+	}
+}
+
+// rateLimitStatus returns the GitHub API rate limit last reported to src,
+// or nil if src is not a GitHub source or no response reported it.
+func rateLimitStatus(src source.Source) *axonv1alpha1.GitHubRateLimit {
+	gh, ok := src.(githubAPISource)
+	if !ok {
+		return nil
+	}
+	rl, ok := gh.RateLimit()
+	if !ok {
+		return nil
+	}
+	return githubRateLimit(rl)
+}
+
+func githubRateLimit(rl source.RateLimit) *axonv1alpha1.GitHubRateLimit {
+	status := &axonv1alpha1.GitHubRateLimit{Limit: rl.Limit, Remaining: rl.Remaining}
+	if !rl.Reset.IsZero() {
+		reset := metav1.NewTime(rl.Reset)
+		status.ResetTime = &reset
+	}
+	return status
+}
+
+// markSourceUnhealthy sets the SourceHealthy condition of the TaskSpawner
+// to False. Failures are logged, since the caller is already reporting an
+// error.
+func markSourceUnhealthy(ctx context.Context, cl client.Client, key types.NamespacedName, message string) {
+	var ts axonv1alpha1.TaskSpawner
+	if err := cl.Get(ctx, key, &ts); err != nil {
+		ctrl.Log.WithName("spawner").Error(err, "Unable to fetch TaskSpawner for status update")
+		return
+	}
+	meta.SetStatusCondition(&ts.Status.Conditions, metav1.Condition{
+		Type:               axonv1alpha1.ConditionSourceHealthy,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: ts.Generation,
+		Reason:             reasonDiscoveryFailed,
+		Message:            message,
+	})
+	if err := cl.Status().Update(ctx, &ts); err != nil {
+		ctrl.Log.WithName("spawner").Error(err, "Unable to update TaskSpawner status")
+	}
+}
+
+func buildSource(ts *axonv1alpha1.TaskSpawner, cfg sourceConfig) (source.Source, error) {
 	if ts.Spec.When.GitHubIssues != nil {
 		gh := ts.Spec.When.GitHubIssues
 
-		token := os.Getenv("GITHUB_TOKEN")
-		if tokenFile != "" {
-			data, err := os.ReadFile(tokenFile)
-			if err != nil {
-				if os.IsNotExist(err) {
-					ctrl.Log.WithName("spawner").Info("Token file not yet available, proceeding without token", "path", tokenFile)
-				} else {
-					return nil, fmt.Errorf("reading token file %s: %w", tokenFile, err)
-				}
-			} else {
-				token = strings.TrimSpace(string(data))
-			}
+		token, err := readGitHubToken(cfg.githubTokenFile)
+		if err != nil {
+			return nil, err
+		}
+
+		src := &source.GitHubSource{
+			Owner:              cfg.githubOwner,
+			Repo:               cfg.githubRepo,
+			Types:              gh.Types,
+			Labels:             gh.Labels,
+			ExcludeLabels:      gh.ExcludeLabels,
+			State:              gh.State,
+			Token:              token,
+			BaseURL:            cfg.githubAPIBaseURL,
+			Cache:              cfg.githubCache,
+			Authors:            gh.Authors,
+			ExcludeAuthors:     gh.ExcludeAuthors,
+			AuthorAssociations: gh.AuthorAssociations,
+		}
+		setIgnoredAuthors(src, ts.Spec.Retrigger)
+		return withRepos(src, gh.Repos, gh.Org, token, cfg, func(owner, repo string) source.Source {
+			repoSrc := *src
+			repoSrc.Owner, repoSrc.Repo = owner, repo
+			return &repoSrc
+		}), nil
+	}
+
+	if ts.Spec.When.GitHubPullRequests != nil {
+		gh := ts.Spec.When.GitHubPullRequests
+
+		token, err := readGitHubToken(cfg.githubTokenFile)
+		if err != nil {
+			return nil, err
 		}
 
-		return &source.GitHubSource{
-			Owner:         owner,
-			Repo:          repo,
-			Types:         gh.Types,
-			Labels:        gh.Labels,
-			ExcludeLabels: gh.ExcludeLabels,
-			State:         gh.State,
-			Token:         token,
-			BaseURL:       apiBaseURL,
+		src := &source.GitHubPullRequestSource{
+			GitHubSource: source.GitHubSource{
+				Owner:         cfg.githubOwner,
+				Repo:          cfg.githubRepo,
+				Labels:        gh.Labels,
+				ExcludeLabels: gh.ExcludeLabels,
+				State:         gh.State,
+				Token:         token,
+				BaseURL:       cfg.githubAPIBaseURL,
+				Cache:         cfg.githubCache,
+			},
+			ReviewState: gh.ReviewState,
+			Draft:       gh.Draft,
+			BaseBranch:  gh.BaseBranch,
+			Author:      gh.Author,
+		}
+		setIgnoredAuthors(&src.GitHubSource, ts.Spec.Retrigger)
+		return withRepos(src, gh.Repos, gh.Org, token, cfg, func(owner, repo string) source.Source {
+			repoSrc := *src
+			repoSrc.Owner, repoSrc.Repo = owner, repo
+			return &repoSrc
+		}), nil
+	}
+
+	if ts.Spec.When.GitLabIssues != nil {
+		gl := ts.Spec.When.GitLabIssues
+
+		project := gl.Project
+		if project == "" {
+			project = cfg.gitlabProject
+		}
+		if project == "" {
+			return nil, fmt.Errorf("no GitLab project configured for TaskSpawner %s/%s", ts.Namespace, ts.Name)
+		}
+
+		return &source.GitLabSource{
+			Project:       project,
+			Types:         gl.Types,
+			Labels:        gl.Labels,
+			ExcludeLabels: gl.ExcludeLabels,
+			State:         gl.State,
+			Token:         os.Getenv("GITLAB_TOKEN"),
+			BaseURL:       cfg.gitlabAPIBaseURL,
+		}, nil
+	}
+
+	if ts.Spec.When.Jira != nil {
+		jira := ts.Spec.When.Jira
+		cloud := jira.Deployment == axonv1alpha1.JiraDeploymentCloud ||
+			(jira.Deployment == "" && source.IsJiraCloudURL(jira.BaseURL))
+		return &source.JiraSource{
+			BaseURL: jira.BaseURL,
+			JQL:     jira.JQL,
+			Cloud:   cloud,
+			User:    os.Getenv("JIRA_USER"),
+			Token:   os.Getenv("JIRA_TOKEN"),
 		}, nil
 	}
 
@@ -273,24 +641,131 @@ func buildSource(ts *axonv1alpha1.TaskSpawner, owner, repo, apiBaseURL, tokenFil
 		}
 		return &source.CronSource{
 			Schedule:          ts.Spec.When.Cron.Schedule,
+			TimeZone:          ts.Spec.When.Cron.TimeZone,
 			LastDiscoveryTime: lastDiscovery,
 		}, nil
 	}
 
+	if hj := ts.Spec.When.HTTPJSON; hj != nil {
+		headers, err := readHTTPHeaders(cfg.httpHeadersDir)
+		if err != nil {
+			return nil, err
+		}
+		return &source.HTTPJSONSource{
+			URL:       hj.URL,
+			Headers:   headers,
+			ItemsPath: hj.ItemsPath,
+			NextPath:  hj.NextPath,
+			Fields: source.HTTPJSONFields{
+				ID:     hj.Fields.ID,
+				Title:  hj.Fields.Title,
+				Body:   hj.Fields.Body,
+				URL:    hj.Fields.URL,
+				Labels: hj.Fields.Labels,
+			},
+			Extra: hj.Extra,
+		}, nil
+	}
+
+	if ke := ts.Spec.When.KubernetesEvents; ke != nil {
+		return buildKubernetesSource(ts, ke, cfg)
+	}
+
 	return nil, fmt.Errorf("no source configured in TaskSpawner %s/%s", ts.Namespace, ts.Name)
 }
 
-func parsePollInterval(s string) time.Duration {
-	if s == "" {
-		return 5 * time.Minute
+// skippedErrorsSource is implemented by the sources that skip the items
+// they cannot read instead of failing the discovery.
+type skippedErrorsSource interface {
+	SkippedErrors() []error
+}
+
+// buildKubernetesSource returns the source of a kubernetesEvents trigger,
+// which discovers objects of the namespace of ts.
+func buildKubernetesSource(ts *axonv1alpha1.TaskSpawner, ke *axonv1alpha1.KubernetesEvents, cfg sourceConfig) (source.Source, error) {
+	if cfg.kubeClient == nil {
+		return nil, fmt.Errorf("no Kubernetes client configured for TaskSpawner %s/%s", ts.Namespace, ts.Name)
 	}
-	d, err := time.ParseDuration(s)
+	gv, err := schema.ParseGroupVersion(ke.APIVersion)
 	if err != nil {
-		// Try parsing as plain number (seconds)
-		if n, err := strconv.Atoi(s); err == nil {
-			return time.Duration(n) * time.Second
+		return nil, fmt.Errorf("parsing apiVersion %q: %w", ke.APIVersion, err)
+	}
+	src := &source.KubernetesSource{
+		Client:    cfg.kubeClient,
+		Clientset: cfg.kubeClientset,
+		Namespace: ts.Namespace,
+		GVK:       gv.WithKind(ke.Kind),
+		TailLines: 50,
+	}
+	if ke.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(ke.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing labelSelector: %w", err)
 		}
-		return 5 * time.Minute
+		src.LabelSelector = selector
+	}
+	if ke.Predicate != nil {
+		src.PredicatePath = ke.Predicate.Path
+		src.PredicateValues = ke.Predicate.Values
+	}
+	if ke.TailLines != nil {
+		src.TailLines = int64(*ke.TailLines)
+	}
+	return src, nil
+}
+
+// readHTTPHeaders returns the request headers in dir, where the Secret of
+// an httpJSON source is mounted: the name of each file is a header name
+// and its content the value. It is read on every cycle so that rotated
+// Secrets are picked up.
+func readHTTPHeaders(dir string) (map[string]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading HTTP headers: %w", err)
+	}
+	headers := make(map[string]string)
+	for _, e := range entries {
+		// Secret volumes keep their data in hidden directories, linked
+		// to by the files of the keys.
+		if strings.HasPrefix(e.Name(), ".") || e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading HTTP header %s: %w", e.Name(), err)
+		}
+		headers[e.Name()] = strings.TrimSpace(string(data))
+	}
+	return headers, nil
+}
+
+// readGitHubToken returns the GitHub token from tokenFile, which is kept
+// up to date by the token refresher sidecar, or from the GITHUB_TOKEN
+// environment variable if tokenFile is not set.
+func readGitHubToken(tokenFile string) (string, error) {
+	if tokenFile == "" {
+		return os.Getenv("GITHUB_TOKEN"), nil
+	}
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ctrl.Log.WithName("spawner").Info("Token file not yet available, proceeding without token", "path", tokenFile)
+			return "", nil
+		}
+		return "", fmt.Errorf("reading token file %s: %w", tokenFile, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// parsePollInterval parses the poll interval of a TaskSpawner, falling back
+// to the default interval if it is invalid.
+func parsePollInterval(s string) time.Duration {
+	d, err := source.ParsePollInterval(s)
+	if err != nil {
+		return source.DefaultPollInterval
 	}
 	return d
 }
@@ -2,14 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -18,14 +27,16 @@ import (
 
 	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
 	"github.com/axon-core/axon/internal/source"
+	"github.com/axon-core/axon/internal/source/jiratest"
 )
 
 type fakeSource struct {
 	items []source.WorkItem
+	err   error
 }
 
 func (f *fakeSource) Discover(_ context.Context) ([]source.WorkItem, error) {
-	return f.items, nil
+	return f.items, f.err
 }
 
 func newTestScheme() *runtime.Scheme {
@@ -105,7 +116,7 @@ func newTask(name, namespace, spawnerName string, phase axonv1alpha1.TaskPhase)
 func TestBuildSource_GitHubIssuesWithBaseURL(t *testing.T) {
 	ts := newTaskSpawner("spawner", "default", nil)
 
-	src, err := buildSource(ts, "my-org", "my-repo", "https://github.example.com/api/v3", "")
+	src, err := buildSource(ts, sourceConfig{githubOwner: "my-org", githubRepo: "my-repo", githubAPIBaseURL: "https://github.example.com/api/v3"})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -125,10 +136,27 @@ func TestBuildSource_GitHubIssuesWithBaseURL(t *testing.T) {
 	}
 }
 
+func TestBuildSource_GitHubIssuesAuthorFilters(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When.GitHubIssues.Authors = []string{"alice"}
+	ts.Spec.When.GitHubIssues.ExcludeAuthors = []string{"renovate"}
+	ts.Spec.When.GitHubIssues.AuthorAssociations = []string{"OWNER", "MEMBER"}
+
+	src, err := buildSource(ts, sourceConfig{githubOwner: "my-org", githubRepo: "my-repo"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ghSrc := src.(*source.GitHubSource)
+	if fmt.Sprint(ghSrc.Authors, ghSrc.ExcludeAuthors, ghSrc.AuthorAssociations) != "[alice] [renovate] [OWNER MEMBER]" {
+		t.Errorf("Unexpected author filters %v %v %v", ghSrc.Authors, ghSrc.ExcludeAuthors, ghSrc.AuthorAssociations)
+	}
+}
+
 func TestBuildSource_GitHubIssuesDefaultBaseURL(t *testing.T) {
 	ts := newTaskSpawner("spawner", "default", nil)
 
-	src, err := buildSource(ts, "axon-core", "axon", "", "")
+	src, err := buildSource(ts, sourceConfig{githubOwner: "axon-core", githubRepo: "axon"})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -142,6 +170,213 @@ func TestBuildSource_GitHubIssuesDefaultBaseURL(t *testing.T) {
 	}
 }
 
+func TestBuildSource_GitLabIssues(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "glpat")
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When = axonv1alpha1.When{
+		GitLabIssues: &axonv1alpha1.GitLabIssues{Types: []string{"merge_requests"}},
+	}
+	cfg := sourceConfig{gitlabProject: "group/sub/project", gitlabAPIBaseURL: "https://gitlab.example.com/api/v4"}
+
+	src, err := buildSource(ts, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	glSrc, ok := src.(*source.GitLabSource)
+	if !ok {
+		t.Fatalf("Expected *source.GitLabSource, got %T", src)
+	}
+	if glSrc.Project != "group/sub/project" || glSrc.BaseURL != "https://gitlab.example.com/api/v4" || glSrc.Token != "glpat" {
+		t.Errorf("Unexpected source %+v", glSrc)
+	}
+
+	ts.Spec.When.GitLabIssues.Project = "other/project"
+	src, err = buildSource(ts, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := src.(*source.GitLabSource).Project; got != "other/project" {
+		t.Errorf("Project = %q, want the spec override %q", got, "other/project")
+	}
+}
+
+func TestBuildSource_HTTPJSON(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "..data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Authorization"), []byte("Bearer token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When = axonv1alpha1.When{
+		HTTPJSON: &axonv1alpha1.HTTPJSON{
+			URL:       "https://tickets.example.com/api/tickets",
+			ItemsPath: ".data",
+			NextPath:  ".links.next",
+			Fields:    axonv1alpha1.HTTPJSONFields{ID: ".id", Title: ".summary"},
+			Extra:     map[string]string{"severity": ".severity"},
+		},
+	}
+
+	src, err := buildSource(ts, sourceConfig{httpHeadersDir: dir})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hjSrc, ok := src.(*source.HTTPJSONSource)
+	if !ok {
+		t.Fatalf("Expected *source.HTTPJSONSource, got %T", src)
+	}
+	if hjSrc.URL != "https://tickets.example.com/api/tickets" || hjSrc.ItemsPath != ".data" || hjSrc.NextPath != ".links.next" || hjSrc.Fields.Title != ".summary" || hjSrc.Extra["severity"] != ".severity" {
+		t.Errorf("Unexpected source %+v", hjSrc)
+	}
+	if len(hjSrc.Headers) != 1 || hjSrc.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("Expected the Authorization header from the headers directory, got %v", hjSrc.Headers)
+	}
+}
+
+func TestRunCycle_Jira(t *testing.T) {
+	jira := jiratest.NewServer(
+		jiratest.Issue{Key: "PROJ-12", Summary: "Login fails", Description: "Steps to reproduce"},
+	)
+	defer jira.Close()
+	t.Setenv("JIRA_TOKEN", "pat")
+
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When = axonv1alpha1.When{
+		Jira: &axonv1alpha1.Jira{BaseURL: jira.URL, JQL: "project = PROJ"},
+	}
+	ts.Spec.TaskTemplate.PromptTemplate = "{{.ID}}: {{.Title}}\n{{.Body}}\n{{.URL}}"
+	cl, key := setupTest(t, ts)
+
+	if err := runCycle(context.Background(), cl, &events.FakeRecorder{}, key, sourceConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var task axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "spawner-proj-12"}, &task); err != nil {
+		t.Fatalf("Expected Task spawner-proj-12 to be created: %v", err)
+	}
+	want := "proj-12: Login fails\nSteps to reproduce\n" + jira.URL + "/browse/PROJ-12"
+	if task.Spec.Prompt != want {
+		t.Errorf("Prompt = %q, want %q", task.Spec.Prompt, want)
+	}
+	if got := jira.Authorization(); got != "Bearer pat" {
+		t.Errorf("Expected the token from JIRA_TOKEN, got authorization %q", got)
+	}
+	if got := jira.Paths(); len(got) != 1 || got[0] != "/rest/api/2/search" {
+		t.Errorf("Expected a Jira Server search, got %q", got)
+	}
+}
+
+func TestBuildSource_JiraDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseURL    string
+		deployment axonv1alpha1.JiraDeployment
+		wantCloud  bool
+	}{
+		{name: "atlassian.net site", baseURL: "https://example.atlassian.net", wantCloud: true},
+		{name: "self-hosted", baseURL: "https://jira.example.com"},
+		{name: "explicit Cloud", baseURL: "https://jira.example.com", deployment: axonv1alpha1.JiraDeploymentCloud, wantCloud: true},
+		{name: "explicit Server", baseURL: "https://example.atlassian.net", deployment: axonv1alpha1.JiraDeploymentServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTaskSpawner("spawner", "default", nil)
+			ts.Spec.When = axonv1alpha1.When{
+				Jira: &axonv1alpha1.Jira{BaseURL: tt.baseURL, JQL: "project = PROJ", Deployment: tt.deployment},
+			}
+			src, err := buildSource(ts, sourceConfig{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			jiraSrc, ok := src.(*source.JiraSource)
+			if !ok {
+				t.Fatalf("Expected *source.JiraSource, got %T", src)
+			}
+			if jiraSrc.Cloud != tt.wantCloud {
+				t.Errorf("Cloud = %v, want %v", jiraSrc.Cloud, tt.wantCloud)
+			}
+		})
+	}
+}
+
+func TestRunCycle_KubernetesEvents(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When = axonv1alpha1.When{
+		KubernetesEvents: &axonv1alpha1.KubernetesEvents{
+			APIVersion:    "v1",
+			Kind:          "Pod",
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Predicate: &axonv1alpha1.FieldPredicate{
+				Path:   ".status.containerStatuses[*].state.waiting.reason",
+				Values: []string{"CrashLoopBackOff"},
+			},
+		},
+	}
+	ts.Spec.TaskTemplate.PromptTemplate = "{{.Title}}\n{{.Object}}"
+	cl, key := setupTest(t, ts)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-1", Labels: map[string]string{"app": "web"}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "app",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}},
+	}
+	if err := cl.Create(context.Background(), pod); err != nil {
+		t.Fatalf("Creating Pod: %v", err)
+	}
+
+	cfg := sourceConfig{kubeClient: cl}
+	for range 2 {
+		if err := runCycle(context.Background(), cl, &events.FakeRecorder{}, key, cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	var tasks axonv1alpha1.TaskList
+	if err := cl.List(context.Background(), &tasks, client.InNamespace("default")); err != nil {
+		t.Fatalf("Listing Tasks: %v", err)
+	}
+	if len(tasks.Items) != 1 {
+		t.Fatalf("Expected a single Task for the crashing Pod across cycles, got %d", len(tasks.Items))
+	}
+	prompt := tasks.Items[0].Spec.Prompt
+	if !strings.HasPrefix(prompt, "Pod web-1: CrashLoopBackOff\n") || !strings.Contains(prompt, "name: web-1") {
+		t.Errorf("Expected the title and YAML of the Pod in the prompt, got %q", prompt)
+	}
+}
+
+func TestRunCycleWithSource_ItemRefOverridesWorkspaceRef(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{
+		items: []source.WorkItem{
+			{ID: "1", Title: "PR 1", Kind: "PR", HeadBranch: "fix-login", Ref: "fix-login"},
+			{ID: "2", Title: "PR 2", Kind: "PR", HeadBranch: "patch-1"},
+		},
+	}
+
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, wantRef := range map[string]string{"spawner-1": "fix-login", "spawner-2": ""} {
+		var task axonv1alpha1.Task
+		if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, &task); err != nil {
+			t.Fatalf("Getting Task %s: %v", name, err)
+		}
+		if task.Spec.WorkspaceRef == nil || task.Spec.WorkspaceRef.Name != "test-ws" || task.Spec.WorkspaceRef.Ref != wantRef {
+			t.Errorf("Task %s WorkspaceRef = %+v, want name test-ws and ref %q", name, task.Spec.WorkspaceRef, wantRef)
+		}
+	}
+	if ts.Spec.TaskTemplate.WorkspaceRef.Ref != "" {
+		t.Error("Expected the TaskSpawner's workspaceRef not to be modified")
+	}
+}
+
 func TestRunCycleWithSource_NoMaxConcurrency(t *testing.T) {
 	ts := newTaskSpawner("spawner", "default", nil)
 	cl, key := setupTest(t, ts)
@@ -154,7 +389,7 @@ func TestRunCycleWithSource_NoMaxConcurrency(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -181,7 +416,7 @@ func TestRunCycleWithSource_MaxConcurrencyLimitsCreation(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -213,7 +448,7 @@ func TestRunCycleWithSource_MaxConcurrencyWithExistingActiveTasks(t *testing.T)
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -245,7 +480,7 @@ func TestRunCycleWithSource_CompletedTasksDontCountTowardsLimit(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -271,7 +506,7 @@ func TestRunCycleWithSource_MaxConcurrencyZeroMeansNoLimit(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -300,7 +535,7 @@ func TestRunCycleWithSource_MaxConcurrencyAlreadyAtLimit(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -330,7 +565,7 @@ func TestRunCycleWithSource_ActiveTasksStatusUpdated(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -360,7 +595,7 @@ func TestRunCycleWithSource_AgentConfigRefForwarded(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -400,7 +635,7 @@ func TestRunCycleWithSource_PodOverridesForwarded(t *testing.T) {
 		},
 	}
 
-	if err := runCycleWithSource(context.Background(), cl, key, src); err != nil {
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
@@ -427,3 +662,160 @@ func TestRunCycleWithSource_PodOverridesForwarded(t *testing.T) {
 		t.Errorf("Expected nodeSelector pool=agents, got %v", task.Spec.PodOverrides.NodeSelector)
 	}
 }
+
+func TestRunCycleWithSource_SuspendedCreatesNoTasks(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.Suspend = true
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{
+		items: []source.WorkItem{
+			{ID: "1", Title: "Item 1"},
+		},
+	}
+
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var taskList axonv1alpha1.TaskList
+	if err := cl.List(context.Background(), &taskList, client.InNamespace("default")); err != nil {
+		t.Fatalf("Listing tasks: %v", err)
+	}
+	if len(taskList.Items) != 0 {
+		t.Errorf("Expected no tasks while suspended, got %d", len(taskList.Items))
+	}
+
+	var updatedTS axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), key, &updatedTS); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	if updatedTS.Status.Phase != axonv1alpha1.TaskSpawnerPhaseSuspended {
+		t.Errorf("Expected phase %s, got %s", axonv1alpha1.TaskSpawnerPhaseSuspended, updatedTS.Status.Phase)
+	}
+}
+
+func TestRunCycleWithSource_CancelledTasksDontCountTowardsLimit(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", int32Ptr(1))
+	existingTasks := []axonv1alpha1.Task{
+		newTask("spawner-cancelled", "default", "spawner", axonv1alpha1.TaskPhaseCancelled),
+	}
+	cl, key := setupTest(t, ts, existingTasks...)
+
+	src := &fakeSource{
+		items: []source.WorkItem{
+			{ID: "cancelled", Title: "Cancelled"},
+			{ID: "2", Title: "Item 2"},
+		},
+	}
+
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var taskList axonv1alpha1.TaskList
+	if err := cl.List(context.Background(), &taskList, client.InNamespace("default")); err != nil {
+		t.Fatalf("Listing tasks: %v", err)
+	}
+	if len(taskList.Items) != 2 {
+		t.Errorf("Expected 2 tasks (1 cancelled + 1 new), got %d", len(taskList.Items))
+	}
+}
+
+func TestRunCycleWithSource_RecordsEvents(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+	recorder := events.NewFakeRecorder(10)
+
+	src := &fakeSource{
+		items: []source.WorkItem{
+			{ID: "1", Title: "Item 1"},
+		},
+	}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if e := <-recorder.Events; e != "Normal TaskCreated Created Task spawner-1 for item 1" {
+		t.Errorf("Unexpected event %q", e)
+	}
+
+	src = &fakeSource{err: errors.New("rate limited")}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err == nil {
+		t.Fatal("Expected discovery error")
+	}
+	if e := <-recorder.Events; e != "Warning DiscoveryFailed Failed to discover work items: rate limited" {
+		t.Errorf("Unexpected event %q", e)
+	}
+
+	var updated axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	healthy := meta.FindStatusCondition(updated.Status.Conditions, axonv1alpha1.ConditionSourceHealthy)
+	if healthy == nil || healthy.Status != metav1.ConditionFalse || healthy.Reason != "DiscoveryFailed" {
+		t.Errorf("Expected SourceHealthy=False with reason DiscoveryFailed, got %+v", healthy)
+	}
+}
+
+func TestRunCycleWithSource_RecordsMetrics(t *testing.T) {
+	ts := newTaskSpawner("metrics-spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{
+		items: []source.WorkItem{
+			{ID: "1", Title: "Item 1"},
+			{ID: "2", Title: "Item 2"},
+		},
+	}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(tasksCreatedTotal.WithLabelValues("default", "metrics-spawner")); got != 2 {
+		t.Errorf("Expected 2 tasks created, got %v", got)
+	}
+	if got := testutil.ToFloat64(itemsDiscovered.WithLabelValues("default", "metrics-spawner")); got != 2 {
+		t.Errorf("Expected 2 items discovered, got %v", got)
+	}
+	if got := testutil.ToFloat64(lastDiscoveryTimestampSeconds.WithLabelValues("default", "metrics-spawner")); got == 0 {
+		t.Error("Expected last discovery timestamp to be set")
+	}
+
+	src = &fakeSource{err: errors.New("unavailable")}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err == nil {
+		t.Fatal("Expected discovery error")
+	}
+	if got := testutil.ToFloat64(discoveryErrorsTotal.WithLabelValues("default", "metrics-spawner")); got != 1 {
+		t.Errorf("Expected 1 discovery error, got %v", got)
+	}
+}
+
+func TestRunCycleWithSource_RateLimited(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+	recorder := events.NewFakeRecorder(10)
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	src := &fakeSource{err: fmt.Errorf("listing issues: %w", &source.RateLimitError{
+		RateLimit: source.RateLimit{Limit: 5000, Remaining: 42, Reset: reset},
+	})}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err == nil {
+		t.Fatal("Expected rate limit error")
+	}
+	if e := <-recorder.Events; !strings.HasPrefix(e, "Warning RateLimited listing issues: GitHub API rate limit low") {
+		t.Errorf("Unexpected event %q", e)
+	}
+
+	var updated axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	healthy := meta.FindStatusCondition(updated.Status.Conditions, axonv1alpha1.ConditionSourceHealthy)
+	if healthy == nil || healthy.Status != metav1.ConditionFalse || healthy.Reason != "RateLimited" {
+		t.Errorf("Expected SourceHealthy=False with reason RateLimited, got %+v", healthy)
+	}
+	rl := updated.Status.RateLimit
+	if rl == nil || rl.Limit != 5000 || rl.Remaining != 42 || rl.ResetTime == nil || !rl.ResetTime.Time.Equal(reset) {
+		t.Errorf("Unexpected rate limit status %+v", rl)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+var spawnerLabels = []string{"namespace", "taskspawner"}
+
+var (
+	discoveryDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "axon_spawner_discovery_duration_seconds",
+			Help:    "Duration of work item discovery in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		spawnerLabels,
+	)
+
+	discoveryErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_spawner_discovery_errors_total",
+			Help: "Number of failed discovery cycles.",
+		},
+		spawnerLabels,
+	)
+
+	lastDiscoveryTimestampSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "axon_spawner_last_discovery_timestamp_seconds",
+			Help: "Unix time of the last successful discovery cycle.",
+		},
+		spawnerLabels,
+	)
+
+	itemsDiscovered = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "axon_spawner_items_discovered",
+			Help: "Number of work items found by the last successful discovery cycle.",
+		},
+		spawnerLabels,
+	)
+
+	tasksCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_spawner_tasks_created_total",
+			Help: "Number of Tasks created by the spawner.",
+		},
+		spawnerLabels,
+	)
+
+	activeTasksGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "axon_spawner_active_tasks",
+			Help: "Number of active (non-terminal) Tasks created by the spawner.",
+		},
+		spawnerLabels,
+	)
+
+	githubAPIErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_spawner_github_api_errors_total",
+			Help: "Number of GitHub API requests that failed or returned a non-OK status.",
+		},
+		spawnerLabels,
+	)
+
+	githubRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "axon_spawner_github_rate_limit_remaining",
+			Help: "Number of GitHub API requests remaining in the current rate limit window.",
+		},
+		spawnerLabels,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		discoveryDurationSeconds,
+		discoveryErrorsTotal,
+		lastDiscoveryTimestampSeconds,
+		itemsDiscovered,
+		tasksCreatedTotal,
+		activeTasksGauge,
+		githubAPIErrorsTotal,
+		githubRateLimitRemaining,
+	)
+}
+
+// githubAPISource is a source backed by the GitHub API.
+type githubAPISource interface {
+	APIErrors() int
+	RateLimitRemaining() (int, bool)
+	RateLimit() (source.RateLimit, bool)
+}
+
+// observeSourceMetrics records the GitHub API metrics of src, if it is a
+// GitHub source.
+func observeSourceMetrics(ts *axonv1alpha1.TaskSpawner, src source.Source) {
+	gh, ok := src.(githubAPISource)
+	if !ok {
+		return
+	}
+	githubAPIErrorsTotal.WithLabelValues(ts.Namespace, ts.Name).Add(float64(gh.APIErrors()))
+	if remaining, ok := gh.RateLimitRemaining(); ok {
+		githubRateLimitRemaining.WithLabelValues(ts.Namespace, ts.Name).Set(float64(remaining))
+	}
+}
+
+// serveMetrics serves the metrics registry on addr until ctx is done.
+// It does nothing if addr is "0".
+func serveMetrics(ctx context.Context, addr string) {
+	if addr == "0" {
+		return
+	}
+	log := ctrl.Log.WithName("spawner")
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		log.Info("serving metrics", "address", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "metrics server failed")
+		}
+	}()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+// prioritize sorts items by the highest weight of their labels, then by
+// the order of the policy. The sort is stable, so items that compare equal
+// keep the order of the source.
+func prioritize(policy *axonv1alpha1.Priority, items []source.WorkItem) {
+	slices.SortStableFunc(items, func(a, b source.WorkItem) int {
+		if c := cmp.Compare(labelPriority(policy, b).Weight, labelPriority(policy, a).Weight); c != 0 {
+			return c
+		}
+		switch policy.Order {
+		case axonv1alpha1.PriorityOrderOldest:
+			return compareTimes(a.CreatedAt, b.CreatedAt, false)
+		case axonv1alpha1.PriorityOrderNewest:
+			return compareTimes(a.CreatedAt, b.CreatedAt, true)
+		case axonv1alpha1.PriorityOrderRecentlyUpdated:
+			return compareTimes(a.UpdatedAt, b.UpdatedAt, true)
+		case axonv1alpha1.PriorityOrderReactions:
+			return cmp.Compare(b.Reactions, a.Reactions)
+		}
+		return 0
+	})
+}
+
+// labelPriority returns the priority of the label of item with the highest
+// weight, or a zero priority if it has none of the labels of the policy.
+func labelPriority(policy *axonv1alpha1.Priority, item source.WorkItem) axonv1alpha1.LabelPriority {
+	var best axonv1alpha1.LabelPriority
+	found := false
+	for _, lp := range policy.Labels {
+		if (!found || lp.Weight > best.Weight) && slices.Contains(item.Labels, lp.Name) {
+			best, found = lp, true
+		}
+	}
+	return best
+}
+
+// compareTimes compares two RFC3339 times, earliest first unless
+// latestFirst is set. Times that cannot be parsed, such as those of sources
+// that do not report them, sort last.
+func compareTimes(a, b string, latestFirst bool) int {
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	if latestFirst {
+		return tb.Compare(ta)
+	}
+	return ta.Compare(tb)
+}
+
+// taskPodOverrides returns the pod overrides of the Task for item: those of
+// the task template, with the PriorityClass of its label of highest weight
+// if it has one.
+func taskPodOverrides(ts *axonv1alpha1.TaskSpawner, item source.WorkItem) *axonv1alpha1.PodOverrides {
+	overrides := ts.Spec.TaskTemplate.PodOverrides
+	if ts.Spec.Priority == nil {
+		return overrides
+	}
+	className := labelPriority(ts.Spec.Priority, item).PriorityClassName
+	if className == "" {
+		return overrides
+	}
+	overrides = overrides.DeepCopy()
+	if overrides == nil {
+		overrides = &axonv1alpha1.PodOverrides{}
+	}
+	overrides.PriorityClassName = className
+	return overrides
+}
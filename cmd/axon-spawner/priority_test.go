@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"k8s.io/client-go/tools/events"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+func TestPrioritize(t *testing.T) {
+	items := []source.WorkItem{
+		{ID: "1", CreatedAt: "2026-01-02T00:00:00Z", UpdatedAt: "2026-01-05T00:00:00Z", Reactions: 1},
+		{ID: "2", Labels: []string{"priority/p1"}, CreatedAt: "2026-01-03T00:00:00Z"},
+		{ID: "3", CreatedAt: "2026-01-01T00:00:00Z", UpdatedAt: "2026-01-04T00:00:00Z", Reactions: 5},
+		{ID: "4", Labels: []string{"priority/p1", "priority/p0"}, CreatedAt: "2026-01-04T00:00:00Z"},
+		{ID: "5"},
+	}
+	labels := []axonv1alpha1.LabelPriority{
+		{Name: "priority/p0", Weight: 100},
+		{Name: "priority/p1", Weight: 50},
+	}
+
+	tests := []struct {
+		name   string
+		policy axonv1alpha1.Priority
+		want   []string
+	}{
+		{name: "labels keep source order", policy: axonv1alpha1.Priority{Labels: labels}, want: []string{"4", "2", "1", "3", "5"}},
+		{name: "oldest", policy: axonv1alpha1.Priority{Labels: labels, Order: axonv1alpha1.PriorityOrderOldest}, want: []string{"4", "2", "3", "1", "5"}},
+		{name: "newest", policy: axonv1alpha1.Priority{Order: axonv1alpha1.PriorityOrderNewest}, want: []string{"4", "2", "1", "3", "5"}},
+		{name: "recently updated", policy: axonv1alpha1.Priority{Order: axonv1alpha1.PriorityOrderRecentlyUpdated}, want: []string{"1", "3", "2", "4", "5"}},
+		{name: "reactions", policy: axonv1alpha1.Priority{Order: axonv1alpha1.PriorityOrderReactions}, want: []string{"3", "1", "2", "4", "5"}},
+		{name: "negative weight last", policy: axonv1alpha1.Priority{Labels: []axonv1alpha1.LabelPriority{{Name: "priority/p1", Weight: -1}}}, want: []string{"1", "3", "5", "2", "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := slices.Clone(items)
+			prioritize(&tt.policy, sorted)
+			var got []string
+			for _, item := range sorted {
+				got = append(got, item.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCycleWithSource_Priority(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", int32Ptr(1))
+	ts.Spec.TaskTemplate.PodOverrides = &axonv1alpha1.PodOverrides{PriorityClassName: "axon-default"}
+	ts.Spec.Priority = &axonv1alpha1.Priority{
+		Labels: []axonv1alpha1.LabelPriority{{Name: "priority/p0", Weight: 100, PriorityClassName: "axon-urgent"}},
+	}
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{items: []source.WorkItem{
+		{ID: "1", Title: "Minor"},
+		{ID: "2", Title: "Outage", Labels: []string{"priority/p0"}},
+	}}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if names := listTaskNames(t, cl); len(names) != 1 || !names["spawner-2"] {
+		t.Fatalf("Expected only the Task of the p0 item under max concurrency, got %v", names)
+	}
+	if got := getTask(t, cl, "spawner-2").Spec.PodOverrides.PriorityClassName; got != "axon-urgent" {
+		t.Errorf("Expected priority class axon-urgent, got %q", got)
+	}
+	if ts.Spec.TaskTemplate.PodOverrides.PriorityClassName != "axon-default" {
+		t.Error("Expected the task template not to be modified")
+	}
+
+	// Items without a prioritized label keep the class of the template.
+	if got := taskPodOverrides(ts, src.items[0]).PriorityClassName; got != "axon-default" {
+		t.Errorf("Expected priority class axon-default, got %q", got)
+	}
+}
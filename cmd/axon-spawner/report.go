@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/reporting"
+	"github.com/axon-core/axon/internal/source"
+)
+
+const (
+	// sourceNumberAnnotation records the number of the issue or pull
+	// request a Task was created for.
+	sourceNumberAnnotation = "axon.io/source-number"
+
+	// sourceRepoAnnotation records the repository ("owner/name") of the
+	// item a Task was created for, when the TaskSpawner discovers items
+	// from several repositories.
+	sourceRepoAnnotation = "axon.io/source-repo"
+
+	// statusCommentIDAnnotation records the ID of the status comment
+	// reporting the progress of a Task.
+	statusCommentIDAnnotation = "axon.io/status-comment-id"
+
+	// reportedPhaseAnnotation records the phase of a Task last reported
+	// in its status comment.
+	reportedPhaseAnnotation = "axon.io/reported-phase"
+
+	// reportedAtAnnotation records when the progress of a Task was last
+	// reported, so that the resulting update of the item does not count as
+	// new activity.
+	reportedAtAnnotation = "axon.io/reported-at"
+
+	// reportInterval is how often the progress of Tasks is reported,
+	// independently of the poll interval.
+	reportInterval = 30 * time.Second
+
+	// reportUpdateSkew is how much later than the last report of a Task
+	// the update of its item caused by the report may appear.
+	reportUpdateSkew = time.Minute
+
+	// statusCommentMarker identifies the status comments posted by the
+	// spawner.
+	statusCommentMarker = "<!-- axon-status -->"
+
+	reasonReportFailed = "ReportFailed"
+)
+
+// statusReporter writes the progress of Tasks to their issue or pull
+// request.
+type statusReporter interface {
+	CreateComment(ctx context.Context, number int, body string) (int64, error)
+	UpdateComment(ctx context.Context, id int64, body string) error
+	AddLabel(ctx context.Context, number int, label string) error
+	RemoveLabel(ctx context.Context, number int, label string) error
+}
+
+// taskAnnotations returns the annotations recording item on its Task.
+func taskAnnotations(item source.WorkItem) map[string]string {
+	annotations := activityAnnotations(item)
+	if item.Number != 0 {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[sourceNumberAnnotation] = strconv.Itoa(item.Number)
+	}
+	if item.Repo != "" {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[sourceRepoAnnotation] = item.Repo
+	}
+	return annotations
+}
+
+// runReporter reports the progress of the Tasks of the TaskSpawner every
+// reportInterval until ctx is done.
+func runReporter(ctx context.Context, cl client.Client, recorder events.EventRecorder, key types.NamespacedName, cfg sourceConfig) {
+	log := ctrl.Log.WithName("spawner").WithName("reporter")
+	for {
+		if done := sleepOrDone(ctx, reportInterval); done {
+			return
+		}
+
+		var ts axonv1alpha1.TaskSpawner
+		if err := cl.Get(ctx, key, &ts); err != nil {
+			log.Error(err, "Unable to fetch TaskSpawner")
+			continue
+		}
+		if ts.Spec.Reporting == nil || cfg.githubOwner == "" {
+			continue
+		}
+
+		token, err := readGitHubToken(cfg.githubTokenFile)
+		if err != nil {
+			log.Error(err, "Unable to read GitHub token")
+			continue
+		}
+		reporterFor := func(repo string) statusReporter {
+			owner, name := cfg.githubOwner, cfg.githubRepo
+			if repo != "" {
+				owner, name, _ = strings.Cut(repo, "/")
+			}
+			return &reporting.GitHubReporter{
+				Owner:   owner,
+				Repo:    name,
+				Token:   token,
+				BaseURL: cfg.githubAPIBaseURL,
+			}
+		}
+		if err := reportTasks(ctx, cl, recorder, &ts, reporterFor); err != nil {
+			log.Error(err, "Reporting Task progress failed")
+		}
+	}
+}
+
+// reportTasks updates the status comment and labels of the items of the
+// Tasks of ts whose phase changed since it was last reported, with the
+// reporter reporterFor returns for their repository ("" for the workspace's
+// repository). Tasks created for the same item, such as re-triggered ones,
+// share a status comment.
+func reportTasks(ctx context.Context, cl client.Client, recorder events.EventRecorder, ts *axonv1alpha1.TaskSpawner, reporterFor func(repo string) statusReporter) error {
+	log := ctrl.Log.WithName("spawner").WithName("reporter")
+
+	var taskList axonv1alpha1.TaskList
+	if err := cl.List(ctx, &taskList,
+		client.InNamespace(ts.Namespace),
+		client.MatchingLabels{"axon.io/taskspawner": ts.Name},
+	); err != nil {
+		return fmt.Errorf("listing Tasks: %w", err)
+	}
+
+	type itemKey struct {
+		repo   string
+		number int
+	}
+	commentIDs := make(map[itemKey]int64)
+	for _, task := range taskList.Items {
+		number, _ := strconv.Atoi(task.Annotations[sourceNumberAnnotation])
+		if id, err := strconv.ParseInt(task.Annotations[statusCommentIDAnnotation], 10, 64); err == nil && number != 0 {
+			commentIDs[itemKey{task.Annotations[sourceRepoAnnotation], number}] = id
+		}
+	}
+
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		number, err := strconv.Atoi(task.Annotations[sourceNumberAnnotation])
+		if err != nil || number == 0 {
+			continue
+		}
+		phase := taskPhase(task)
+		if task.Annotations[reportedPhaseAnnotation] == string(phase) {
+			continue
+		}
+
+		key := itemKey{task.Annotations[sourceRepoAnnotation], number}
+		commentID, err := reportTask(ctx, reporterFor(key.repo), ts.Spec.Reporting, task, number, commentIDs[key])
+		if err != nil {
+			recorder.Eventf(ts, task, corev1.EventTypeWarning, reasonReportFailed, "Report", "Failed to report progress of Task %s on #%d: %v", task.Name, number, err)
+			log.Error(err, "Reporting Task progress", "task", task.Name, "number", number)
+			continue
+		}
+		commentIDs[key] = commentID
+
+		patch := client.MergeFrom(task.DeepCopy())
+		task.Annotations[statusCommentIDAnnotation] = strconv.FormatInt(commentID, 10)
+		task.Annotations[reportedPhaseAnnotation] = string(phase)
+		task.Annotations[reportedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if err := cl.Patch(ctx, task, patch); err != nil {
+			return fmt.Errorf("recording reported phase of Task %s: %w", task.Name, err)
+		}
+		log.Info("Reported Task progress", "task", task.Name, "number", number, "phase", phase)
+	}
+	return nil
+}
+
+// reportTask writes the status comment and labels of task on its item
+// number and returns the ID of the status comment. It updates comment
+// commentID if set and not deleted, and creates a new one otherwise.
+func reportTask(ctx context.Context, reporter statusReporter, policy *axonv1alpha1.Reporting, task *axonv1alpha1.Task, number int, commentID int64) (int64, error) {
+	body := statusComment(task)
+	if commentID != 0 {
+		err := reporter.UpdateComment(ctx, commentID, body)
+		if err != nil && !errors.Is(err, reporting.ErrNotFound) {
+			return 0, err
+		}
+		if err != nil {
+			commentID = 0
+		}
+	}
+	if commentID == 0 {
+		var err error
+		if commentID, err = reporter.CreateComment(ctx, number, body); err != nil {
+			return 0, err
+		}
+	}
+
+	var add, remove []string
+	switch taskPhase(task) {
+	case axonv1alpha1.TaskPhaseSucceeded:
+		add = []string{policy.DoneLabel}
+		remove = []string{policy.InProgressLabel}
+	case axonv1alpha1.TaskPhaseFailed, axonv1alpha1.TaskPhaseCancelled:
+		add = []string{policy.FailedLabel}
+		remove = []string{policy.InProgressLabel}
+	default:
+		add = []string{policy.InProgressLabel}
+		remove = []string{policy.DoneLabel, policy.FailedLabel}
+	}
+	for _, label := range remove {
+		if label == "" {
+			continue
+		}
+		if err := reporter.RemoveLabel(ctx, number, label); err != nil {
+			return 0, err
+		}
+	}
+	for _, label := range add {
+		if label == "" {
+			continue
+		}
+		if err := reporter.AddLabel(ctx, number, label); err != nil {
+			return 0, err
+		}
+	}
+	return commentID, nil
+}
+
+// statusComment renders the status comment of task.
+func statusComment(task *axonv1alpha1.Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nAxon Task `%s` is **%s**.\n", statusCommentMarker, task.Name, taskPhase(task))
+	if task.Status.CostUSD != "" {
+		fmt.Fprintf(&b, "\n- Cost: $%s", task.Status.CostUSD)
+	}
+	for _, url := range task.Status.PullRequestURLs {
+		fmt.Fprintf(&b, "\n- Pull request: %s", url)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// taskPhase returns the phase of task, treating a Task that has not been
+// picked up by the controller yet as pending.
+func taskPhase(task *axonv1alpha1.Task) axonv1alpha1.TaskPhase {
+	if task.Status.Phase == "" {
+		return axonv1alpha1.TaskPhasePending
+	}
+	return task.Status.Phase
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/reporting"
+	"github.com/axon-core/axon/internal/source"
+)
+
+// fakeReporter records the calls made to it.
+type fakeReporter struct {
+	calls    []string
+	comments map[int64]string
+	nextID   int64
+}
+
+func (f *fakeReporter) CreateComment(_ context.Context, number int, body string) (int64, error) {
+	f.nextID++
+	if f.comments == nil {
+		f.comments = make(map[int64]string)
+	}
+	f.comments[f.nextID] = body
+	f.calls = append(f.calls, fmt.Sprintf("create #%d", number))
+	return f.nextID, nil
+}
+
+func (f *fakeReporter) UpdateComment(_ context.Context, id int64, body string) error {
+	f.calls = append(f.calls, fmt.Sprintf("update %d", id))
+	if _, ok := f.comments[id]; !ok {
+		return reporting.ErrNotFound
+	}
+	f.comments[id] = body
+	return nil
+}
+
+func (f *fakeReporter) AddLabel(_ context.Context, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("add %s #%d", label, number))
+	return nil
+}
+
+func (f *fakeReporter) RemoveLabel(_ context.Context, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("remove %s #%d", label, number))
+	return nil
+}
+
+// forRepo returns f as the reporter of every repository.
+func (f *fakeReporter) forRepo(string) statusReporter {
+	return f
+}
+
+func newReportingTaskSpawner() *axonv1alpha1.TaskSpawner {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.Reporting = &axonv1alpha1.Reporting{InProgressLabel: "axon/in-progress", DoneLabel: "axon/done"}
+	return ts
+}
+
+func getTask(t *testing.T, cl client.Client, name string) *axonv1alpha1.Task {
+	t.Helper()
+	var task axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, &task); err != nil {
+		t.Fatalf("Getting Task %s: %v", name, err)
+	}
+	return &task
+}
+
+func TestRunCycleWithSource_RecordsSourceNumber(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{items: []source.WorkItem{{ID: "42", Number: 42}}}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := getTask(t, cl, "spawner-42").Annotations[sourceNumberAnnotation]; got != "42" {
+		t.Errorf("Expected source number annotation 42, got %q", got)
+	}
+}
+
+func TestReportTasks(t *testing.T) {
+	ts := newReportingTaskSpawner()
+	task := newTask("spawner-42", "default", "spawner", axonv1alpha1.TaskPhaseRunning)
+	task.Annotations = map[string]string{sourceNumberAnnotation: "42"}
+	unrelated := newTask("spawner-cron", "default", "spawner", axonv1alpha1.TaskPhaseRunning)
+	cl, _ := setupTest(t, ts, task, unrelated)
+	reporter := &fakeReporter{}
+
+	if err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, reporter.forRepo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := "create #42,remove axon/done #42,add axon/in-progress #42"
+	if got := strings.Join(reporter.calls, ","); got != want {
+		t.Errorf("Calls = %q, want %q", got, want)
+	}
+	if !strings.Contains(reporter.comments[1], "`spawner-42` is **Running**") {
+		t.Errorf("Unexpected comment %q", reporter.comments[1])
+	}
+
+	got := getTask(t, cl, "spawner-42")
+	if got.Annotations[statusCommentIDAnnotation] != "1" || got.Annotations[reportedPhaseAnnotation] != "Running" {
+		t.Errorf("Unexpected annotations %v", got.Annotations)
+	}
+
+	// An unchanged phase is not reported again.
+	reporter.calls = nil
+	if err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, reporter.forRepo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(reporter.calls) != 0 {
+		t.Errorf("Expected no calls, got %v", reporter.calls)
+	}
+
+	got.Status.Phase = axonv1alpha1.TaskPhaseSucceeded
+	got.Status.CostUSD = "0.42"
+	got.Status.PullRequestURLs = []string{"https://github.com/owner/repo/pull/43"}
+	if err := cl.Update(context.Background(), got); err != nil {
+		t.Fatalf("Updating Task status: %v", err)
+	}
+	if err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, reporter.forRepo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = "update 1,remove axon/in-progress #42,add axon/done #42"
+	if got := strings.Join(reporter.calls, ","); got != want {
+		t.Errorf("Calls = %q, want %q", got, want)
+	}
+	for _, s := range []string{"**Succeeded**", "Cost: $0.42", "Pull request: https://github.com/owner/repo/pull/43"} {
+		if !strings.Contains(reporter.comments[1], s) {
+			t.Errorf("Expected comment to contain %q, got %q", s, reporter.comments[1])
+		}
+	}
+}
+
+func TestReportTasks_SharesCommentAcrossAttempts(t *testing.T) {
+	ts := newReportingTaskSpawner()
+	first := newTask("spawner-42", "default", "spawner", axonv1alpha1.TaskPhaseSucceeded)
+	first.Annotations = map[string]string{
+		sourceNumberAnnotation:    "42",
+		statusCommentIDAnnotation: "7",
+		reportedPhaseAnnotation:   "Succeeded",
+	}
+	second := newTask("spawner-42-2", "default", "spawner", "")
+	second.Annotations = map[string]string{sourceNumberAnnotation: "42"}
+	cl, _ := setupTest(t, ts, first, second)
+	reporter := &fakeReporter{comments: map[int64]string{7: "old"}}
+
+	if err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, reporter.forRepo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reporter.calls[0] != "update 7" || !strings.Contains(reporter.comments[7], "`spawner-42-2` is **Pending**") {
+		t.Errorf("Expected the existing comment to be updated, got calls %v and comment %q", reporter.calls, reporter.comments[7])
+	}
+}
+
+func TestReportTasks_RecreatesDeletedComment(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.Reporting = &axonv1alpha1.Reporting{}
+	task := newTask("spawner-42", "default", "spawner", axonv1alpha1.TaskPhaseFailed)
+	task.Annotations = map[string]string{
+		sourceNumberAnnotation:    "42",
+		statusCommentIDAnnotation: "7",
+		reportedPhaseAnnotation:   "Running",
+	}
+	cl, _ := setupTest(t, ts, task)
+	reporter := &fakeReporter{nextID: 10}
+
+	if err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, reporter.forRepo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Join(reporter.calls, ","); got != "update 7,create #42" {
+		t.Errorf("Unexpected calls %q", got)
+	}
+	if got := getTask(t, cl, "spawner-42").Annotations[statusCommentIDAnnotation]; got != "11" {
+		t.Errorf("Expected the new comment ID to be recorded, got %q", got)
+	}
+}
+
+func TestReportTasks_SeparatesRepositories(t *testing.T) {
+	ts := newReportingTaskSpawner()
+	axon := newTask("spawner-axon-42", "default", "spawner", axonv1alpha1.TaskPhaseRunning)
+	axon.Annotations = map[string]string{sourceNumberAnnotation: "42", sourceRepoAnnotation: "axon-core/axon"}
+	docs := newTask("spawner-docs-42", "default", "spawner", axonv1alpha1.TaskPhaseRunning)
+	docs.Annotations = map[string]string{
+		sourceNumberAnnotation:    "42",
+		sourceRepoAnnotation:      "axon-core/docs",
+		statusCommentIDAnnotation: "7",
+		reportedPhaseAnnotation:   "Pending",
+	}
+	cl, _ := setupTest(t, ts, axon, docs)
+	reporters := map[string]*fakeReporter{
+		"axon-core/axon": {},
+		"axon-core/docs": {comments: map[int64]string{7: "old"}, nextID: 7},
+	}
+
+	err := reportTasks(context.Background(), cl, &events.FakeRecorder{}, ts, func(repo string) statusReporter {
+		return reporters[repo]
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := reporters["axon-core/axon"].calls[0]; got != "create #42" {
+		t.Errorf("Expected a new comment on axon-core/axon, got %q", got)
+	}
+	if got := reporters["axon-core/docs"].calls[0]; got != "update 7" {
+		t.Errorf("Expected the comment on axon-core/docs to be updated, got %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+// repoErrorsSource is implemented by the sources that keep discovering the
+// other repositories when one of them fails.
+type repoErrorsSource interface {
+	RepoErrors() []error
+}
+
+// withRepos returns src, or, if repos or org is set, a source discovering
+// the items of these repositories with the source newSource returns for
+// each of them.
+func withRepos(src source.Source, repos []axonv1alpha1.GitHubRepository, org *axonv1alpha1.GitHubOrganization, token string, cfg sourceConfig, newSource func(owner, repo string) source.Source) source.Source {
+	if len(repos) == 0 && org == nil {
+		return src
+	}
+	multi := &source.GitHubReposSource{
+		GitHubSource: source.GitHubSource{
+			Token:   token,
+			BaseURL: cfg.githubAPIBaseURL,
+			Cache:   cfg.githubCache,
+		},
+		NewSource: newSource,
+	}
+	for _, repo := range repos {
+		multi.Repos = append(multi.Repos, repo.Name)
+	}
+	if org != nil {
+		multi.Org = org.Name
+		multi.RepoPattern = org.RepoPattern
+	}
+	return multi
+}
+
+// githubRepos returns the repositories listed by the GitHub source of ts.
+func githubRepos(ts *axonv1alpha1.TaskSpawner) []axonv1alpha1.GitHubRepository {
+	switch {
+	case ts.Spec.When.GitHubIssues != nil:
+		return ts.Spec.When.GitHubIssues.Repos
+	case ts.Spec.When.GitHubPullRequests != nil:
+		return ts.Spec.When.GitHubPullRequests.Repos
+	}
+	return nil
+}
+
+// itemWorkspaceRef returns the reference to the Workspace the Task for item
+// works in, checking out item.Ref if set. Items discovered from several
+// repositories work in the Workspace of their repository.
+func itemWorkspaceRef(ctx context.Context, cl client.Client, ts *axonv1alpha1.TaskSpawner, item source.WorkItem) (*axonv1alpha1.WorkspaceReference, error) {
+	if ts.Spec.TaskTemplate.WorkspaceRef == nil {
+		return nil, nil
+	}
+	workspaceRef := *ts.Spec.TaskTemplate.WorkspaceRef
+	if item.Repo != "" {
+		ref, err := repoWorkspaceRef(ctx, cl, ts, item.Repo)
+		if err != nil {
+			return nil, err
+		}
+		workspaceRef = *ref
+	}
+	if item.Ref != "" {
+		workspaceRef.Ref = item.Ref
+	}
+	return &workspaceRef, nil
+}
+
+// repoWorkspaceRef returns the reference to the Workspace of repository
+// repo ("owner/name"): the workspaceRef listed for it, the template
+// workspace if it is the same repository, or a Workspace derived from the
+// template workspace, which it creates or updates.
+func repoWorkspaceRef(ctx context.Context, cl client.Client, ts *axonv1alpha1.TaskSpawner, repo string) (*axonv1alpha1.WorkspaceReference, error) {
+	for _, r := range githubRepos(ts) {
+		if strings.EqualFold(r.Name, repo) && r.WorkspaceRef != nil {
+			ref := *r.WorkspaceRef
+			return &ref, nil
+		}
+	}
+
+	template := ts.Spec.TaskTemplate.WorkspaceRef
+	var tmpl axonv1alpha1.Workspace
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: ts.Namespace, Name: template.Name}, &tmpl); err != nil {
+		return nil, fmt.Errorf("fetching Workspace %s: %w", template.Name, err)
+	}
+	if strings.EqualFold(githubRepoName(tmpl.Spec.Repo), repo) {
+		ref := *template
+		return &ref, nil
+	}
+
+	owner, name, _ := strings.Cut(repo, "/")
+	ws := &axonv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", ts.Name, source.RepoKey(owner, name)),
+			Namespace: ts.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, cl, ws, func() error {
+		if ws.Labels == nil {
+			ws.Labels = make(map[string]string)
+		}
+		ws.Labels["axon.io/taskspawner"] = ts.Name
+		ws.Spec = axonv1alpha1.WorkspaceSpec{
+			Repo:      githubRepoURL(tmpl.Spec.Repo, owner, name),
+			Provider:  tmpl.Spec.Provider,
+			SecretRef: tmpl.Spec.SecretRef,
+			Files:     tmpl.Spec.Files,
+		}
+		return controllerutil.SetControllerReference(ts, ws, cl.Scheme())
+	}); err != nil {
+		return nil, fmt.Errorf("creating Workspace %s for %s: %w", ws.Name, repo, err)
+	}
+	return &axonv1alpha1.WorkspaceReference{Name: ws.Name}, nil
+}
+
+// githubRepoName returns the "owner/name" of a GitHub repository URL, in
+// either https or scp-like ssh form.
+func githubRepoName(repoURL string) string {
+	path := repoURL
+	if _, rest, ok := strings.Cut(repoURL, "@"); ok && !strings.Contains(repoURL, "://") {
+		_, path, _ = strings.Cut(rest, ":")
+	} else if u, err := url.Parse(repoURL); err == nil {
+		path = u.Path
+	}
+	return strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
+
+// githubRepoURL returns the URL of repository owner/name on the host of
+// repoURL, in the same form.
+func githubRepoURL(repoURL, owner, name string) string {
+	if user, rest, ok := strings.Cut(repoURL, "@"); ok && !strings.Contains(repoURL, "://") {
+		host, _, _ := strings.Cut(rest, ":")
+		return fmt.Sprintf("%s@%s:%s/%s.git", user, host, owner, name)
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
+	}
+	u.Path = fmt.Sprintf("/%s/%s.git", owner, name)
+	return u.String()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+func TestBuildSource_GitHubIssuesRepos(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When.GitHubIssues.Labels = []string{"bug"}
+	ts.Spec.When.GitHubIssues.Repos = []axonv1alpha1.GitHubRepository{{Name: "axon-core/docs"}}
+	ts.Spec.When.GitHubIssues.Org = &axonv1alpha1.GitHubOrganization{Name: "axon-core", RepoPattern: "^service-"}
+
+	src, err := buildSource(ts, sourceConfig{githubOwner: "axon-core", githubRepo: "axon", githubAPIBaseURL: "https://github.example.com/api/v3"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	multi, ok := src.(*source.GitHubReposSource)
+	if !ok {
+		t.Fatalf("Expected *source.GitHubReposSource, got %T", src)
+	}
+	if len(multi.Repos) != 1 || multi.Repos[0] != "axon-core/docs" || multi.Org != "axon-core" || multi.RepoPattern != "^service-" {
+		t.Errorf("Unexpected repositories %v, org %q and pattern %q", multi.Repos, multi.Org, multi.RepoPattern)
+	}
+	if multi.BaseURL != "https://github.example.com/api/v3" {
+		t.Errorf("Expected the base URL to be passed, got %q", multi.BaseURL)
+	}
+
+	repoSrc, ok := multi.NewSource("axon-core", "docs").(*source.GitHubSource)
+	if !ok {
+		t.Fatalf("Expected *source.GitHubSource for a repository")
+	}
+	if repoSrc.Owner != "axon-core" || repoSrc.Repo != "docs" || len(repoSrc.Labels) != 1 || repoSrc.BaseURL != "https://github.example.com/api/v3" {
+		t.Errorf("Unexpected repository source %+v", repoSrc)
+	}
+}
+
+func TestRunCycleWithSource_MultipleRepos(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When.GitHubIssues.Repos = []axonv1alpha1.GitHubRepository{
+		{Name: "axon-core/axon"},
+		{Name: "axon-core/docs"},
+		{Name: "other/lib", WorkspaceRef: &axonv1alpha1.WorkspaceReference{Name: "lib", Ref: "develop"}},
+	}
+	cl, key := setupTest(t, ts)
+	template := &axonv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ws", Namespace: "default"},
+		Spec: axonv1alpha1.WorkspaceSpec{
+			Repo:      "https://github.com/axon-core/axon.git",
+			Ref:       "main",
+			SecretRef: &axonv1alpha1.SecretReference{Name: "github-token"},
+			Files:     []axonv1alpha1.WorkspaceFile{{Path: "CLAUDE.md", Content: "Be brief."}},
+		},
+	}
+	if err := cl.Create(context.Background(), template); err != nil {
+		t.Fatalf("Creating Workspace: %v", err)
+	}
+
+	axonKey := source.RepoKey("axon-core", "axon")
+	docsKey := source.RepoKey("axon-core", "docs")
+	libKey := source.RepoKey("other", "lib")
+	src := &fakeSource{items: []source.WorkItem{
+		{ID: axonKey + "-1", Number: 1, Repo: "axon-core/axon"},
+		{ID: docsKey + "-1", Number: 1, Repo: "axon-core/docs"},
+		{ID: libKey + "-1", Number: 1, Repo: "other/lib"},
+	}}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	derived := "spawner-" + docsKey
+	tests := []struct {
+		task    string
+		want    axonv1alpha1.WorkspaceReference
+		repoAnn string
+	}{
+		{task: "spawner-" + axonKey + "-1", want: axonv1alpha1.WorkspaceReference{Name: "test-ws"}, repoAnn: "axon-core/axon"},
+		{task: "spawner-" + docsKey + "-1", want: axonv1alpha1.WorkspaceReference{Name: derived}, repoAnn: "axon-core/docs"},
+		{task: "spawner-" + libKey + "-1", want: axonv1alpha1.WorkspaceReference{Name: "lib", Ref: "develop"}, repoAnn: "other/lib"},
+	}
+	for _, tt := range tests {
+		task := getTask(t, cl, tt.task)
+		if task.Spec.WorkspaceRef == nil || *task.Spec.WorkspaceRef != tt.want {
+			t.Errorf("Task %s: expected workspaceRef %+v, got %+v", tt.task, tt.want, task.Spec.WorkspaceRef)
+		}
+		if got := task.Annotations[sourceRepoAnnotation]; got != tt.repoAnn {
+			t.Errorf("Task %s: expected source repo annotation %q, got %q", tt.task, tt.repoAnn, got)
+		}
+	}
+
+	var ws axonv1alpha1.Workspace
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: derived}, &ws); err != nil {
+		t.Fatalf("Getting derived Workspace: %v", err)
+	}
+	if ws.Spec.Repo != "https://github.com/axon-core/docs.git" || ws.Spec.Ref != "" {
+		t.Errorf("Expected the default branch of axon-core/docs, got repo %q and ref %q", ws.Spec.Repo, ws.Spec.Ref)
+	}
+	if ws.Spec.SecretRef == nil || ws.Spec.SecretRef.Name != "github-token" || len(ws.Spec.Files) != 1 {
+		t.Errorf("Expected the secretRef and files of the template workspace, got %+v", ws.Spec)
+	}
+	if owner := metav1.GetControllerOf(&ws); owner == nil || owner.Kind != "TaskSpawner" || owner.Name != "spawner" {
+		t.Errorf("Expected the Workspace to be owned by the TaskSpawner, got %+v", owner)
+	}
+}
+
+// partialReposSource is a fakeSource that reports repositories it could not
+// discover.
+type partialReposSource struct {
+	fakeSource
+	repoErrs []error
+}
+
+func (s *partialReposSource) RepoErrors() []error {
+	return s.repoErrs
+}
+
+func TestRunCycleWithSource_SkipsFailedRepos(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+	recorder := events.NewFakeRecorder(10)
+
+	src := &partialReposSource{
+		fakeSource: fakeSource{items: []source.WorkItem{{ID: "1", Title: "Item 1"}}},
+		repoErrs:   []error{errors.New("discovering items of other/lib: GitHub API returned status 404")},
+	}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if e := <-recorder.Events; e != "Warning DiscoveryFailed Skipping repository: discovering items of other/lib: GitHub API returned status 404" {
+		t.Errorf("Unexpected event %q", e)
+	}
+	getTask(t, cl, "spawner-1")
+}
+
+func TestGitHubRepoURL(t *testing.T) {
+	tests := []struct {
+		repoURL string
+		want    string
+	}{
+		{repoURL: "https://github.com/axon-core/axon.git", want: "https://github.com/axon-core/docs.git"},
+		{repoURL: "https://github.example.com/axon-core/axon", want: "https://github.example.com/axon-core/docs.git"},
+		{repoURL: "git@github.com:axon-core/axon.git", want: "git@github.com:axon-core/docs.git"},
+	}
+	for _, tt := range tests {
+		if got := githubRepoURL(tt.repoURL, "axon-core", "docs"); got != tt.want {
+			t.Errorf("githubRepoURL(%q) = %q, want %q", tt.repoURL, got, tt.want)
+		}
+		if got := githubRepoName(tt.repoURL); got != "axon-core/axon" {
+			t.Errorf("githubRepoName(%q) = %q, want axon-core/axon", tt.repoURL, got)
+		}
+	}
+}
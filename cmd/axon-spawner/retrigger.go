@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+const (
+	// updatedAtAnnotation records the update time of the item when its
+	// Task was created.
+	updatedAtAnnotation = "axon.io/source-updated-at"
+
+	// lastCommentIDAnnotation records the ID of the latest comment on the
+	// item, other than the bot's, when its Task was created.
+	lastCommentIDAnnotation = "axon.io/source-last-comment-id"
+)
+
+// activityAnnotations returns the annotations recording the activity of
+// item on its Task, or nil if the source does not report activity.
+func activityAnnotations(item source.WorkItem) map[string]string {
+	if item.UpdatedAt == "" {
+		return nil
+	}
+	return map[string]string{
+		updatedAtAnnotation:     item.UpdatedAt,
+		lastCommentIDAnnotation: item.LastCommentID,
+	}
+}
+
+// retriggerTaskName returns the name of the Task to create for item, whose
+// first Task baseName already exists, or "" if no Task should be created.
+// Attempts are named baseName-2, baseName-3 and so on. A new attempt is
+// only created once the latest one has finished and the item has seen
+// activity since it was created.
+func retriggerTaskName(policy *axonv1alpha1.RetriggerPolicy, baseName string, item source.WorkItem, existing map[string]*axonv1alpha1.Task) string {
+	latest := existing[baseName]
+	attempt := 1
+	for {
+		t, ok := existing[fmt.Sprintf("%s-%d", baseName, attempt+1)]
+		if !ok {
+			break
+		}
+		latest = t
+		attempt++
+	}
+
+	if !isTaskFinished(latest) || !hasNewActivity(policy, item, latest) {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", baseName, attempt+1)
+}
+
+// hasNewActivity reports whether item has seen activity that re-triggers
+// under policy since task was created. Tasks that did not record the
+// activity of their item are never re-triggered.
+func hasNewActivity(policy *axonv1alpha1.RetriggerPolicy, item source.WorkItem, task *axonv1alpha1.Task) bool {
+	lastCommentID, ok := task.Annotations[lastCommentIDAnnotation]
+	if !ok || item.UpdatedAt == "" {
+		return false
+	}
+	if item.LastCommentID != "" && item.LastCommentID != lastCommentID {
+		return true
+	}
+	if policy.On != axonv1alpha1.RetriggerOnUpdates || item.UpdatedByIgnoredAuthor {
+		return false
+	}
+	updated, err := time.Parse(time.RFC3339, item.UpdatedAt)
+	if err != nil {
+		return false
+	}
+	recorded, err := time.Parse(time.RFC3339, task.Annotations[updatedAtAnnotation])
+	if err != nil {
+		return false
+	}
+	// Reporting the progress of the Task updates the item too.
+	if reported, err := time.Parse(time.RFC3339, task.Annotations[reportedAtAnnotation]); err == nil && reported.After(recorded) {
+		recorded = reported.Add(reportUpdateSkew)
+	}
+	return updated.After(recorded)
+}
+
+func isTaskFinished(t *axonv1alpha1.Task) bool {
+	return t.Status.Phase == axonv1alpha1.TaskPhaseSucceeded || t.Status.Phase == axonv1alpha1.TaskPhaseFailed ||
+		t.Status.Phase == axonv1alpha1.TaskPhaseCancelled
+}
+
+// setIgnoredAuthors configures src to ignore the comments of the bot when
+// tracking the activity of items, if retrigger is set.
+func setIgnoredAuthors(src *source.GitHubSource, retrigger *axonv1alpha1.RetriggerPolicy) {
+	if retrigger == nil {
+		return
+	}
+	src.IgnoreAuthors = retrigger.IgnoreAuthors
+	src.IgnoreSelf = true
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+func newActivityTask(name string, phase axonv1alpha1.TaskPhase, updatedAt, lastCommentID string) axonv1alpha1.Task {
+	task := newTask(name, "default", "spawner", phase)
+	task.Annotations = map[string]string{
+		updatedAtAnnotation:     updatedAt,
+		lastCommentIDAnnotation: lastCommentID,
+	}
+	return task
+}
+
+func listTaskNames(t *testing.T, cl client.Client) map[string]bool {
+	t.Helper()
+	var tasks axonv1alpha1.TaskList
+	if err := cl.List(context.Background(), &tasks, client.InNamespace("default")); err != nil {
+		t.Fatalf("Listing tasks: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, task := range tasks.Items {
+		names[task.Name] = true
+	}
+	return names
+}
+
+func TestRunCycleWithSource_RecordsActivity(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{items: []source.WorkItem{{ID: "1", UpdatedAt: "2026-01-01T10:00:00Z", LastCommentID: "100"}}}
+	if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var task axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "spawner-1"}, &task); err != nil {
+		t.Fatalf("Getting Task: %v", err)
+	}
+	if task.Annotations[updatedAtAnnotation] != "2026-01-01T10:00:00Z" || task.Annotations[lastCommentIDAnnotation] != "100" {
+		t.Errorf("Unexpected annotations %v", task.Annotations)
+	}
+}
+
+func TestRunCycleWithSource_Retrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		existing []axonv1alpha1.Task
+		item     source.WorkItem
+		wantTask string
+	}{
+		{
+			name:     "retrigger disabled",
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "101"},
+		},
+		{
+			name:     "new comment",
+			on:       axonv1alpha1.RetriggerOnComments,
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "101"},
+			wantTask: "spawner-1-2",
+		},
+		{
+			name: "new comment after second attempt",
+			on:   axonv1alpha1.RetriggerOnComments,
+			existing: []axonv1alpha1.Task{
+				newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100"),
+				newActivityTask("spawner-1-2", axonv1alpha1.TaskPhaseFailed, "2026-01-02T10:00:00Z", "101"),
+			},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-03T10:00:00Z", LastCommentID: "102"},
+			wantTask: "spawner-1-3",
+		},
+		{
+			name:     "previous attempt still running",
+			on:       axonv1alpha1.RetriggerOnComments,
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseRunning, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "101"},
+		},
+		{
+			name:     "update without comment",
+			on:       axonv1alpha1.RetriggerOnComments,
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "100"},
+		},
+		{
+			name:     "update on updates",
+			on:       axonv1alpha1.RetriggerOnUpdates,
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "100"},
+			wantTask: "spawner-1-2",
+		},
+		{
+			name: "update by progress report",
+			on:   axonv1alpha1.RetriggerOnUpdates,
+			existing: func() []axonv1alpha1.Task {
+				task := newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")
+				task.Annotations[reportedAtAnnotation] = "2026-01-02T09:59:30Z"
+				return []axonv1alpha1.Task{task}
+			}(),
+			item: source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "100"},
+		},
+		{
+			name:     "bot comment on updates",
+			on:       axonv1alpha1.RetriggerOnUpdates,
+			existing: []axonv1alpha1.Task{newActivityTask("spawner-1", axonv1alpha1.TaskPhaseSucceeded, "2026-01-01T10:00:00Z", "100")},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "100", UpdatedByIgnoredAuthor: true},
+		},
+		{
+			name:     "task without recorded activity",
+			on:       axonv1alpha1.RetriggerOnUpdates,
+			existing: []axonv1alpha1.Task{newTask("spawner-1", "default", "spawner", axonv1alpha1.TaskPhaseSucceeded)},
+			item:     source.WorkItem{ID: "1", UpdatedAt: "2026-01-02T10:00:00Z", LastCommentID: "101"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTaskSpawner("spawner", "default", nil)
+			if tt.on != "" {
+				ts.Spec.Retrigger = &axonv1alpha1.RetriggerPolicy{On: tt.on}
+			}
+			cl, key := setupTest(t, ts, tt.existing...)
+
+			if err := runCycleWithSource(context.Background(), cl, &events.FakeRecorder{}, key, &fakeSource{items: []source.WorkItem{tt.item}}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			names := listTaskNames(t, cl)
+			want := len(tt.existing)
+			if tt.wantTask != "" {
+				want++
+				if !names[tt.wantTask] {
+					t.Errorf("Expected Task %s to be created, got %v", tt.wantTask, names)
+				}
+			}
+			if len(names) != want {
+				t.Errorf("Expected %d Tasks, got %v", want, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+// Reasons for the Throttled condition and Events, naming the limit that
+// was reached.
+const (
+	reasonThrottled           = "Throttled"
+	reasonMaxTasksPerHour     = "MaxTasksPerHour"
+	reasonMaxTasksPerDay      = "MaxTasksPerDay"
+	reasonDailyBudgetExceeded = "DailyBudgetExceeded"
+	reasonWithinLimits        = "WithinLimits"
+)
+
+// taskLimit is the number of Tasks a spawner may still create under its
+// rate and budget limits.
+type taskLimit struct {
+	// remaining is the number of Tasks that may still be created, or -1 if
+	// the spawner has no rate or budget limit.
+	remaining int
+	// reason names the limit capping remaining.
+	reason string
+	// created and maxTasks are the Tasks created in the window of that
+	// limit and its maximum, for rate limits.
+	created  int
+	maxTasks int32
+	window   string
+	// spent and budget are the cost of the Tasks created in the last 24
+	// hours and the daily budget, for the budget limit.
+	spent  float64
+	budget string
+}
+
+// message describes the usage of the limit after createdNow more Tasks
+// were created.
+func (l taskLimit) message(createdNow int) string {
+	if l.reason == reasonDailyBudgetExceeded {
+		return fmt.Sprintf("Tasks created in the last 24 hours cost $%.2f of a daily budget of $%s", l.spent, l.budget)
+	}
+	return fmt.Sprintf("%d of at most %d Tasks created in the last %s", l.created+createdNow, l.maxTasks, l.window)
+}
+
+// hasTaskLimits reports whether ts sets a rate or budget limit.
+func hasTaskLimits(ts *axonv1alpha1.TaskSpawner) bool {
+	return (ts.Spec.MaxTasksPerHour != nil && *ts.Spec.MaxTasksPerHour > 0) ||
+		(ts.Spec.MaxTasksPerDay != nil && *ts.Spec.MaxTasksPerDay > 0) ||
+		dailyBudget(ts) > 0
+}
+
+// dailyBudget returns the daily budget of ts in US dollars, or zero if it
+// has none.
+func dailyBudget(ts *axonv1alpha1.TaskSpawner) float64 {
+	if ts.Spec.DailyBudgetUSD == "" {
+		return 0
+	}
+	budget, err := strconv.ParseFloat(ts.Spec.DailyBudgetUSD, 64)
+	if err != nil {
+		return 0
+	}
+	return budget
+}
+
+// maxRecentTasks is the number of Tasks kept in status.recentTasks, and
+// the maximum of maxTasksPerHour and maxTasksPerDay.
+const maxRecentTasks = 1000
+
+// recentTasks returns the Tasks ts created in the 24 hours before now,
+// oldest first, from its status.recentTasks and tasks, the existing Tasks
+// it created. Recorded Tasks take the cost their agent reported from
+// tasks, so Tasks deleted since keep the cost last recorded for them. A
+// record older than the existing Task of the same name is of an earlier
+// Task that was deleted and is kept as is.
+func recentTasks(ts *axonv1alpha1.TaskSpawner, tasks []axonv1alpha1.Task, now time.Time) []axonv1alpha1.SpawnedTask {
+	existing := make(map[string]*axonv1alpha1.Task, len(tasks))
+	for i := range tasks {
+		existing[tasks[i].Name] = &tasks[i]
+	}
+
+	recorded := make(map[string]bool, len(ts.Status.RecentTasks))
+	recent := make([]axonv1alpha1.SpawnedTask, 0, len(ts.Status.RecentTasks)+len(tasks))
+	for _, r := range ts.Status.RecentTasks {
+		if t, ok := existing[r.Name]; ok && !r.CreationTime.Before(&t.CreationTimestamp) {
+			recorded[r.Name] = true
+			if t.Status.CostUSD != "" {
+				r.CostUSD = t.Status.CostUSD
+			}
+		}
+		recent = append(recent, r)
+	}
+	// Tasks created before the spawner had limits are not recorded yet.
+	for i := range tasks {
+		if recorded[tasks[i].Name] {
+			continue
+		}
+		recent = append(recent, axonv1alpha1.SpawnedTask{
+			Name:         tasks[i].Name,
+			CreationTime: tasks[i].CreationTimestamp,
+			CostUSD:      tasks[i].Status.CostUSD,
+		})
+	}
+
+	slices.SortStableFunc(recent, func(a, b axonv1alpha1.SpawnedTask) int {
+		return a.CreationTime.Compare(b.CreationTime.Time)
+	})
+	recent = slices.DeleteFunc(recent, func(r axonv1alpha1.SpawnedTask) bool {
+		return !r.CreationTime.After(now.Add(-24 * time.Hour))
+	})
+	if len(recent) > maxRecentTasks {
+		recent = recent[len(recent)-maxRecentTasks:]
+	}
+	return recent
+}
+
+// remainingTasks returns how many Tasks ts may still create at now under
+// its rate and budget limits, given recent, the Tasks it created in the
+// last 24 hours as returned by recentTasks. Tasks are counted over the
+// hour and the 24 hours before now, and their cost is the one their agent
+// reported.
+func remainingTasks(ts *axonv1alpha1.TaskSpawner, recent []axonv1alpha1.SpawnedTask, now time.Time) taskLimit {
+	var lastHour, lastDay int
+	var spent float64
+	for _, r := range recent {
+		if !r.CreationTime.After(now.Add(-24 * time.Hour)) {
+			continue
+		}
+		lastDay++
+		if r.CreationTime.After(now.Add(-time.Hour)) {
+			lastHour++
+		}
+		if cost, err := strconv.ParseFloat(r.CostUSD, 64); err == nil {
+			spent += cost
+		}
+	}
+
+	limit := taskLimit{remaining: -1}
+	capAt := func(maxTasks *int32, created int, reason, window string) {
+		if maxTasks == nil || *maxTasks == 0 {
+			return
+		}
+		remaining := max(int(*maxTasks)-created, 0)
+		if limit.remaining < 0 || remaining < limit.remaining {
+			limit = taskLimit{
+				remaining: remaining,
+				reason:    reason,
+				created:   created,
+				maxTasks:  *maxTasks,
+				window:    window,
+			}
+		}
+	}
+	capAt(ts.Spec.MaxTasksPerHour, lastHour, reasonMaxTasksPerHour, "hour")
+	capAt(ts.Spec.MaxTasksPerDay, lastDay, reasonMaxTasksPerDay, "24 hours")
+
+	if budget := dailyBudget(ts); budget > 0 && spent >= budget {
+		limit = taskLimit{
+			remaining: 0,
+			reason:    reasonDailyBudgetExceeded,
+			spent:     spent,
+			budget:    ts.Spec.DailyBudgetUSD,
+		}
+	}
+	return limit
+}
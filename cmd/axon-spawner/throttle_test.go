@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+func newTaskCreatedAt(name string, created time.Time, costUSD string) axonv1alpha1.Task {
+	task := newTask(name, "default", "spawner", axonv1alpha1.TaskPhaseSucceeded)
+	task.CreationTimestamp = metav1.NewTime(created)
+	task.Status.CostUSD = costUSD
+	return task
+}
+
+func TestRemainingTasks(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tasks := []axonv1alpha1.Task{
+		newTaskCreatedAt("spawner-1", now.Add(-10*time.Minute), "1.50"),
+		newTaskCreatedAt("spawner-2", now.Add(-2*time.Hour), "2"),
+		newTaskCreatedAt("spawner-3", now.Add(-23*time.Hour), ""),
+		newTaskCreatedAt("spawner-4", now.Add(-25*time.Hour), "100"),
+	}
+
+	tests := []struct {
+		name          string
+		perHour       *int32
+		perDay        *int32
+		budget        string
+		wantRemaining int
+		wantReason    string
+	}{
+		{name: "no limits", wantRemaining: -1},
+		{name: "zero limits", perHour: int32Ptr(0), perDay: int32Ptr(0), budget: "0", wantRemaining: -1},
+		{name: "hourly limit", perHour: int32Ptr(3), wantRemaining: 2, wantReason: reasonMaxTasksPerHour},
+		{name: "daily limit below hourly limit", perHour: int32Ptr(3), perDay: int32Ptr(4), wantRemaining: 1, wantReason: reasonMaxTasksPerDay},
+		{name: "daily limit reached", perDay: int32Ptr(2), wantRemaining: 0, wantReason: reasonMaxTasksPerDay},
+		{name: "within budget", budget: "5", wantRemaining: -1},
+		{name: "budget exceeded", perHour: int32Ptr(10), budget: "3.50", wantRemaining: 0, wantReason: reasonDailyBudgetExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTaskSpawner("spawner", "default", nil)
+			ts.Spec.MaxTasksPerHour = tt.perHour
+			ts.Spec.MaxTasksPerDay = tt.perDay
+			ts.Spec.DailyBudgetUSD = tt.budget
+
+			limit := remainingTasks(ts, recentTasks(ts, tasks, now), now)
+			if limit.remaining != tt.wantRemaining || limit.reason != tt.wantReason {
+				t.Errorf("Expected %d remaining Tasks limited by %q, got %d limited by %q", tt.wantRemaining, tt.wantReason, limit.remaining, limit.reason)
+			}
+		})
+	}
+}
+
+func TestRecentTasks(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Status.RecentTasks = []axonv1alpha1.SpawnedTask{
+		{Name: "spawner-1", CreationTime: metav1.NewTime(now.Add(-25 * time.Hour)), CostUSD: "9"},
+		{Name: "spawner-2", CreationTime: metav1.NewTime(now.Add(-3 * time.Hour)), CostUSD: "1"},
+		{Name: "spawner-3", CreationTime: metav1.NewTime(now.Add(-2 * time.Hour))},
+		{Name: "spawner-4", CreationTime: metav1.NewTime(now.Add(-time.Hour))},
+	}
+	tasks := []axonv1alpha1.Task{
+		// spawner-2 was deleted, spawner-3 reported its cost and spawner-4
+		// was deleted and created again for the same item.
+		newTaskCreatedAt("spawner-3", now.Add(-2*time.Hour), "2.50"),
+		newTaskCreatedAt("spawner-4", now.Add(-10*time.Minute), ""),
+		newTaskCreatedAt("spawner-5", now.Add(-5*time.Minute), ""),
+	}
+
+	got := recentTasks(ts, tasks, now)
+	want := []axonv1alpha1.SpawnedTask{
+		{Name: "spawner-2", CreationTime: metav1.NewTime(now.Add(-3 * time.Hour)), CostUSD: "1"},
+		{Name: "spawner-3", CreationTime: metav1.NewTime(now.Add(-2 * time.Hour)), CostUSD: "2.50"},
+		{Name: "spawner-4", CreationTime: metav1.NewTime(now.Add(-time.Hour))},
+		{Name: "spawner-4", CreationTime: metav1.NewTime(now.Add(-10 * time.Minute))},
+		{Name: "spawner-5", CreationTime: metav1.NewTime(now.Add(-5 * time.Minute))},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d recent Tasks, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || !got[i].CreationTime.Equal(&want[i].CreationTime) || got[i].CostUSD != want[i].CostUSD {
+			t.Errorf("Expected recent Task %d to be %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRunCycleWithSource_ThrottledAfterTasksDeleted(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.MaxTasksPerDay = int32Ptr(2)
+	ts.Spec.DailyBudgetUSD = "5"
+	cl, key := setupTest(t, ts)
+
+	src := &fakeSource{items: []source.WorkItem{
+		{ID: "1", Title: "First"},
+		{ID: "2", Title: "Second"},
+	}}
+	recorder := events.NewFakeRecorder(10)
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The Tasks report their cost and are deleted, as by
+	// ttlSecondsAfterFinished.
+	for _, name := range []string{"spawner-1", "spawner-2"} {
+		task := getTask(t, cl, name)
+		task.Status.CostUSD = "3"
+		if err := cl.Update(context.Background(), task); err != nil {
+			t.Fatalf("Updating Task: %v", err)
+		}
+	}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, &fakeSource{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, name := range []string{"spawner-1", "spawner-2"} {
+		if err := cl.Delete(context.Background(), getTask(t, cl, name)); err != nil {
+			t.Fatalf("Deleting Task: %v", err)
+		}
+	}
+
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if names := listTaskNames(t, cl); len(names) != 0 {
+		t.Errorf("Expected no Tasks to be created again, got %v", names)
+	}
+
+	var updated axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, axonv1alpha1.ConditionThrottled)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonDailyBudgetExceeded {
+		t.Fatalf("Expected Throttled condition with reason %s, got %+v", reasonDailyBudgetExceeded, cond)
+	}
+	if len(updated.Status.RecentTasks) != 2 {
+		t.Errorf("Expected 2 recent Tasks, got %+v", updated.Status.RecentTasks)
+	}
+}
+
+func TestRunCycleWithSource_Throttled(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.MaxTasksPerHour = int32Ptr(2)
+	existing := newTaskCreatedAt("spawner-1", time.Now().Add(-time.Minute), "")
+	cl, key := setupTest(t, ts, existing)
+
+	src := &fakeSource{items: []source.WorkItem{
+		{ID: "1", Title: "Done"},
+		{ID: "2", Title: "Second"},
+		{ID: "3", Title: "Third"},
+		{ID: "4", Title: "Fourth"},
+	}}
+	recorder := events.NewFakeRecorder(10)
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := listTaskNames(t, cl)
+	if len(names) != 2 || !names["spawner-2"] {
+		t.Fatalf("Expected a single new Task under the hourly limit, got %v", names)
+	}
+
+	var updated axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	if updated.Status.Phase != axonv1alpha1.TaskSpawnerPhaseThrottled {
+		t.Errorf("Expected phase Throttled, got %q", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, axonv1alpha1.ConditionThrottled)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonMaxTasksPerHour {
+		t.Fatalf("Expected Throttled condition with reason %s, got %+v", reasonMaxTasksPerHour, cond)
+	}
+	want := "Throttled by MaxTasksPerHour: 2 of at most 2 Tasks created in the last hour, skipped 2 items"
+	if cond.Message != want || updated.Status.Message != want {
+		t.Errorf("Expected message %q, got condition message %q and status message %q", want, cond.Message, updated.Status.Message)
+	}
+
+	// The event is recorded when the spawner becomes throttled, not on
+	// every cycle it stays throttled.
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var throttledEvents int
+	for len(recorder.Events) > 0 {
+		if e := <-recorder.Events; strings.HasPrefix(e, "Warning Throttled ") {
+			throttledEvents++
+		}
+	}
+	if throttledEvents != 1 {
+		t.Errorf("Expected 1 Throttled event, got %d", throttledEvents)
+	}
+
+	// Raising the limit lifts the throttle.
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	updated.Spec.MaxTasksPerHour = int32Ptr(10)
+	if err := cl.Update(context.Background(), &updated); err != nil {
+		t.Fatalf("Updating TaskSpawner: %v", err)
+	}
+	if err := runCycleWithSource(context.Background(), cl, recorder, key, src); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := cl.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	if updated.Status.Phase != axonv1alpha1.TaskSpawnerPhaseRunning || meta.IsStatusConditionTrue(updated.Status.Conditions, axonv1alpha1.ConditionThrottled) {
+		t.Errorf("Expected the spawner to no longer be throttled, got phase %q", updated.Status.Phase)
+	}
+	if names := listTaskNames(t, cl); len(names) != 4 {
+		t.Errorf("Expected Tasks for all items, got %v", names)
+	}
+}
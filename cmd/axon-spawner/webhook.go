@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+const (
+	// webhookPath is the path on which GitHub webhook deliveries are received.
+	webhookPath = "/webhook"
+
+	// maxWebhookPayloadBytes is the maximum size of a GitHub webhook payload.
+	maxWebhookPayloadBytes = 25 << 20
+
+	// webhookQueueSize is the number of verified deliveries that can wait
+	// for the cycle goroutine before new deliveries are refused.
+	webhookQueueSize = 100
+)
+
+// webhookSource is a source.Source for the work item of a single webhook
+// delivery.
+type webhookSource struct {
+	items []source.WorkItem
+}
+
+// Discover returns the delivered work items.
+func (s *webhookSource) Discover(_ context.Context) ([]source.WorkItem, error) {
+	return s.items, nil
+}
+
+// webhookItemSource is implemented by the sources that turn GitHub webhook
+// deliveries into work items.
+type webhookItemSource interface {
+	ItemFromWebhook(ctx context.Context, event string, payload []byte) (*source.WorkItem, error)
+}
+
+// webhookDelivery is a GitHub webhook delivery whose signature has been
+// verified, waiting to be processed by the cycle goroutine.
+type webhookDelivery struct {
+	event   string
+	id      string
+	payload []byte
+}
+
+// webhookHandler receives GitHub webhook deliveries for a TaskSpawner. It
+// acknowledges each verified delivery as soon as it is queued so that
+// GitHub's delivery timeout does not depend on how long a discovery cycle
+// takes; the queued deliveries are processed by a deliveryProcessor.
+type webhookHandler struct {
+	secret []byte
+	// deliveries queues verified deliveries for the cycle goroutine.
+	deliveries chan<- webhookDelivery
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("spawner").WithName("webhook")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadBytes))
+	if err != nil {
+		http.Error(w, "reading payload", http.StatusBadRequest)
+		return
+	}
+	if !source.VerifyGitHubSignature(h.secret, payload, r.Header.Get("X-Hub-Signature-256")) {
+		log.Info("Rejecting delivery with invalid signature", "delivery", r.Header.Get("X-GitHub-Delivery"))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	if !source.IsSupportedGitHubEvent(event) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	d := webhookDelivery{event: event, id: r.Header.Get("X-GitHub-Delivery"), payload: payload}
+	select {
+	case h.deliveries <- d:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		// GitHub reports the delivery as failed and it can be redelivered;
+		// the polling fallback picks up the item otherwise.
+		log.Info("Dropping delivery because the queue is full", "event", event, "delivery", d.id)
+		http.Error(w, "delivery queue full", http.StatusServiceUnavailable)
+	}
+}
+
+// deliveryProcessor creates Tasks for the webhook deliveries queued by
+// webhookHandler through runCycleWithSource.
+type deliveryProcessor struct {
+	cl       client.Client
+	recorder events.EventRecorder
+	key      types.NamespacedName
+	// buildSource returns the source used to filter deliveries and fetch
+	// comments.
+	buildSource func(ts *axonv1alpha1.TaskSpawner) (source.Source, error)
+}
+
+// process turns d into a work item, if it matches the TaskSpawner's
+// filters, and creates a Task for it.
+func (p *deliveryProcessor) process(ctx context.Context, d webhookDelivery) error {
+	log := ctrl.Log.WithName("spawner").WithName("webhook")
+
+	var ts axonv1alpha1.TaskSpawner
+	if err := p.cl.Get(ctx, p.key, &ts); err != nil {
+		return fmt.Errorf("fetching TaskSpawner: %w", err)
+	}
+	src, err := p.buildSource(&ts)
+	if err != nil {
+		return fmt.Errorf("building source: %w", err)
+	}
+	gh, ok := src.(webhookItemSource)
+	if !ok {
+		return nil
+	}
+
+	item, err := gh.ItemFromWebhook(ctx, d.event, d.payload)
+	if err != nil {
+		return fmt.Errorf("handling %s delivery %s: %w", d.event, d.id, err)
+	}
+	if item == nil {
+		return nil
+	}
+
+	log.Info("Received work item", "event", d.event, "item", item.ID, "delivery", d.id)
+	if err := runCycleWithSource(ctx, p.cl, p.recorder, p.key, &webhookSource{items: []source.WorkItem{*item}}); err != nil {
+		return fmt.Errorf("creating Task for item %s: %w", item.ID, err)
+	}
+	return nil
+}
+
+// waitForNextCycle waits for d to elapse, processing webhook deliveries as
+// they arrive in the meantime. It returns true if ctx is done. deliveries
+// may be nil when the webhook receiver is disabled.
+func waitForNextCycle(ctx context.Context, d time.Duration, deliveries <-chan webhookDelivery, p *deliveryProcessor) bool {
+	log := ctrl.Log.WithName("spawner").WithName("webhook")
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-timer.C:
+			return false
+		case delivery := <-deliveries:
+			if err := p.process(ctx, delivery); err != nil {
+				log.Error(err, "Unable to process delivery", "delivery", delivery.id)
+			}
+		}
+	}
+}
+
+// serveWebhook serves h on addr until ctx is done. It does nothing if addr
+// is "0".
+func serveWebhook(ctx context.Context, addr string, h http.Handler) {
+	if addr == "0" {
+		return
+	}
+	log := ctrl.Log.WithName("spawner")
+
+	mux := http.NewServeMux()
+	mux.Handle(webhookPath, h)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		log.Info("serving GitHub webhook", "address", addr, "path", webhookPath)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "webhook server failed")
+		}
+	}()
+}
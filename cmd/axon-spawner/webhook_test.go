@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+const issueOpenedPayload = `{
+	"action": "opened",
+	"issue": {"number": 7, "title": "Crash", "body": "It crashes", "html_url": "https://github.com/axon-core/axon/issues/7", "state": "open", "labels": [{"name": "bug"}]},
+	"repository": {"name": "axon", "owner": {"login": "axon-core"}}
+}`
+
+func newWebhookHandler(t *testing.T, ts *axonv1alpha1.TaskSpawner) (*webhookHandler, *deliveryProcessor, chan webhookDelivery, client.Client) {
+	t.Helper()
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"body": "still broken"}]`))
+	}))
+	t.Cleanup(github.Close)
+
+	cl, key := setupTest(t, ts)
+	deliveries := make(chan webhookDelivery, 1)
+	h := &webhookHandler{
+		secret:     []byte("s3cr3t"),
+		deliveries: deliveries,
+	}
+	p := &deliveryProcessor{
+		cl:       cl,
+		recorder: &events.FakeRecorder{},
+		key:      key,
+		buildSource: func(ts *axonv1alpha1.TaskSpawner) (source.Source, error) {
+			return buildSource(ts, sourceConfig{githubOwner: "axon-core", githubRepo: "axon", githubAPIBaseURL: github.URL})
+		},
+	}
+	return h, p, deliveries, cl
+}
+
+func deliver(h http.Handler, event, payload, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, webhookPath, strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func signPayload(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler_CreatesTask(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	h, p, deliveries, cl := newWebhookHandler(t, ts)
+
+	rec := deliver(h, source.GitHubEventIssues, issueOpenedPayload, signPayload("s3cr3t", issueOpenedPayload))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("Expected the delivery to be queued, got %d queued", len(deliveries))
+	}
+	if err := p.process(context.Background(), <-deliveries); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	var task axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "spawner-7"}, &task); err != nil {
+		t.Fatalf("Expected Task spawner-7 to be created: %v", err)
+	}
+	if !strings.Contains(task.Spec.Prompt, "still broken") {
+		t.Errorf("Expected prompt to contain the issue comments, got %q", task.Spec.Prompt)
+	}
+
+	var updated axonv1alpha1.TaskSpawner
+	if err := cl.Get(context.Background(), p.key, &updated); err != nil {
+		t.Fatalf("Getting TaskSpawner: %v", err)
+	}
+	if updated.Status.TotalTasksCreated != 1 {
+		t.Errorf("Expected TotalTasksCreated 1, got %d", updated.Status.TotalTasksCreated)
+	}
+	if updated.Status.LastDiscoveryTime != nil {
+		t.Error("Expected a webhook delivery not to update LastDiscoveryTime")
+	}
+}
+
+func TestWebhookHandler_RejectsInvalidSignature(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	h, _, deliveries, _ := newWebhookHandler(t, ts)
+
+	rec := deliver(h, source.GitHubEventIssues, issueOpenedPayload, signPayload("wrong", issueOpenedPayload))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", rec.Code)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("Expected no queued deliveries, got %d", len(deliveries))
+	}
+}
+
+func TestWebhookHandler_IgnoresFilteredDeliveries(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	ts.Spec.When.GitHubIssues.ExcludeLabels = []string{"bug"}
+	h, p, deliveries, cl := newWebhookHandler(t, ts)
+
+	for _, event := range []string{"ping", source.GitHubEventIssues} {
+		rec := deliver(h, event, issueOpenedPayload, signPayload("s3cr3t", issueOpenedPayload))
+		if rec.Code != http.StatusAccepted {
+			t.Errorf("Expected status 202 for %s event, got %d", event, rec.Code)
+		}
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("Expected only the issues delivery to be queued, got %d queued", len(deliveries))
+	}
+	if err := p.process(context.Background(), <-deliveries); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	var tasks axonv1alpha1.TaskList
+	if err := cl.List(context.Background(), &tasks); err != nil {
+		t.Fatalf("Listing Tasks: %v", err)
+	}
+	if len(tasks.Items) != 0 {
+		t.Errorf("Expected no Tasks, got %d", len(tasks.Items))
+	}
+}
+
+func TestWebhookHandler_RejectsGet(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	h, _, _, _ := newWebhookHandler(t, ts)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, webhookPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_RefusesWhenQueueFull(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	h, _, deliveries, _ := newWebhookHandler(t, ts)
+
+	signature := signPayload("s3cr3t", issueOpenedPayload)
+	if rec := deliver(h, source.GitHubEventIssues, issueOpenedPayload, signature); rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d", rec.Code)
+	}
+	if rec := deliver(h, source.GitHubEventIssues, issueOpenedPayload, signature); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 when the queue is full, got %d", rec.Code)
+	}
+	if len(deliveries) != 1 {
+		t.Errorf("Expected 1 queued delivery, got %d", len(deliveries))
+	}
+}
+
+func TestWaitForNextCycle_ProcessesDeliveries(t *testing.T) {
+	ts := newTaskSpawner("spawner", "default", nil)
+	h, p, deliveries, cl := newWebhookHandler(t, ts)
+
+	deliver(h, source.GitHubEventIssues, issueOpenedPayload, signPayload("s3cr3t", issueOpenedPayload))
+	if done := waitForNextCycle(context.Background(), 100*time.Millisecond, deliveries, p); done {
+		t.Fatal("Expected waitForNextCycle to return false when the interval elapses")
+	}
+
+	var task axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "spawner-7"}, &task); err != nil {
+		t.Fatalf("Expected Task spawner-7 to be created while waiting: %v", err)
+	}
+}
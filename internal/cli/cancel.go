@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func newCancelCommand(cfg *ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel <task>",
+		Short: "Cancel a running task while keeping its status and outputs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("task name is required\nUsage: %s", cmd.Use)
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: expected 1 task name, got %d\nUsage: %s", len(args), cmd.Use)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, ns, err := cfg.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			task := &axonv1alpha1.Task{}
+			if err := cl.Get(ctx, client.ObjectKey{Name: args[0], Namespace: ns}, task); err != nil {
+				return fmt.Errorf("getting task: %w", err)
+			}
+
+			switch task.Status.Phase {
+			case axonv1alpha1.TaskPhaseSucceeded, axonv1alpha1.TaskPhaseFailed, axonv1alpha1.TaskPhaseCancelled:
+				return fmt.Errorf("task %q has already finished (%s)", args[0], task.Status.Phase)
+			}
+
+			if task.Spec.Cancel {
+				fmt.Fprintf(os.Stdout, "task/%s already cancelled\n", args[0])
+				return nil
+			}
+
+			patch := client.MergeFrom(task.DeepCopy())
+			task.Spec.Cancel = true
+			if err := cl.Patch(ctx, task, patch); err != nil {
+				return fmt.Errorf("cancelling task: %w", err)
+			}
+			fmt.Fprintf(os.Stdout, "task/%s cancelled\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.ValidArgsFunction = completeTaskNames(cfg)
+
+	return cmd
+}
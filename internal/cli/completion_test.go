@@ -22,6 +22,9 @@ func TestValidArgsFunctionWired(t *testing.T) {
 		{"delete workspace", []string{"delete", "workspace"}},
 		{"delete taskspawner", []string{"delete", "taskspawner"}},
 		{"logs", []string{"logs"}},
+		{"cancel", []string{"cancel"}},
+		{"suspend taskspawner", []string{"suspend", "taskspawner"}},
+		{"resume taskspawner", []string{"resume", "taskspawner"}},
 	}
 
 	for _, tt := range tests {
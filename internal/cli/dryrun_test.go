@@ -288,6 +288,15 @@ func TestInstallCommand_DryRun(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
+	// The manifests are larger than the pipe buffer, so drain the pipe
+	// while the command writes to it.
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		out.ReadFrom(r)
+		close(done)
+	}()
+
 	if err := cmd.Execute(); err != nil {
 		w.Close()
 		os.Stdout = old
@@ -296,8 +305,7 @@ func TestInstallCommand_DryRun(t *testing.T) {
 
 	w.Close()
 	os.Stdout = old
-	var out bytes.Buffer
-	out.ReadFrom(r)
+	<-done
 	output := out.String()
 
 	if !strings.Contains(output, "CustomResourceDefinition") {
@@ -317,3 +325,41 @@ func TestInstallCommand_DryRun(t *testing.T) {
 		t.Errorf("dry-run should not print installation messages, got:\n%s", output[:min(len(output), 500)])
 	}
 }
+
+func TestRunCommand_DryRun_DependsOn(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("secret: my-secret\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetArgs([]string{
+		"run",
+		"--config", cfgPath,
+		"--dry-run",
+		"--prompt", "review the change",
+		"--name", "review",
+		"--depends-on", "investigate,implement",
+	})
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	if err := cmd.Execute(); err != nil {
+		w.Close()
+		os.Stdout = old
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w.Close()
+	os.Stdout = old
+	var out bytes.Buffer
+	out.ReadFrom(r)
+	output := out.String()
+
+	if !strings.Contains(output, "dependsOn:\n  - investigate\n  - implement") {
+		t.Errorf("expected YAML output to contain dependsOn list, got:\n%s", output)
+	}
+}
@@ -104,6 +104,10 @@ func waitForPod(ctx context.Context, cl client.Client, name, namespace string) (
 			return nil, fmt.Errorf("task %q failed before starting: %s", name, msg)
 		}
 
+		if task.Status.Phase == axonv1alpha1.TaskPhaseCancelled && task.Status.PodName == "" {
+			return nil, fmt.Errorf("task %q was cancelled before starting", name)
+		}
+
 		if task.Status.PodName != "" {
 			return task, nil
 		}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"sigs.k8s.io/yaml"
 
@@ -45,12 +48,21 @@ func printTaskDetail(w io.Writer, t *axonv1alpha1.Task) {
 	if t.Spec.WorkspaceRef != nil {
 		printField(w, "Workspace", t.Spec.WorkspaceRef.Name)
 	}
+	if len(t.Spec.DependsOn) > 0 {
+		printField(w, "Depends On", strings.Join(t.Spec.DependsOn, ", "))
+	}
+	if t.Spec.Cancel {
+		printField(w, "Cancel", "true")
+	}
 	if t.Status.JobName != "" {
 		printField(w, "Job", t.Status.JobName)
 	}
 	if t.Status.PodName != "" {
 		printField(w, "Pod", t.Status.PodName)
 	}
+	if t.Spec.RetryPolicy != nil {
+		printField(w, "Attempts", fmt.Sprintf("%d/%d", len(t.Status.Attempts), t.Spec.RetryPolicy.MaxAttempts))
+	}
 	if t.Status.StartTime != nil {
 		printField(w, "Start Time", t.Status.StartTime.Time.Format(time.RFC3339))
 	}
@@ -60,12 +72,32 @@ func printTaskDetail(w io.Writer, t *axonv1alpha1.Task) {
 	if t.Status.Message != "" {
 		printField(w, "Message", t.Status.Message)
 	}
+	if t.Status.CostUSD != "" {
+		printField(w, "Cost (USD)", t.Status.CostUSD)
+	}
+	if t.Status.NumTurns > 0 {
+		printField(w, "Turns", fmt.Sprintf("%d", t.Status.NumTurns))
+	}
+	if t.Status.InputTokens > 0 || t.Status.OutputTokens > 0 {
+		printField(w, "Tokens", fmt.Sprintf("input=%d output=%d", t.Status.InputTokens, t.Status.OutputTokens))
+	}
 	if len(t.Status.Outputs) > 0 {
 		printField(w, "Outputs", t.Status.Outputs[0])
 		for _, o := range t.Status.Outputs[1:] {
 			fmt.Fprintf(w, "%-20s%s\n", "", o)
 		}
 	}
+	if len(t.Status.Results) > 0 {
+		keys := make([]string, 0, len(t.Status.Results))
+		for k := range t.Status.Results {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		printField(w, "Results", fmt.Sprintf("%s=%s", keys[0], t.Status.Results[keys[0]]))
+		for _, k := range keys[1:] {
+			fmt.Fprintf(w, "%-20s%s=%s\n", "", k, t.Status.Results[k])
+		}
+	}
 }
 
 func printTaskSpawnerTable(w io.Writer, spawners []axonv1alpha1.TaskSpawner, allNamespaces bool) {
@@ -84,8 +116,26 @@ func printTaskSpawnerTable(w io.Writer, spawners []axonv1alpha1.TaskSpawner, all
 			} else {
 				source = "GitHub Issues"
 			}
+		} else if s.Spec.When.GitHubPullRequests != nil {
+			if s.Spec.TaskTemplate.WorkspaceRef != nil {
+				source = s.Spec.TaskTemplate.WorkspaceRef.Name
+			} else {
+				source = "GitHub Pull Requests"
+			}
+		} else if s.Spec.When.GitLabIssues != nil {
+			if s.Spec.TaskTemplate.WorkspaceRef != nil {
+				source = s.Spec.TaskTemplate.WorkspaceRef.Name
+			} else {
+				source = "GitLab Issues"
+			}
+		} else if s.Spec.When.Jira != nil {
+			source = "jira: " + s.Spec.When.Jira.BaseURL
 		} else if s.Spec.When.Cron != nil {
 			source = "cron: " + s.Spec.When.Cron.Schedule
+		} else if s.Spec.When.HTTPJSON != nil {
+			source = "http: " + s.Spec.When.HTTPJSON.URL
+		} else if s.Spec.When.KubernetesEvents != nil {
+			source = "k8s: " + s.Spec.When.KubernetesEvents.Kind
 		}
 		if allNamespaces {
 			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
@@ -100,10 +150,31 @@ func printTaskSpawnerTable(w io.Writer, spawners []axonv1alpha1.TaskSpawner, all
 	tw.Flush()
 }
 
+// printGitHubRepos prints the repositories and organization of a GitHub
+// source, if set.
+func printGitHubRepos(w io.Writer, repos []axonv1alpha1.GitHubRepository, org *axonv1alpha1.GitHubOrganization) {
+	if len(repos) > 0 {
+		names := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		printField(w, "Repositories", fmt.Sprintf("%v", names))
+	}
+	if org != nil {
+		printField(w, "Organization", org.Name)
+		if org.RepoPattern != "" {
+			printField(w, "Repo Pattern", org.RepoPattern)
+		}
+	}
+}
+
 func printTaskSpawnerDetail(w io.Writer, ts *axonv1alpha1.TaskSpawner) {
 	printField(w, "Name", ts.Name)
 	printField(w, "Namespace", ts.Namespace)
 	printField(w, "Phase", string(ts.Status.Phase))
+	if ts.Spec.Suspend {
+		printField(w, "Suspended", "true")
+	}
 	if ts.Spec.TaskTemplate.WorkspaceRef != nil {
 		printField(w, "Workspace", ts.Spec.TaskTemplate.WorkspaceRef.Name)
 	}
@@ -119,15 +190,95 @@ func printTaskSpawnerDetail(w io.Writer, ts *axonv1alpha1.TaskSpawner) {
 		if len(gh.Labels) > 0 {
 			printField(w, "Labels", fmt.Sprintf("%v", gh.Labels))
 		}
+		printGitHubRepos(w, gh.Repos, gh.Org)
+	} else if ts.Spec.When.GitHubPullRequests != nil {
+		gh := ts.Spec.When.GitHubPullRequests
+		printField(w, "Source", "GitHub Pull Requests")
+		if gh.State != "" {
+			printField(w, "State", gh.State)
+		}
+		if gh.ReviewState != "" {
+			printField(w, "Review State", gh.ReviewState)
+		}
+		if gh.Draft != nil {
+			printField(w, "Draft", fmt.Sprintf("%t", *gh.Draft))
+		}
+		if gh.BaseBranch != "" {
+			printField(w, "Base Branch", gh.BaseBranch)
+		}
+		if gh.Author != "" {
+			printField(w, "Author", gh.Author)
+		}
+		if len(gh.Labels) > 0 {
+			printField(w, "Labels", fmt.Sprintf("%v", gh.Labels))
+		}
+		printGitHubRepos(w, gh.Repos, gh.Org)
+	} else if ts.Spec.When.GitLabIssues != nil {
+		gl := ts.Spec.When.GitLabIssues
+		printField(w, "Source", "GitLab Issues")
+		if gl.Project != "" {
+			printField(w, "Project", gl.Project)
+		}
+		if len(gl.Types) > 0 {
+			printField(w, "Types", fmt.Sprintf("%v", gl.Types))
+		}
+		if gl.State != "" {
+			printField(w, "State", gl.State)
+		}
+		if len(gl.Labels) > 0 {
+			printField(w, "Labels", fmt.Sprintf("%v", gl.Labels))
+		}
+	} else if ts.Spec.When.Jira != nil {
+		printField(w, "Source", "Jira")
+		printField(w, "Jira URL", ts.Spec.When.Jira.BaseURL)
+		printField(w, "JQL", ts.Spec.When.Jira.JQL)
 	} else if ts.Spec.When.Cron != nil {
 		printField(w, "Source", "Cron")
 		printField(w, "Schedule", ts.Spec.When.Cron.Schedule)
+		if ts.Spec.When.Cron.TimeZone != "" {
+			printField(w, "Time Zone", ts.Spec.When.Cron.TimeZone)
+		}
+		if ts.Spec.When.Cron.ConcurrencyPolicy != "" {
+			printField(w, "Concurrency Policy", string(ts.Spec.When.Cron.ConcurrencyPolicy))
+		}
+	} else if ts.Spec.When.HTTPJSON != nil {
+		printField(w, "Source", "HTTP JSON")
+		printField(w, "URL", ts.Spec.When.HTTPJSON.URL)
+		if ts.Spec.When.HTTPJSON.ItemsPath != "" {
+			printField(w, "Items Path", ts.Spec.When.HTTPJSON.ItemsPath)
+		}
+	} else if ke := ts.Spec.When.KubernetesEvents; ke != nil {
+		printField(w, "Source", "Kubernetes Events")
+		printField(w, "API Version", ke.APIVersion)
+		printField(w, "Kind", ke.Kind)
+		if ke.LabelSelector != nil {
+			printField(w, "Label Selector", metav1.FormatLabelSelector(ke.LabelSelector))
+		}
+		if ke.Predicate != nil {
+			predicate := ke.Predicate.Path
+			if len(ke.Predicate.Values) > 0 {
+				predicate += " in " + strings.Join(ke.Predicate.Values, ", ")
+			}
+			printField(w, "Predicate", predicate)
+		}
 	}
 	printField(w, "Task Type", ts.Spec.TaskTemplate.Type)
 	if ts.Spec.TaskTemplate.Model != "" {
 		printField(w, "Model", ts.Spec.TaskTemplate.Model)
 	}
 	printField(w, "Poll Interval", ts.Spec.PollInterval)
+	if ts.Spec.MaxTasksPerHour != nil && *ts.Spec.MaxTasksPerHour > 0 {
+		printField(w, "Max Tasks Per Hour", fmt.Sprintf("%d", *ts.Spec.MaxTasksPerHour))
+	}
+	if ts.Spec.MaxTasksPerDay != nil && *ts.Spec.MaxTasksPerDay > 0 {
+		printField(w, "Max Tasks Per Day", fmt.Sprintf("%d", *ts.Spec.MaxTasksPerDay))
+	}
+	if ts.Spec.DailyBudgetUSD != "" {
+		printField(w, "Daily Budget (USD)", ts.Spec.DailyBudgetUSD)
+	}
+	if ts.Spec.Priority != nil && ts.Spec.Priority.Order != "" {
+		printField(w, "Priority Order", ts.Spec.Priority.Order)
+	}
 	if ts.Status.DeploymentName != "" {
 		printField(w, "Deployment", ts.Status.DeploymentName)
 	}
@@ -37,6 +37,7 @@ func newRunCommand(cfg *ClientConfig) *cobra.Command {
 		skillFlags     []string
 		agentFlags     []string
 		agentConfigRef string
+		dependsOn      []string
 	)
 
 	cmd := &cobra.Command{
@@ -240,8 +241,9 @@ func newRunCommand(cfg *ClientConfig) *cobra.Command {
 							Name: secret,
 						},
 					},
-					Model: model,
-					Image: image,
+					Model:     model,
+					Image:     image,
+					DependsOn: dependsOn,
 				},
 			}
 
@@ -328,11 +330,13 @@ func newRunCommand(cfg *ClientConfig) *cobra.Command {
 	cmd.Flags().StringArrayVar(&skillFlags, "skill", nil, "skill definition as name=content or name=@file")
 	cmd.Flags().StringArrayVar(&agentFlags, "agent", nil, "agent definition as name=content or name=@file")
 	cmd.Flags().StringVar(&agentConfigRef, "agent-config", "", "name of AgentConfig resource to use")
+	cmd.Flags().StringSliceVar(&dependsOn, "depends-on", nil, "names of Tasks that must succeed before this task starts")
 
 	cmd.MarkFlagRequired("prompt")
 
 	_ = cmd.RegisterFlagCompletionFunc("credential-type", cobra.FixedCompletions([]string{"api-key", "oauth"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]string{"claude-code", "codex", "gemini"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("depends-on", completeTaskNames(cfg))
 
 	return cmd
 }
@@ -350,7 +354,8 @@ func watchTask(ctx context.Context, cl client.Client, name, namespace string) er
 			lastPhase = task.Status.Phase
 		}
 
-		if task.Status.Phase == axonv1alpha1.TaskPhaseSucceeded || task.Status.Phase == axonv1alpha1.TaskPhaseFailed {
+		if task.Status.Phase == axonv1alpha1.TaskPhaseSucceeded || task.Status.Phase == axonv1alpha1.TaskPhaseFailed ||
+			task.Status.Phase == axonv1alpha1.TaskPhaseCancelled {
 			return nil
 		}
 
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func newSuspendCommand(cfg *ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suspend",
+		Short: "Suspend resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return fmt.Errorf("must specify a resource type")
+		},
+	}
+
+	cmd.AddCommand(newSetTaskSpawnerSuspendCommand(cfg, true))
+
+	return cmd
+}
+
+func newResumeCommand(cfg *ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume suspended resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return fmt.Errorf("must specify a resource type")
+		},
+	}
+
+	cmd.AddCommand(newSetTaskSpawnerSuspendCommand(cfg, false))
+
+	return cmd
+}
+
+// newSetTaskSpawnerSuspendCommand returns the "taskspawner" subcommand of
+// suspend (when suspend is true) or resume (when suspend is false).
+func newSetTaskSpawnerSuspendCommand(cfg *ClientConfig, suspend bool) *cobra.Command {
+	short := "Stop a task spawner from creating new tasks"
+	action := "suspended"
+	if !suspend {
+		short = "Let a suspended task spawner create new tasks again"
+		action = "resumed"
+	}
+
+	cmd := &cobra.Command{
+		Use:     "taskspawner <name>",
+		Aliases: []string{"taskspawners", "ts"},
+		Short:   short,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("task spawner name is required\nUsage: %s", cmd.Use)
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: expected 1 task spawner name, got %d\nUsage: %s", len(args), cmd.Use)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, ns, err := cfg.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			ts := &axonv1alpha1.TaskSpawner{}
+			if err := cl.Get(ctx, client.ObjectKey{Name: args[0], Namespace: ns}, ts); err != nil {
+				return fmt.Errorf("getting task spawner: %w", err)
+			}
+
+			if ts.Spec.Suspend == suspend {
+				fmt.Fprintf(os.Stdout, "taskspawner/%s already %s\n", args[0], action)
+				return nil
+			}
+
+			patch := client.MergeFrom(ts.DeepCopy())
+			ts.Spec.Suspend = suspend
+			if err := cl.Patch(ctx, ts, patch); err != nil {
+				return fmt.Errorf("updating task spawner: %w", err)
+			}
+			fmt.Fprintf(os.Stdout, "taskspawner/%s %s\n", args[0], action)
+			return nil
+		},
+	}
+
+	cmd.ValidArgsFunction = completeTaskSpawnerNames(cfg)
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+// AgentConfigReconciler reconciles an AgentConfig object. It validates the
+// plugins of the AgentConfig and reports the result as status conditions.
+type AgentConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=axon.io,resources=agentconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=axon.io,resources=agentconfigs/status,verbs=get;update;patch
+
+// Reconcile handles AgentConfig reconciliation.
+func (r *AgentConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ac axonv1alpha1.AgentConfig
+	if err := r.Get(ctx, req.NamespacedName, &ac); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch AgentConfig")
+		return ctrl.Result{}, err
+	}
+
+	ready := metav1.Condition{Type: axonv1alpha1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Valid"}
+	if _, err := buildPluginSetupScript(ac.Spec.Plugins); err != nil {
+		ready = metav1.Condition{Type: axonv1alpha1.ConditionReady, Status: metav1.ConditionFalse, Reason: "InvalidPlugins", Message: err.Error()}
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, req.NamespacedName, &ac); getErr != nil {
+			return getErr
+		}
+		before := ac.Status.DeepCopy()
+		ac.Status.ObservedGeneration = ac.Generation
+		setCondition(&ac.Status.Conditions, ac.Generation, ready.Type, ready.Status, ready.Reason, ready.Message)
+		if equality.Semantic.DeepEqual(before, &ac.Status) {
+			return nil
+		}
+		return r.Status().Update(ctx, &ac)
+	}); err != nil {
+		logger.Error(err, "Unable to update AgentConfig status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&axonv1alpha1.AgentConfig{}).
+		Complete(r)
+}
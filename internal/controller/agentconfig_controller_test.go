@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestAgentConfigReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		plugins    []axonv1alpha1.PluginSpec
+		wantReady  metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "valid plugins",
+			plugins:    []axonv1alpha1.PluginSpec{{Name: "team", Skills: []axonv1alpha1.SkillDefinition{{Name: "review", Content: "x"}}}},
+			wantReady:  metav1.ConditionTrue,
+			wantReason: "Valid",
+		},
+		{
+			name:       "invalid plugin name",
+			plugins:    []axonv1alpha1.PluginSpec{{Name: "../escape"}},
+			wantReady:  metav1.ConditionFalse,
+			wantReason: "InvalidPlugins",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ac := &axonv1alpha1.AgentConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "ac", Namespace: "default"},
+				Spec:       axonv1alpha1.AgentConfigSpec{Plugins: tt.plugins},
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(newDependencyTestScheme()).
+				WithObjects(ac).
+				WithStatusSubresource(ac).
+				Build()
+			r := &AgentConfigReconciler{Client: cl}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ac)}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var got axonv1alpha1.AgentConfig
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(ac), &got); err != nil {
+				t.Fatalf("Getting AgentConfig: %v", err)
+			}
+			ready := meta.FindStatusCondition(got.Status.Conditions, axonv1alpha1.ConditionReady)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready = %+v, want status %s reason %s", ready, tt.wantReady, tt.wantReason)
+			}
+		})
+	}
+}
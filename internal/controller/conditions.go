@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+// setCondition sets a condition observed at the given generation. The
+// transition time is only updated when the status changes.
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// conditionsChanged reports whether setting desired on current would change
+// the status, reason or message of any condition.
+func conditionsChanged(current, desired []metav1.Condition) bool {
+	for _, d := range desired {
+		c := meta.FindStatusCondition(current, d.Type)
+		if c == nil || c.Status != d.Status || c.Reason != d.Reason || c.Message != d.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// setTaskCondition sets a condition on task observed at its generation.
+func setTaskCondition(task *axonv1alpha1.Task, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	task.Status.ObservedGeneration = task.Generation
+	setCondition(&task.Status.Conditions, task.Generation, conditionType, status, reason, message)
+}
+
+// setTaskPhaseConditions derives the Ready and Completed conditions from
+// the phase of task. It must be called whenever the phase changes.
+func setTaskPhaseConditions(task *axonv1alpha1.Task) {
+	phase := task.Status.Phase
+	if phase == "" {
+		phase = axonv1alpha1.TaskPhasePending
+	}
+	reason := string(phase)
+	message := task.Status.Message
+
+	switch phase {
+	case axonv1alpha1.TaskPhaseSucceeded:
+		setTaskCondition(task, axonv1alpha1.ConditionReady, metav1.ConditionTrue, reason, message)
+		setTaskCondition(task, axonv1alpha1.ConditionCompleted, metav1.ConditionTrue, reason, message)
+	case axonv1alpha1.TaskPhaseFailed, axonv1alpha1.TaskPhaseCancelled:
+		setTaskCondition(task, axonv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+		setTaskCondition(task, axonv1alpha1.ConditionCompleted, metav1.ConditionTrue, reason, message)
+	default:
+		setTaskCondition(task, axonv1alpha1.ConditionReady, metav1.ConditionUnknown, reason, message)
+		setTaskCondition(task, axonv1alpha1.ConditionCompleted, metav1.ConditionFalse, reason, message)
+	}
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestSetTaskPhaseConditions(t *testing.T) {
+	tests := []struct {
+		phase         axonv1alpha1.TaskPhase
+		wantReady     metav1.ConditionStatus
+		wantCompleted metav1.ConditionStatus
+		wantReason    string
+	}{
+		{"", metav1.ConditionUnknown, metav1.ConditionFalse, "Pending"},
+		{axonv1alpha1.TaskPhaseRunning, metav1.ConditionUnknown, metav1.ConditionFalse, "Running"},
+		{axonv1alpha1.TaskPhaseRetrying, metav1.ConditionUnknown, metav1.ConditionFalse, "Retrying"},
+		{axonv1alpha1.TaskPhaseSucceeded, metav1.ConditionTrue, metav1.ConditionTrue, "Succeeded"},
+		{axonv1alpha1.TaskPhaseFailed, metav1.ConditionFalse, metav1.ConditionTrue, "Failed"},
+		{axonv1alpha1.TaskPhaseCancelled, metav1.ConditionFalse, metav1.ConditionTrue, "Cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			task := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Status:     axonv1alpha1.TaskStatus{Phase: tt.phase, Message: "msg"},
+			}
+			setTaskPhaseConditions(task)
+
+			ready := meta.FindStatusCondition(task.Status.Conditions, axonv1alpha1.ConditionReady)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready = %+v, want status %s reason %s", ready, tt.wantReady, tt.wantReason)
+			}
+			completed := meta.FindStatusCondition(task.Status.Conditions, axonv1alpha1.ConditionCompleted)
+			if completed == nil || completed.Status != tt.wantCompleted {
+				t.Errorf("Completed = %+v, want status %s", completed, tt.wantCompleted)
+			}
+			if ready != nil && (ready.ObservedGeneration != 3 || ready.Message != "msg") {
+				t.Errorf("Ready = %+v, want observedGeneration 3 and message %q", ready, "msg")
+			}
+			if task.Status.ObservedGeneration != 3 {
+				t.Errorf("ObservedGeneration = %d, want 3", task.Status.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestConditionsChanged(t *testing.T) {
+	current := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Valid"},
+	}
+	if conditionsChanged(current, []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Valid"}}) {
+		t.Error("Expected identical conditions to be unchanged")
+	}
+	if !conditionsChanged(current, []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Invalid"}}) {
+		t.Error("Expected a status change to be detected")
+	}
+	if !conditionsChanged(current, []metav1.Condition{{Type: "SourceHealthy", Status: metav1.ConditionTrue, Reason: "Ok"}}) {
+		t.Error("Expected a new condition to be detected")
+	}
+}
@@ -0,0 +1,37 @@
+package controller
+
+// Reasons for the Events recorded on Tasks and TaskSpawners.
+const (
+	// reasonJobCreated is recorded when a Job is created for a Task attempt.
+	reasonJobCreated = "JobCreated"
+	// reasonJobBuildFailed is recorded when the Job for a Task cannot be built.
+	reasonJobBuildFailed = "JobBuildFailed"
+	// reasonPromptRenderFailed is recorded when the prompt of a Task cannot
+	// be rendered with the outputs of its dependencies.
+	reasonPromptRenderFailed = "PromptRenderFailed"
+	// reasonWorkspaceNotFound is recorded when the referenced Workspace does
+	// not exist.
+	reasonWorkspaceNotFound = "WorkspaceNotFound"
+	// reasonAgentConfigNotFound is recorded when the referenced AgentConfig
+	// does not exist.
+	reasonAgentConfigNotFound = "AgentConfigNotFound"
+	// reasonTokenResolutionFailed is recorded when a GitHub App
+	// installation token cannot be generated.
+	reasonTokenResolutionFailed = "TokenResolutionFailed"
+	// reasonTTLExpired is recorded when a finished Task is deleted because
+	// its TTL has expired.
+	reasonTTLExpired = "TTLExpired"
+	// reasonDeploymentCreated is recorded when the spawner Deployment of a
+	// TaskSpawner is created.
+	reasonDeploymentCreated = "DeploymentCreated"
+	// reasonDeploymentUpdated is recorded when the spawner Deployment of a
+	// TaskSpawner is updated.
+	reasonDeploymentUpdated = "DeploymentUpdated"
+	// reasonWebhookServiceUpdated is recorded when the webhook Service of a
+	// TaskSpawner is brought back in line with its desired ports and
+	// selector.
+	reasonWebhookServiceUpdated = "WebhookServiceUpdated"
+	// reasonWebhookServiceConflict is recorded when a Service that the
+	// TaskSpawner does not own has the name of its webhook Service.
+	reasonWebhookServiceConflict = "WebhookServiceConflict"
+)
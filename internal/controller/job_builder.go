@@ -47,6 +47,11 @@ const (
 	// PluginMountPath is the mount path for the plugin volume.
 	PluginMountPath = "/axon/plugin"
 
+	// ResultsFilePath is the path of the file, exposed to the agent as
+	// AXON_RESULTS_FILE, to which the agent can write results that
+	// capture-outputs.sh includes in the outputs block.
+	ResultsFilePath = "/tmp/axon-results"
+
 	// AgentUID is the UID shared between the git-clone init
 	// container and the agent container. Custom agent images must run
 	// as this UID so that both containers can read and write the
@@ -127,7 +132,9 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 		image = task.Spec.Image
 	}
 
-	var envVars []corev1.EnvVar
+	envVars := []corev1.EnvVar{
+		{Name: "AXON_RESULTS_FILE", Value: ResultsFilePath},
+	}
 
 	// Set AXON_MODEL for all agent containers.
 	if task.Spec.Model != "" {
@@ -168,7 +175,16 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 
 	var workspaceEnvVars []corev1.EnvVar
 	var isEnterprise bool
-	if workspace != nil {
+	isGitLab := workspace != nil && workspaceProvider(workspace) == axonv1alpha1.WorkspaceProviderGitLab
+	if isGitLab {
+		host, _ := parseGitLabRepo(workspace.Repo)
+		if host != "" && host != "gitlab.com" {
+			// Set GITLAB_HOST for self-hosted GitLab so that glab CLI targets the correct host.
+			glHostEnv := corev1.EnvVar{Name: "GITLAB_HOST", Value: host}
+			envVars = append(envVars, glHostEnv)
+			workspaceEnvVars = append(workspaceEnvVars, glHostEnv)
+		}
+	} else if workspace != nil {
 		host, _, _ := parseGitHubRepo(workspace.Repo)
 		isEnterprise = host != "" && host != "github.com"
 
@@ -180,7 +196,21 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 		}
 	}
 
-	if workspace != nil && workspace.SecretRef != nil {
+	if isGitLab && workspace.SecretRef != nil {
+		gitlabTokenEnv := corev1.EnvVar{
+			Name: "GITLAB_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: workspace.SecretRef.Name,
+					},
+					Key: "GITLAB_TOKEN",
+				},
+			},
+		}
+		envVars = append(envVars, gitlabTokenEnv)
+		workspaceEnvVars = append(workspaceEnvVars, gitlabTokenEnv)
+	} else if workspace != nil && workspace.SecretRef != nil {
 		secretKeyRef := &corev1.SecretKeySelector{
 			LocalObjectReference: corev1.LocalObjectReference{
 				Name: workspace.SecretRef.Name,
@@ -218,6 +248,10 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 		Command:         []string{"/axon_entrypoint.sh"},
 		Args:            []string{task.Spec.Prompt},
 		Env:             envVars,
+		// capture-outputs.sh writes the outputs block to the termination
+		// log so that the controller does not depend on Pod logs.
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 
 	var initContainers []corev1.Container
@@ -261,6 +295,11 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 
 		if workspace.SecretRef != nil {
 			credentialHelper := `!f() { echo "username=x-access-token"; echo "password=$GITHUB_TOKEN"; }; f`
+			if isGitLab {
+				// GitLab accepts personal, project and group access tokens
+				// with the oauth2 username.
+				credentialHelper = `!f() { echo "username=oauth2"; echo "password=$GITLAB_TOKEN"; }; f`
+			}
 			initContainer.Command = []string{"sh", "-c",
 				fmt.Sprintf(
 					`git -c credential.helper='%s' "$@" && git -C %s/repo config credential.helper '%s'`,
@@ -336,6 +375,7 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 	// are reflected in the final spec.
 	var activeDeadlineSeconds *int64
 	var nodeSelector map[string]string
+	var priorityClassName string
 
 	if po := task.Spec.PodOverrides; po != nil {
 		if po.Resources != nil {
@@ -363,6 +403,8 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 		if po.NodeSelector != nil {
 			nodeSelector = po.NodeSelector
 		}
+
+		priorityClassName = po.PriorityClassName
 	}
 
 	job := &batchv1.Job{
@@ -389,12 +431,13 @@ func (b *JobBuilder) buildAgentJob(task *axonv1alpha1.Task, workspace *axonv1alp
 					},
 				},
 				Spec: corev1.PodSpec{
-					RestartPolicy:   corev1.RestartPolicyNever,
-					SecurityContext: podSecurityContext,
-					InitContainers:  initContainers,
-					Volumes:         volumes,
-					Containers:      []corev1.Container{mainContainer},
-					NodeSelector:    nodeSelector,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					SecurityContext:   podSecurityContext,
+					InitContainers:    initContainers,
+					Volumes:           volumes,
+					Containers:        []corev1.Container{mainContainer},
+					NodeSelector:      nodeSelector,
+					PriorityClassName: priorityClassName,
 				},
 			},
 		},
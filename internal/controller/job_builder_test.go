@@ -64,6 +64,14 @@ func TestBuildClaudeCodeJob_DefaultImage(t *testing.T) {
 	if !foundAxonModel {
 		t.Error("Expected AXON_MODEL env var to be set")
 	}
+
+	// Outputs are written to the termination log by capture-outputs.sh.
+	if container.TerminationMessagePath != corev1.TerminationMessagePathDefault {
+		t.Errorf("Expected termination message path %q, got %q", corev1.TerminationMessagePathDefault, container.TerminationMessagePath)
+	}
+	if container.TerminationMessagePolicy != corev1.TerminationMessageFallbackToLogsOnError {
+		t.Errorf("Expected termination message policy %q, got %q", corev1.TerminationMessageFallbackToLogsOnError, container.TerminationMessagePolicy)
+	}
 }
 
 func TestBuildClaudeCodeJob_CustomImage(t *testing.T) {
@@ -585,6 +593,61 @@ func TestBuildClaudeCodeJob_GithubComWorkspaceUsesGHToken(t *testing.T) {
 	}
 }
 
+func TestBuildClaudeCodeJob_GitLabWorkspaceUsesGitLabToken(t *testing.T) {
+	builder := NewJobBuilder()
+	task := &axonv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-gitlab",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpec{
+			Type:   AgentTypeClaudeCode,
+			Prompt: "Fix the bug",
+			Credentials: axonv1alpha1.Credentials{
+				Type:      axonv1alpha1.CredentialTypeAPIKey,
+				SecretRef: axonv1alpha1.SecretReference{Name: "my-secret"},
+			},
+		},
+	}
+
+	workspace := &axonv1alpha1.WorkspaceSpec{
+		Repo:     "https://git.example.com/group/sub/repo.git",
+		Provider: axonv1alpha1.WorkspaceProviderGitLab,
+		SecretRef: &axonv1alpha1.SecretReference{
+			Name: "gitlab-token",
+		},
+	}
+
+	job, err := builder.Build(task, workspace, nil)
+	if err != nil {
+		t.Fatalf("Build() returned error: %v", err)
+	}
+
+	initContainer := job.Spec.Template.Spec.InitContainers[0]
+	initEnvMap := map[string]string{}
+	for _, env := range initContainer.Env {
+		if env.Value != "" {
+			initEnvMap[env.Name] = env.Value
+		} else {
+			initEnvMap[env.Name] = env.ValueFrom.SecretKeyRef.Key
+		}
+	}
+	if initEnvMap["GITLAB_TOKEN"] != "GITLAB_TOKEN" {
+		t.Errorf("Expected GITLAB_TOKEN from secret key GITLAB_TOKEN, got %q", initEnvMap["GITLAB_TOKEN"])
+	}
+	if initEnvMap["GITLAB_HOST"] != "git.example.com" {
+		t.Errorf("Expected GITLAB_HOST = %q, got %q", "git.example.com", initEnvMap["GITLAB_HOST"])
+	}
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN", "GH_HOST"} {
+		if _, ok := initEnvMap[name]; ok {
+			t.Errorf("%s should not be set for GitLab workspace", name)
+		}
+	}
+	if !strings.Contains(initContainer.Command[2], `username=oauth2`) || !strings.Contains(initContainer.Command[2], `$GITLAB_TOKEN`) {
+		t.Errorf("Expected GitLab credential helper, got %q", initContainer.Command[2])
+	}
+}
+
 func TestBuildCodexJob_DefaultImage(t *testing.T) {
 	builder := NewJobBuilder()
 	task := &axonv1alpha1.Task{
@@ -1326,6 +1389,36 @@ func TestBuildJob_PodOverridesNodeSelector(t *testing.T) {
 	}
 }
 
+func TestBuildJob_PodOverridesPriorityClassName(t *testing.T) {
+	builder := NewJobBuilder()
+	task := &axonv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-priority-class",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpec{
+			Type:   AgentTypeClaudeCode,
+			Prompt: "Fix issue",
+			Credentials: axonv1alpha1.Credentials{
+				Type:      axonv1alpha1.CredentialTypeAPIKey,
+				SecretRef: axonv1alpha1.SecretReference{Name: "my-secret"},
+			},
+			PodOverrides: &axonv1alpha1.PodOverrides{
+				PriorityClassName: "axon-urgent",
+			},
+		},
+	}
+
+	job, err := builder.Build(task, nil, nil)
+	if err != nil {
+		t.Fatalf("Build() returned error: %v", err)
+	}
+
+	if got := job.Spec.Template.Spec.PriorityClassName; got != "axon-urgent" {
+		t.Errorf("Expected priorityClassName axon-urgent, got %q", got)
+	}
+}
+
 func TestBuildJob_PodOverridesAllFields(t *testing.T) {
 	builder := NewJobBuilder()
 	task := &axonv1alpha1.Task{
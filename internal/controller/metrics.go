@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+var (
+	// taskDurationSeconds observes how long finished Tasks ran, from the
+	// start of their first attempt until they succeeded or failed.
+	taskDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "axon_task_duration_seconds",
+			Help:    "Duration of finished Tasks in seconds, by agent type and phase.",
+			Buckets: prometheus.ExponentialBuckets(30, 2, 10),
+		},
+		[]string{"type", "phase"},
+	)
+
+	// outputCaptureFailuresTotal counts finished attempts whose outputs
+	// could not be read within outputRetryWindow.
+	outputCaptureFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_task_output_capture_failures_total",
+			Help: "Number of finished Tasks whose outputs could not be captured, by agent type.",
+		},
+		[]string{"type"},
+	)
+
+	// taskCostUSDTotal accumulates the cost reported by agents.
+	taskCostUSDTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_task_cost_usd_total",
+			Help: "Total cost in US dollars reported by agents, by namespace and agent type.",
+		},
+		[]string{"namespace", "type"},
+	)
+
+	// taskTokensTotal accumulates the tokens reported by agents.
+	taskTokensTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "axon_task_tokens_total",
+			Help: "Total tokens reported by agents, by namespace, agent type and direction (input or output).",
+		},
+		[]string{"namespace", "type", "direction"},
+	)
+
+	tasksDesc = prometheus.NewDesc(
+		"axon_tasks",
+		"Number of Tasks by namespace, phase and agent type.",
+		[]string{"namespace", "phase", "type"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		taskDurationSeconds,
+		outputCaptureFailuresTotal,
+		taskCostUSDTotal,
+		taskTokensTotal,
+	)
+}
+
+// RegisterTaskCollector registers a collector that reports the number of
+// Tasks by phase and agent type. Tasks are listed from reader, which should
+// be the manager's cached client, on every scrape.
+func RegisterTaskCollector(reader client.Reader) error {
+	return metrics.Registry.Register(&taskCollector{reader: reader})
+}
+
+// taskCollector is a prometheus.Collector that counts Tasks by phase and
+// agent type.
+type taskCollector struct {
+	reader client.Reader
+}
+
+// Describe implements prometheus.Collector.
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tasksDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var tasks axonv1alpha1.TaskList
+	if err := c.reader.List(ctx, &tasks); err != nil {
+		ch <- prometheus.NewInvalidMetric(tasksDesc, err)
+		return
+	}
+
+	type key struct {
+		namespace string
+		phase     axonv1alpha1.TaskPhase
+		agentType string
+	}
+	counts := make(map[key]int)
+	for _, t := range tasks.Items {
+		phase := t.Status.Phase
+		if phase == "" {
+			phase = axonv1alpha1.TaskPhasePending
+		}
+		counts[key{t.Namespace, phase, t.Spec.Type}]++
+	}
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.GaugeValue, float64(n), k.namespace, string(k.phase), k.agentType)
+	}
+}
+
+// observeUsage records the usage reported by an attempt of task.
+func observeUsage(task *axonv1alpha1.Task, usage *Usage) {
+	if usage == nil {
+		return
+	}
+	if usage.HasCost {
+		taskCostUSDTotal.WithLabelValues(task.Namespace, task.Spec.Type).Add(usage.CostUSD)
+	}
+	taskTokensTotal.WithLabelValues(task.Namespace, task.Spec.Type, "input").Add(float64(usage.InputTokens))
+	taskTokensTotal.WithLabelValues(task.Namespace, task.Spec.Type, "output").Add(float64(usage.OutputTokens))
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestTaskCollector(t *testing.T) {
+	newTask := func(name, agentType string, phase axonv1alpha1.TaskPhase) *axonv1alpha1.Task {
+		return &axonv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       axonv1alpha1.TaskSpec{Type: agentType},
+			Status:     axonv1alpha1.TaskStatus{Phase: phase},
+		}
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(newDependencyTestScheme()).
+		WithObjects(
+			newTask("a", AgentTypeClaudeCode, axonv1alpha1.TaskPhaseRunning),
+			newTask("b", AgentTypeClaudeCode, axonv1alpha1.TaskPhaseRunning),
+			newTask("c", AgentTypeCodex, axonv1alpha1.TaskPhaseFailed),
+			newTask("d", AgentTypeCodex, ""),
+		).
+		Build()
+
+	expected := `
+# HELP axon_tasks Number of Tasks by namespace, phase and agent type.
+# TYPE axon_tasks gauge
+axon_tasks{namespace="default",phase="Failed",type="codex"} 1
+axon_tasks{namespace="default",phase="Pending",type="codex"} 1
+axon_tasks{namespace="default",phase="Running",type="claude-code"} 2
+`
+	if err := testutil.CollectAndCompare(&taskCollector{reader: cl}, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestObserveUsage(t *testing.T) {
+	task := &axonv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "usage", Namespace: "metrics-test"},
+		Spec:       axonv1alpha1.TaskSpec{Type: AgentTypeClaudeCode},
+	}
+
+	observeUsage(task, &Usage{CostUSD: 0.5, HasCost: true, InputTokens: 100, OutputTokens: 20})
+	observeUsage(task, &Usage{CostUSD: 0.25, HasCost: true, InputTokens: 50})
+	observeUsage(task, nil)
+
+	if got := testutil.ToFloat64(taskCostUSDTotal.WithLabelValues("metrics-test", AgentTypeClaudeCode)); got != 0.75 {
+		t.Errorf("cost = %v, want 0.75", got)
+	}
+	if got := testutil.ToFloat64(taskTokensTotal.WithLabelValues("metrics-test", AgentTypeClaudeCode, "input")); got != 150 {
+		t.Errorf("input tokens = %v, want 150", got)
+	}
+	if got := testutil.ToFloat64(taskTokensTotal.WithLabelValues("metrics-test", AgentTypeClaudeCode, "output")); got != 20 {
+		t.Errorf("output tokens = %v, want 20", got)
+	}
+}
@@ -1,6 +1,14 @@
 package controller
 
-import "strings"
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
 
 const (
 	outputStartMarker = "---AXON_OUTPUTS_START---"
@@ -38,3 +46,141 @@ func ParseOutputs(logData string) []string {
 	}
 	return result
 }
+
+// terminationMessage returns the termination message of the named
+// container in pod, or an empty string if the container has not
+// terminated. capture-outputs.sh writes the outputs block there, which
+// survives Pod log rotation and does not require access to the logs API.
+func terminationMessage(pod *corev1.Pod, container string) string {
+	if pod == nil {
+		return ""
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+		if cs.State.Terminated != nil {
+			return cs.State.Terminated.Message
+		}
+		if cs.LastTerminationState.Terminated != nil {
+			return cs.LastTerminationState.Terminated.Message
+		}
+	}
+	return ""
+}
+
+const (
+	// ResultKeyBranch is the result key for the git branch.
+	ResultKeyBranch = "branch"
+
+	// ResultKeyCommit is the result key for the commit SHA.
+	ResultKeyCommit = "commit"
+
+	// ResultKeyPullRequest is the result key for a pull request URL.
+	ResultKeyPullRequest = "pr"
+)
+
+// Results holds the typed results parsed from the output lines.
+type Results struct {
+	Branch          string
+	CommitSHA       string
+	PullRequestURLs []string
+	// Values holds every key/value result, including the well-known keys.
+	Values map[string]string
+}
+
+// ParseResults parses output lines into typed results. Each line is one of:
+//
+//   - a JSON object, whose fields are added as results (non-string values
+//     are stored as their JSON encoding),
+//   - a "key: value" pair,
+//   - a bare http(s) URL, which is treated as a pull request URL.
+//
+// The "branch", "commit" and "pr" keys populate the dedicated fields.
+// Lines in any other format are ignored.
+func ParseResults(outputs []string) *Results {
+	results := &Results{}
+	for _, line := range outputs {
+		switch {
+		case strings.HasPrefix(line, "{"):
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(line), &obj); err != nil {
+				continue
+			}
+			keys := make([]string, 0, len(obj))
+			for k := range obj {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				var s string
+				if err := json.Unmarshal(obj[k], &s); err != nil {
+					s = string(obj[k])
+				}
+				results.add(k, s)
+			}
+		case strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://"):
+			results.add(ResultKeyPullRequest, line)
+		default:
+			key, value, ok := strings.Cut(line, ": ")
+			if !ok || !isResultKey(key) {
+				continue
+			}
+			results.add(key, strings.TrimSpace(value))
+		}
+	}
+	if results.Values == nil {
+		return nil
+	}
+	return results
+}
+
+// add records a single key/value result.
+func (r *Results) add(key, value string) {
+	if r.Values == nil {
+		r.Values = make(map[string]string)
+	}
+	r.Values[key] = value
+
+	switch key {
+	case ResultKeyBranch:
+		r.Branch = value
+	case ResultKeyCommit:
+		r.CommitSHA = value
+	case ResultKeyPullRequest:
+		for _, u := range r.PullRequestURLs {
+			if u == value {
+				return
+			}
+		}
+		r.PullRequestURLs = append(r.PullRequestURLs, value)
+	}
+}
+
+// isResultKey reports whether key is a valid result key: a non-empty
+// string of letters, digits, '-', '_' and '.'.
+func isResultKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// setOutputs records the output lines and the results parsed from them
+// in status.
+func setOutputs(status *axonv1alpha1.TaskStatus, outputs []string) {
+	status.Outputs = outputs
+	results := ParseResults(outputs)
+	if results == nil {
+		results = &Results{}
+	}
+	status.Results = results.Values
+	status.Branch = results.Branch
+	status.CommitSHA = results.CommitSHA
+	status.PullRequestURLs = results.PullRequestURLs
+}
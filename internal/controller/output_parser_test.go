@@ -1,7 +1,12 @@
 package controller
 
 import (
+	"reflect"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
 )
 
 func TestParseOutputs(t *testing.T) {
@@ -94,3 +99,171 @@ func TestParseOutputs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		outputs  []string
+		expected *Results
+	}{
+		{
+			name:     "no outputs",
+			outputs:  nil,
+			expected: nil,
+		},
+		{
+			name: "legacy format",
+			outputs: []string{
+				"branch: axon-task-123",
+				"https://github.com/org/repo/pull/1",
+				"https://github.com/org/repo/pull/2",
+			},
+			expected: &Results{
+				Branch: "axon-task-123",
+				PullRequestURLs: []string{
+					"https://github.com/org/repo/pull/1",
+					"https://github.com/org/repo/pull/2",
+				},
+				Values: map[string]string{
+					"branch": "axon-task-123",
+					"pr":     "https://github.com/org/repo/pull/2",
+				},
+			},
+		},
+		{
+			name: "key/value results",
+			outputs: []string{
+				"branch: feature",
+				"commit: 0123456789abcdef",
+				"pr: https://github.com/org/repo/pull/7",
+				"tests: passed",
+			},
+			expected: &Results{
+				Branch:          "feature",
+				CommitSHA:       "0123456789abcdef",
+				PullRequestURLs: []string{"https://github.com/org/repo/pull/7"},
+				Values: map[string]string{
+					"branch": "feature",
+					"commit": "0123456789abcdef",
+					"pr":     "https://github.com/org/repo/pull/7",
+					"tests":  "passed",
+				},
+			},
+		},
+		{
+			name: "JSON results",
+			outputs: []string{
+				`{"commit": "abc123", "coverage": 87.5, "files": ["a.go", "b.go"], "summary": "Fixed the bug"}`,
+			},
+			expected: &Results{
+				CommitSHA: "abc123",
+				Values: map[string]string{
+					"commit":   "abc123",
+					"coverage": "87.5",
+					"files":    `["a.go", "b.go"]`,
+					"summary":  "Fixed the bug",
+				},
+			},
+		},
+		{
+			name: "duplicate PR URLs are recorded once",
+			outputs: []string{
+				"https://github.com/org/repo/pull/1",
+				"pr: https://github.com/org/repo/pull/1",
+			},
+			expected: &Results{
+				PullRequestURLs: []string{"https://github.com/org/repo/pull/1"},
+				Values: map[string]string{
+					"pr": "https://github.com/org/repo/pull/1",
+				},
+			},
+		},
+		{
+			name: "unrecognized lines are ignored",
+			outputs: []string{
+				"just some text",
+				"not a key: value",
+				"{malformed json",
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseResults(tt.outputs)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseResults() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetOutputs(t *testing.T) {
+	status := &axonv1alpha1.TaskStatus{
+		Branch: "stale",
+	}
+
+	setOutputs(status, []string{"branch: main", "https://github.com/org/repo/pull/1"})
+	if status.Branch != "main" {
+		t.Errorf("Branch = %q, want %q", status.Branch, "main")
+	}
+	if len(status.PullRequestURLs) != 1 {
+		t.Errorf("PullRequestURLs = %v, want 1 URL", status.PullRequestURLs)
+	}
+	if len(status.Outputs) != 2 {
+		t.Errorf("Outputs = %v, want 2 lines", status.Outputs)
+	}
+
+	setOutputs(status, nil)
+	if status.Branch != "" || status.Results != nil || status.PullRequestURLs != nil {
+		t.Errorf("Expected results to be cleared, got %+v", status)
+	}
+}
+
+func TestTerminationMessage(t *testing.T) {
+	terminated := func(msg string) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: msg}}
+	}
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected string
+	}{
+		{
+			name:     "nil pod",
+			pod:      nil,
+			expected: "",
+		},
+		{
+			name: "terminated container",
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "other", State: terminated("other output")},
+				{Name: "claude-code", State: terminated("---AXON_OUTPUTS_START---\nbranch: main\n---AXON_OUTPUTS_END---")},
+			}}},
+			expected: "---AXON_OUTPUTS_START---\nbranch: main\n---AXON_OUTPUTS_END---",
+		},
+		{
+			name: "last termination state",
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "claude-code", LastTerminationState: terminated("previous")},
+			}}},
+			expected: "previous",
+		},
+		{
+			name: "running container",
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "claude-code", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			}}},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminationMessage(tt.pod, "claude-code"); got != tt.expected {
+				t.Errorf("terminationMessage() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
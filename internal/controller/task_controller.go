@@ -9,13 +9,16 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
@@ -26,7 +29,7 @@ const (
 	taskFinalizer = "axon.io/finalizer"
 
 	// outputRetryWindow is the maximum duration after CompletionTime
-	// during which the controller retries reading Pod logs for outputs.
+	// during which the controller retries reading outputs from the Pod.
 	outputRetryWindow = 30 * time.Second
 
 	// outputRetryInterval is the delay between output capture retries.
@@ -40,6 +43,7 @@ type TaskReconciler struct {
 	JobBuilder  *JobBuilder
 	Clientset   kubernetes.Interface
 	TokenClient *githubapp.TokenClient
+	Recorder    events.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=axon.io,resources=tasks,verbs=get;list;watch;create;update;patch;delete
@@ -51,6 +55,7 @@ type TaskReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile handles Task reconciliation.
 func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -80,10 +85,26 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Handle cancellation. Tasks that already finished are left untouched.
+	if task.Status.Phase == axonv1alpha1.TaskPhaseCancelled ||
+		(task.Spec.Cancel && task.Status.Phase != axonv1alpha1.TaskPhaseSucceeded && task.Status.Phase != axonv1alpha1.TaskPhaseFailed) {
+		return r.handleCancel(ctx, &task)
+	}
+
+	// After a failed attempt, wait for the backoff to elapse before
+	// looking for the Job of the next attempt.
+	jobName := currentJobName(&task)
+	if task.Status.Phase == axonv1alpha1.TaskPhaseRetrying {
+		if wait := retryDelay(&task, time.Now()); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		jobName = attemptJobName(task.Name, attemptCount(&task)+1)
+	}
+
 	// Check if Job already exists
 	var job batchv1.Job
 	jobExists := true
-	if err := r.Get(ctx, req.NamespacedName, &job); err != nil {
+	if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: jobName}, &job); err != nil {
 		if apierrors.IsNotFound(err) {
 			jobExists = false
 		} else {
@@ -94,7 +115,24 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	// Create Job if it doesn't exist
 	if !jobExists {
-		return r.createJob(ctx, &task)
+		// A Task that failed because of its dependencies never gets a Job
+		if len(task.Spec.DependsOn) > 0 && task.Status.Phase == axonv1alpha1.TaskPhaseFailed {
+			return r.handleTTL(ctx, &task, ctrl.Result{})
+		}
+
+		var depResults map[string]dependencyResult
+		if len(task.Spec.DependsOn) > 0 {
+			state, err := r.checkDependencies(ctx, &task)
+			if err != nil {
+				logger.Error(err, "Unable to check Task dependencies")
+				return ctrl.Result{}, err
+			}
+			if !state.Ready {
+				return r.updateDependencyStatus(ctx, &task, state)
+			}
+			depResults = state.Results
+		}
+		return r.createJob(ctx, &task, depResults)
 	}
 
 	// Update status based on Job status
@@ -103,10 +141,18 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return result, err
 	}
 
-	// Check TTL expiration for finished Tasks
-	if expired, requeueAfter := r.ttlExpired(&task); expired {
+	return r.handleTTL(ctx, &task, result)
+}
+
+// handleTTL deletes the Task if its TTL has expired, or adjusts the result
+// to requeue when the TTL will expire.
+func (r *TaskReconciler) handleTTL(ctx context.Context, task *axonv1alpha1.Task, result ctrl.Result) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if expired, requeueAfter := r.ttlExpired(task); expired {
 		logger.Info("Deleting Task due to TTL expiration", "task", task.Name)
-		if err := r.Delete(ctx, &task); err != nil {
+		r.Recorder.Eventf(task, nil, corev1.EventTypeNormal, reasonTTLExpired, "Delete", "Deleting Task after its TTL expired")
+		if err := r.Delete(ctx, task); err != nil {
 			if apierrors.IsNotFound(err) {
 				return ctrl.Result{}, nil
 			}
@@ -124,20 +170,119 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	return result, nil
 }
 
+// updateDependencyStatus records that the Task is waiting for its
+// dependencies, or marks it Failed when one of them has failed.
+func (r *TaskReconciler) updateDependencyStatus(ctx context.Context, task *axonv1alpha1.Task, state *dependencyState) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	newPhase := axonv1alpha1.TaskPhaseWaiting
+	if state.Failed != "" {
+		newPhase = axonv1alpha1.TaskPhaseFailed
+	}
+	newMessage := dependencyMessage(task, state)
+
+	if task.Status.Phase == newPhase && task.Status.Message == newMessage {
+		return ctrl.Result{}, nil
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
+			return getErr
+		}
+		task.Status.Phase = newPhase
+		task.Status.Message = newMessage
+		if newPhase == axonv1alpha1.TaskPhaseFailed {
+			now := metav1.Now()
+			task.Status.CompletionTime = &now
+		}
+		setTaskPhaseConditions(task)
+		return r.Status().Update(ctx, task)
+	}); err != nil {
+		logger.Error(err, "Unable to update Task status")
+		return ctrl.Result{}, err
+	}
+
+	r.recordPhaseEvent(task, newPhase, newMessage)
+
+	if newPhase == axonv1alpha1.TaskPhaseFailed {
+		logger.Info("Task dependency failed", "task", task.Name, "dependency", state.Failed)
+		return r.handleTTL(ctx, task, ctrl.Result{})
+	}
+	return ctrl.Result{}, nil
+}
+
+// handleCancel stops a cancelled Task by suspending the Job of its current
+// attempt, which deletes the Job's Pods, and moves the Task to the
+// Cancelled phase. The Job and the Task status are kept.
+func (r *TaskReconciler) handleCancel(ctx context.Context, task *axonv1alpha1.Task) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if task.Status.Phase == axonv1alpha1.TaskPhaseCancelled {
+		return r.handleTTL(ctx, task, ctrl.Result{})
+	}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: currentJobName(task)}, &job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to fetch Job")
+			return ctrl.Result{}, err
+		}
+	} else if job.Spec.Suspend == nil || !*job.Spec.Suspend {
+		suspend := true
+		job.Spec.Suspend = &suspend
+		if err := r.Update(ctx, &job); err != nil {
+			logger.Error(err, "Unable to suspend Job", "job", job.Name)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Suspended Job of cancelled Task", "job", job.Name)
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
+			return getErr
+		}
+		now := metav1.Now()
+		task.Status.Phase = axonv1alpha1.TaskPhaseCancelled
+		task.Status.Message = "Task cancelled"
+		task.Status.CompletionTime = &now
+		if a := currentAttempt(task); a != nil && a.CompletionTime == nil {
+			a.CompletionTime = &now
+			a.Phase = axonv1alpha1.TaskPhaseCancelled
+		}
+		setTaskPhaseConditions(task)
+		return r.Status().Update(ctx, task)
+	}); err != nil {
+		logger.Error(err, "Unable to update Task status")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Cancelled Task", "task", task.Name)
+	r.recordPhaseEvent(task, axonv1alpha1.TaskPhaseCancelled, task.Status.Message)
+	return r.handleTTL(ctx, task, ctrl.Result{})
+}
+
 // handleDeletion handles Task deletion.
 func (r *TaskReconciler) handleDeletion(ctx context.Context, task *axonv1alpha1.Task) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	if controllerutil.ContainsFinalizer(task, taskFinalizer) {
-		// Delete the Job if it exists
-		var job batchv1.Job
-		if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: task.Name}, &job); err == nil {
-			propagationPolicy := metav1.DeletePropagationBackground
-			if err := r.Delete(ctx, &job, &client.DeleteOptions{
-				PropagationPolicy: &propagationPolicy,
-			}); err != nil && !apierrors.IsNotFound(err) {
-				logger.Error(err, "unable to delete Job")
-				return ctrl.Result{}, err
+		// Delete the Jobs of every attempt if they exist
+		jobNames := []string{task.Name}
+		for _, a := range task.Status.Attempts {
+			if a.JobName != task.Name {
+				jobNames = append(jobNames, a.JobName)
+			}
+		}
+		for _, jobName := range jobNames {
+			var job batchv1.Job
+			if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: jobName}, &job); err == nil {
+				propagationPolicy := metav1.DeletePropagationBackground
+				if err := r.Delete(ctx, &job, &client.DeleteOptions{
+					PropagationPolicy: &propagationPolicy,
+				}); err != nil && !apierrors.IsNotFound(err) {
+					logger.Error(err, "unable to delete Job")
+					return ctrl.Result{}, err
+				}
 			}
 		}
 
@@ -152,8 +297,9 @@ func (r *TaskReconciler) handleDeletion(ctx context.Context, task *axonv1alpha1.
 	return ctrl.Result{}, nil
 }
 
-// createJob creates a Job for the Task.
-func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task) (ctrl.Result, error) {
+// createJob creates a Job for the Task. depResults holds the results of the
+// Task's dependencies, if any, and is used to render the prompt.
+func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task, depResults map[string]dependencyResult) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	var workspace *axonv1alpha1.WorkspaceSpec
@@ -165,24 +311,37 @@ func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task)
 		}, &ws); err != nil {
 			if apierrors.IsNotFound(err) {
 				logger.Info("Workspace not found yet, requeuing", "workspace", task.Spec.WorkspaceRef.Name)
+				r.Recorder.Eventf(task, nil, corev1.EventTypeWarning, reasonWorkspaceNotFound, "Resolve", "Workspace %q not found", task.Spec.WorkspaceRef.Name)
+				if err := r.updateCondition(ctx, task, axonv1alpha1.ConditionWorkspaceResolved, metav1.ConditionFalse, reasonWorkspaceNotFound,
+					fmt.Sprintf("Workspace %q not found", task.Spec.WorkspaceRef.Name)); err != nil {
+					logger.Error(err, "Unable to update Task status")
+					return ctrl.Result{}, err
+				}
 				return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 			}
 			logger.Error(err, "Unable to fetch Workspace", "workspace", task.Spec.WorkspaceRef.Name)
 			return ctrl.Result{}, err
 		}
 		workspace = &ws.Spec
+		if task.Spec.WorkspaceRef.Ref != "" {
+			workspace.Ref = task.Spec.WorkspaceRef.Ref
+		}
 
 		// Handle GitHub App authentication
 		if workspace.SecretRef != nil {
 			resolvedWorkspace, err := r.resolveGitHubAppToken(ctx, task, workspace)
 			if err != nil {
 				logger.Error(err, "Unable to resolve GitHub App token")
+				r.Recorder.Eventf(task, nil, corev1.EventTypeWarning, reasonTokenResolutionFailed, "Resolve", "Failed to resolve GitHub token: %v", err)
 				updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 					if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
 						return getErr
 					}
 					task.Status.Phase = axonv1alpha1.TaskPhaseFailed
 					task.Status.Message = fmt.Sprintf("Failed to resolve GitHub token: %v", err)
+					setTaskCondition(task, axonv1alpha1.ConditionWorkspaceResolved, metav1.ConditionTrue, "Resolved", "")
+					setTaskCondition(task, axonv1alpha1.ConditionCredentialsResolved, metav1.ConditionFalse, reasonTokenResolutionFailed, task.Status.Message)
+					setTaskPhaseConditions(task)
 					return r.Status().Update(ctx, task)
 				})
 				if updateErr != nil {
@@ -203,6 +362,7 @@ func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task)
 		}, &ac); err != nil {
 			if apierrors.IsNotFound(err) {
 				logger.Info("AgentConfig not found yet, requeuing", "agentConfig", task.Spec.AgentConfigRef.Name)
+				r.Recorder.Eventf(task, nil, corev1.EventTypeWarning, reasonAgentConfigNotFound, "Resolve", "AgentConfig %q not found", task.Spec.AgentConfigRef.Name)
 				return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 			}
 			logger.Error(err, "Unable to fetch AgentConfig", "agentConfig", task.Spec.AgentConfigRef.Name)
@@ -211,15 +371,46 @@ func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task)
 		agentConfig = &ac.Spec
 	}
 
-	job, err := r.JobBuilder.Build(task, workspace, agentConfig)
+	buildTask := task
+	if len(task.Spec.DependsOn) > 0 {
+		prompt, err := renderDependencyPrompt(task.Spec.Prompt, depResults)
+		if err != nil {
+			logger.Error(err, "Unable to render prompt with dependency outputs")
+			r.Recorder.Eventf(task, nil, corev1.EventTypeWarning, reasonPromptRenderFailed, "Render", "Failed to render prompt: %v", err)
+			updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
+					return getErr
+				}
+				now := metav1.Now()
+				task.Status.Phase = axonv1alpha1.TaskPhaseFailed
+				task.Status.Message = fmt.Sprintf("Failed to render prompt: %v", err)
+				task.Status.CompletionTime = &now
+				setTaskCondition(task, axonv1alpha1.ConditionJobCreated, metav1.ConditionFalse, reasonPromptRenderFailed, task.Status.Message)
+				setTaskPhaseConditions(task)
+				return r.Status().Update(ctx, task)
+			})
+			if updateErr != nil {
+				logger.Error(updateErr, "Unable to update Task status")
+				return ctrl.Result{}, nil
+			}
+			return r.handleTTL(ctx, task, ctrl.Result{})
+		}
+		buildTask = task.DeepCopy()
+		buildTask.Spec.Prompt = prompt
+	}
+
+	job, err := r.JobBuilder.Build(buildTask, workspace, agentConfig)
 	if err != nil {
 		logger.Error(err, "unable to build Job")
+		r.Recorder.Eventf(task, nil, corev1.EventTypeWarning, reasonJobBuildFailed, "Build", "Failed to build Job: %v", err)
 		updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 			if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
 				return getErr
 			}
 			task.Status.Phase = axonv1alpha1.TaskPhaseFailed
 			task.Status.Message = fmt.Sprintf("Failed to build Job: %v", err)
+			setTaskCondition(task, axonv1alpha1.ConditionJobCreated, metav1.ConditionFalse, reasonJobBuildFailed, task.Status.Message)
+			setTaskPhaseConditions(task)
 			return r.Status().Update(ctx, task)
 		})
 		if updateErr != nil {
@@ -228,6 +419,10 @@ func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task)
 		return ctrl.Result{}, err
 	}
 
+	// Each attempt runs in a fresh Job
+	attempt := attemptCount(task) + 1
+	job.Name = attemptJobName(task.Name, attempt)
+
 	// Set owner reference
 	if err := controllerutil.SetControllerReference(task, job, r.Scheme); err != nil {
 		logger.Error(err, "unable to set owner reference")
@@ -242,15 +437,32 @@ func (r *TaskReconciler) createJob(ctx context.Context, task *axonv1alpha1.Task)
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("created Job", "job", job.Name)
+	logger.Info("created Job", "job", job.Name, "attempt", attempt)
+	r.Recorder.Eventf(task, job, corev1.EventTypeNormal, reasonJobCreated, "Create", "Created Job %s for attempt %d", job.Name, attempt)
 
 	// Update status
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
 			return getErr
 		}
+		now := metav1.Now()
 		task.Status.Phase = axonv1alpha1.TaskPhasePending
 		task.Status.JobName = job.Name
+		// The Pod of a previous attempt no longer reflects the Task
+		task.Status.PodName = ""
+		task.Status.Attempts = append(task.Status.Attempts, axonv1alpha1.TaskAttempt{
+			Attempt:   attempt,
+			JobName:   job.Name,
+			StartTime: &now,
+		})
+		if workspace != nil {
+			setTaskCondition(task, axonv1alpha1.ConditionWorkspaceResolved, metav1.ConditionTrue, "Resolved", "")
+			if workspace.SecretRef != nil {
+				setTaskCondition(task, axonv1alpha1.ConditionCredentialsResolved, metav1.ConditionTrue, "Resolved", "")
+			}
+		}
+		setTaskCondition(task, axonv1alpha1.ConditionJobCreated, metav1.ConditionTrue, reasonJobCreated, fmt.Sprintf("Created Job %s for attempt %d", job.Name, attempt))
+		setTaskPhaseConditions(task)
 		return r.Status().Update(ctx, task)
 	}); err != nil {
 		logger.Error(err, "Unable to update Task status")
@@ -343,7 +555,8 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 	if task.Status.PodName == "" {
 		var pods corev1.PodList
 		if err := r.List(ctx, &pods, client.InNamespace(task.Namespace), client.MatchingLabels{
-			"axon.io/task": task.Name,
+			"axon.io/task":       task.Name,
+			batchv1.JobNameLabel: job.Name,
 		}); err == nil && len(pods.Items) > 0 {
 			podName = pods.Items[0].Name
 		}
@@ -352,12 +565,15 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 	// Determine the new phase based on Job status
 	var newPhase axonv1alpha1.TaskPhase
 	var newMessage string
+	var failureReason axonv1alpha1.FailureReason
 	var setStartTime, setCompletionTime bool
+	var requeueAfter time.Duration
 
 	if job.Status.Active > 0 {
 		if task.Status.Phase != axonv1alpha1.TaskPhaseRunning {
 			newPhase = axonv1alpha1.TaskPhaseRunning
-			setStartTime = true
+			// StartTime records when the first attempt started running
+			setStartTime = task.Status.StartTime == nil
 		}
 	} else if job.Status.Succeeded > 0 {
 		if task.Status.Phase != axonv1alpha1.TaskPhaseSucceeded {
@@ -366,10 +582,25 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 			setCompletionTime = true
 		}
 	} else if job.Status.Failed > 0 {
-		if task.Status.Phase != axonv1alpha1.TaskPhaseFailed {
-			newPhase = axonv1alpha1.TaskPhaseFailed
-			newMessage = "Task failed"
-			setCompletionTime = true
+		if task.Status.Phase != axonv1alpha1.TaskPhaseFailed && task.Status.Phase != axonv1alpha1.TaskPhaseRetrying {
+			effectivePodName := podName
+			if effectivePodName == "" {
+				effectivePodName = task.Status.PodName
+			}
+			failureReason = jobFailureReason(job, r.getPod(ctx, task.Namespace, effectivePodName), task.Spec.Type)
+			attempts := attemptCount(task)
+			if shouldRetry(task, failureReason) {
+				requeueAfter = retryBackoff(task.Spec.RetryPolicy, attempts)
+				newPhase = axonv1alpha1.TaskPhaseRetrying
+				newMessage = fmt.Sprintf("Attempt %d failed (%s), retrying in %s", attempts, failureReason, requeueAfter)
+			} else {
+				newPhase = axonv1alpha1.TaskPhaseFailed
+				newMessage = "Task failed"
+				if attempts > 1 {
+					newMessage = fmt.Sprintf("Task failed after %d attempts (%s)", attempts, failureReason)
+				}
+				setCompletionTime = true
+			}
 		}
 	}
 
@@ -386,28 +617,45 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 		return ctrl.Result{}, nil
 	}
 
-	// Read outputs from Pod logs when transitioning to a terminal phase
-	// or retrying capture for an already-completed task
+	// Read outputs when an attempt finishes or when retrying capture for an
+	// already-completed task. Outputs are taken from the container
+	// termination message, falling back to the Pod logs; usage is always
+	// read from the Pod logs.
+	attemptFinished := setCompletionTime || newPhase == axonv1alpha1.TaskPhaseRetrying
 	var outputs []string
-	if setCompletionTime || retryOutputs {
+	var usage *Usage
+	if attemptFinished || retryOutputs {
 		effectivePodName := podName
 		if effectivePodName == "" {
 			effectivePodName = task.Status.PodName
 		}
 		containerName := task.Spec.Type
-		outputs = r.readOutputs(ctx, task.Namespace, effectivePodName, containerName)
+		outputs = ParseOutputs(terminationMessage(r.getPod(ctx, task.Namespace, effectivePodName), containerName))
+		logData := r.readLogs(ctx, task.Namespace, effectivePodName, containerName)
+		if outputs == nil {
+			outputs = ParseOutputs(logData)
+		}
+		usage = ParseUsage(task.Spec.Type, logData)
 	}
 
 	// When retrying output capture, skip the status update if we still
 	// have nothing — just requeue to try again later.
 	if retryOutputs && outputs == nil {
+		// Give up once the next retry would fall outside the window
+		if time.Since(task.Status.CompletionTime.Time)+outputRetryInterval >= outputRetryWindow {
+			logger.Info("Unable to capture Task outputs", "task", task.Name)
+			outputCaptureFailuresTotal.WithLabelValues(task.Spec.Type).Inc()
+			return ctrl.Result{}, nil
+		}
 		return ctrl.Result{RequeueAfter: outputRetryInterval}, nil
 	}
 
+	var usageAdded bool
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
 			return getErr
 		}
+		usageAdded = false
 		if podNameChanged {
 			task.Status.PodName = podName
 		}
@@ -420,11 +668,29 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 			}
 			if setCompletionTime {
 				task.Status.CompletionTime = &now
-				task.Status.Outputs = outputs
+				setOutputs(&task.Status, outputs)
+			}
+			if attemptFinished {
+				addUsage(&task.Status, usage)
+				usageAdded = true
 			}
+			if a := currentAttempt(task); a != nil && attemptFinished {
+				a.CompletionTime = &now
+				a.Phase = newPhase
+				if newPhase == axonv1alpha1.TaskPhaseRetrying {
+					a.Phase = axonv1alpha1.TaskPhaseFailed
+				}
+				a.Reason = failureReason
+			}
+			setTaskPhaseConditions(task)
 		}
 		if retryOutputs && outputs != nil {
-			task.Status.Outputs = outputs
+			setOutputs(&task.Status, outputs)
+			// Usage is read from the same logs, so it was missed as well
+			if task.Status.NumTurns == 0 && task.Status.InputTokens == 0 && task.Status.CostUSD == "" {
+				addUsage(&task.Status, usage)
+				usageAdded = true
+			}
 		}
 		return r.Status().Update(ctx, task)
 	}); err != nil {
@@ -432,6 +698,25 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 		return ctrl.Result{}, err
 	}
 
+	if phaseChanged {
+		r.recordPhaseEvent(task, newPhase, newMessage)
+	}
+	if usageAdded {
+		observeUsage(task, usage)
+	}
+	if setCompletionTime {
+		start := task.CreationTimestamp.Time
+		if task.Status.StartTime != nil {
+			start = task.Status.StartTime.Time
+		}
+		taskDurationSeconds.WithLabelValues(task.Spec.Type, string(newPhase)).Observe(task.Status.CompletionTime.Sub(start).Seconds())
+	}
+
+	if newPhase == axonv1alpha1.TaskPhaseRetrying {
+		logger.Info("Task attempt failed, retrying", "task", task.Name, "reason", failureReason, "after", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// Requeue to retry output capture when the initial attempt got nothing
 	if setCompletionTime && outputs == nil {
 		return ctrl.Result{RequeueAfter: outputRetryInterval}, nil
@@ -440,6 +725,35 @@ func (r *TaskReconciler) updateStatus(ctx context.Context, task *axonv1alpha1.Ta
 	return ctrl.Result{}, nil
 }
 
+// updateCondition persists a condition on task unless it is already set
+// with the same status, reason and message.
+func (r *TaskReconciler) updateCondition(ctx context.Context, task *axonv1alpha1.Task, conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	if c := meta.FindStatusCondition(task.Status.Conditions, conditionType); c != nil &&
+		c.Status == status && c.Reason == reason && c.Message == message && c.ObservedGeneration == task.Generation {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(task), task); getErr != nil {
+			return getErr
+		}
+		setTaskCondition(task, conditionType, status, reason, message)
+		return r.Status().Update(ctx, task)
+	})
+}
+
+// recordPhaseEvent records an Event for the transition of task to phase.
+// Transitions to Failed and Retrying are recorded as warnings.
+func (r *TaskReconciler) recordPhaseEvent(task *axonv1alpha1.Task, phase axonv1alpha1.TaskPhase, message string) {
+	eventType := corev1.EventTypeNormal
+	if phase == axonv1alpha1.TaskPhaseFailed || phase == axonv1alpha1.TaskPhaseRetrying {
+		eventType = corev1.EventTypeWarning
+	}
+	if message == "" {
+		message = fmt.Sprintf("Task is %s", phase)
+	}
+	r.Recorder.Eventf(task, nil, eventType, string(phase), "UpdatePhase", "%s", message)
+}
+
 // ttlExpired checks whether a finished Task has exceeded its TTL.
 // It returns (true, 0) if the Task should be deleted now, or (false, duration)
 // if the Task should be requeued after the given duration.
@@ -447,7 +761,8 @@ func (r *TaskReconciler) ttlExpired(task *axonv1alpha1.Task) (bool, time.Duratio
 	if task.Spec.TTLSecondsAfterFinished == nil {
 		return false, 0
 	}
-	if task.Status.Phase != axonv1alpha1.TaskPhaseSucceeded && task.Status.Phase != axonv1alpha1.TaskPhaseFailed {
+	if task.Status.Phase != axonv1alpha1.TaskPhaseSucceeded && task.Status.Phase != axonv1alpha1.TaskPhaseFailed &&
+		task.Status.Phase != axonv1alpha1.TaskPhaseCancelled {
 		return false, 0
 	}
 	if task.Status.CompletionTime == nil {
@@ -463,11 +778,26 @@ func (r *TaskReconciler) ttlExpired(task *axonv1alpha1.Task) (bool, time.Duratio
 	return false, remaining
 }
 
-// readOutputs reads Pod logs and extracts output markers.
-func (r *TaskReconciler) readOutputs(ctx context.Context, namespace, podName, container string) []string {
-	if r.Clientset == nil || podName == "" {
+// getPod returns the named Pod, or nil if it cannot be fetched.
+func (r *TaskReconciler) getPod(ctx context.Context, namespace, podName string) *corev1.Pod {
+	if podName == "" {
+		return nil
+	}
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, &pod); err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to fetch Pod", "pod", podName, "error", err)
 		return nil
 	}
+	return &pod
+}
+
+// readLogs reads the tail of the Pod logs of the given container, from
+// which outputs and usage are extracted. It returns an empty string if the
+// logs cannot be read.
+func (r *TaskReconciler) readLogs(ctx context.Context, namespace, podName, container string) string {
+	if r.Clientset == nil || podName == "" {
+		return ""
+	}
 	logger := log.FromContext(ctx)
 
 	var tailLines int64 = 50
@@ -478,23 +808,28 @@ func (r *TaskReconciler) readOutputs(ctx context.Context, namespace, podName, co
 	stream, err := req.Stream(ctx)
 	if err != nil {
 		logger.V(1).Info("Unable to read Pod logs for outputs", "pod", podName, "error", err)
-		return nil
+		return ""
 	}
 	defer stream.Close()
 
 	data, err := io.ReadAll(stream)
 	if err != nil {
 		logger.V(1).Info("Unable to read Pod log stream", "pod", podName, "error", err)
-		return nil
+		return ""
 	}
 
-	return ParseOutputs(string(data))
+	return string(data)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &axonv1alpha1.Task{}, dependsOnIndexField, indexDependsOn); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&axonv1alpha1.Task{}).
 		Owns(&batchv1.Job{}).
+		Watches(&axonv1alpha1.Task{}, handler.EnqueueRequestsFromMapFunc(r.findDependentTasks)).
 		Complete(r)
 }
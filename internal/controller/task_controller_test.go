@@ -1,10 +1,15 @@
 package controller
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
 )
@@ -158,3 +163,97 @@ func TestTTLExpired(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleCancel(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         *batchv1.Job
+		wantSuspend bool
+	}{
+		{
+			name: "Running Job is suspended",
+			job: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "default"},
+				Status:     batchv1.JobStatus{Active: 1},
+			},
+			wantSuspend: true,
+		},
+		{
+			name: "No Job created yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "default"},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:   AgentTypeClaudeCode,
+					Prompt: "test",
+					Cancel: true,
+				},
+				Status: axonv1alpha1.TaskStatus{
+					Phase:   axonv1alpha1.TaskPhaseRunning,
+					JobName: "my-task",
+					Outputs: []string{"branch: fix-1"},
+					Attempts: []axonv1alpha1.TaskAttempt{
+						{Attempt: 1, JobName: "my-task"},
+					},
+				},
+			}
+
+			objs := []client.Object{task}
+			if tt.job != nil {
+				objs = append(objs, tt.job)
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(newDependencyTestScheme()).
+				WithObjects(objs...).
+				WithStatusSubresource(task).
+				Build()
+			recorder := events.NewFakeRecorder(10)
+			r := &TaskReconciler{Client: cl, Recorder: recorder}
+
+			if _, err := r.handleCancel(context.Background(), task); err != nil {
+				t.Fatalf("handleCancel() error = %v", err)
+			}
+
+			select {
+			case e := <-recorder.Events:
+				if e != "Normal Cancelled Task cancelled" {
+					t.Errorf("Event = %q, want %q", e, "Normal Cancelled Task cancelled")
+				}
+			default:
+				t.Error("Expected a Cancelled event to be recorded")
+			}
+
+			var got axonv1alpha1.Task
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(task), &got); err != nil {
+				t.Fatalf("Getting Task: %v", err)
+			}
+			if got.Status.Phase != axonv1alpha1.TaskPhaseCancelled {
+				t.Errorf("Phase = %q, want %q", got.Status.Phase, axonv1alpha1.TaskPhaseCancelled)
+			}
+			if got.Status.CompletionTime == nil {
+				t.Error("Expected CompletionTime to be set")
+			}
+			if len(got.Status.Outputs) != 1 {
+				t.Errorf("Expected outputs to be kept, got %v", got.Status.Outputs)
+			}
+			if got.Status.Attempts[0].Phase != axonv1alpha1.TaskPhaseCancelled {
+				t.Errorf("Attempt phase = %q, want %q", got.Status.Attempts[0].Phase, axonv1alpha1.TaskPhaseCancelled)
+			}
+
+			if tt.job != nil {
+				var job batchv1.Job
+				if err := cl.Get(context.Background(), client.ObjectKeyFromObject(tt.job), &job); err != nil {
+					t.Fatalf("Getting Job: %v", err)
+				}
+				suspended := job.Spec.Suspend != nil && *job.Spec.Suspend
+				if suspended != tt.wantSuspend {
+					t.Errorf("Job suspended = %v, want %v", suspended, tt.wantSuspend)
+				}
+			}
+		})
+	}
+}
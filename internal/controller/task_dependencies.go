@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+const (
+	// dependsOnIndexField is the field index used to look up Tasks by the
+	// names of the Tasks they depend on.
+	dependsOnIndexField = "spec.dependsOn"
+)
+
+// dependencyResult holds the results of a succeeded dependency that are
+// exposed to the dependent Task's prompt template.
+type dependencyResult struct {
+	// Outputs are the output lines captured from the dependency.
+	Outputs []string
+	// Results are the key/value results captured from the dependency.
+	Results map[string]string
+	// Branch is the git branch the dependency worked on.
+	Branch string
+	// CommitSHA is the SHA of the last commit on Branch.
+	CommitSHA string
+	// PullRequestURLs are the pull requests opened by the dependency.
+	PullRequestURLs []string
+}
+
+// dependencyState summarizes the state of a Task's dependencies.
+type dependencyState struct {
+	// Ready is true when every dependency has succeeded.
+	Ready bool
+	// Failed is the name of the first dependency found in the Failed or
+	// Cancelled phase.
+	Failed string
+	// Waiting lists the dependencies that have not yet succeeded.
+	Waiting []string
+	// Cycle is the path of Task names, starting and ending with the Task
+	// itself, of a dependency cycle that can never be satisfied.
+	Cycle []string
+	// Results maps each succeeded dependency name to its results.
+	Results map[string]dependencyResult
+}
+
+// checkDependencies fetches the Tasks listed in task.Spec.DependsOn and
+// reports whether they have all succeeded.
+func (r *TaskReconciler) checkDependencies(ctx context.Context, task *axonv1alpha1.Task) (*dependencyState, error) {
+	state := &dependencyState{
+		Results: make(map[string]dependencyResult, len(task.Spec.DependsOn)),
+	}
+
+	for _, name := range task.Spec.DependsOn {
+		if name == task.Name {
+			state.Failed = name
+			return state, nil
+		}
+
+		var dep axonv1alpha1.Task
+		if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: name}, &dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				state.Waiting = append(state.Waiting, name)
+				continue
+			}
+			return nil, fmt.Errorf("fetching dependency %q: %w", name, err)
+		}
+
+		switch dep.Status.Phase {
+		case axonv1alpha1.TaskPhaseSucceeded:
+			state.Results[name] = dependencyResult{
+				Outputs:         dep.Status.Outputs,
+				Results:         dep.Status.Results,
+				Branch:          dep.Status.Branch,
+				CommitSHA:       dep.Status.CommitSHA,
+				PullRequestURLs: dep.Status.PullRequestURLs,
+			}
+		case axonv1alpha1.TaskPhaseFailed, axonv1alpha1.TaskPhaseCancelled:
+			state.Failed = name
+			return state, nil
+		default:
+			state.Waiting = append(state.Waiting, name)
+		}
+	}
+
+	state.Ready = len(state.Waiting) == 0
+	if !state.Ready {
+		cycle, err := r.findDependencyCycle(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if cycle != nil {
+			state.Cycle = cycle
+			state.Failed = cycle[1]
+		}
+	}
+	return state, nil
+}
+
+// findDependencyCycle follows the dependsOn edges of the Tasks in the
+// namespace, starting at task, and returns the path of a cycle leading back
+// to it, or nil if there is none. Dependencies that do not exist yet or
+// have already succeeded cannot block the Task and are not followed.
+func (r *TaskReconciler) findDependencyCycle(ctx context.Context, task *axonv1alpha1.Task) ([]string, error) {
+	visited := map[string]bool{task.Name: true}
+
+	var walk func(path, deps []string) ([]string, error)
+	walk = func(path, deps []string) ([]string, error) {
+		for _, name := range deps {
+			if name == task.Name {
+				return append(slices.Clip(path), name), nil
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			var dep axonv1alpha1.Task
+			if err := r.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: name}, &dep); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("fetching dependency %q: %w", name, err)
+			}
+			if dep.Status.Phase == axonv1alpha1.TaskPhaseSucceeded {
+				continue
+			}
+			cycle, err := walk(append(slices.Clip(path), name), dep.Spec.DependsOn)
+			if err != nil || cycle != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+	return walk([]string{task.Name}, task.Spec.DependsOn)
+}
+
+// dependencyMessage returns a human-readable status message for a Task
+// whose dependencies are not yet satisfied.
+func dependencyMessage(task *axonv1alpha1.Task, state *dependencyState) string {
+	if state.Failed == task.Name {
+		return "Task cannot depend on itself"
+	}
+	if len(state.Cycle) > 0 {
+		return fmt.Sprintf("Dependency cycle detected: %s", strings.Join(state.Cycle, " -> "))
+	}
+	if state.Failed != "" {
+		return fmt.Sprintf("Dependency %q did not succeed", state.Failed)
+	}
+	return fmt.Sprintf("Waiting for dependencies: %s", strings.Join(state.Waiting, ", "))
+}
+
+// renderDependencyPrompt renders the Task prompt as a Go text/template with
+// the results of its dependencies available as .Deps.
+func renderDependencyPrompt(prompt string, results map[string]dependencyResult) (string, error) {
+	tmpl, err := parseDependencyPrompt(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		Deps map[string]dependencyResult
+	}{
+		Deps: results,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("executing prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// parseDependencyPrompt parses the prompt of a Task that has dependencies
+// as a Go text/template.
+func parseDependencyPrompt(prompt string) (*template.Template, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=zero").Parse(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing prompt template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// findDependentTasks maps a Task to reconcile requests for the Tasks in the
+// same namespace that depend on it, so that they are re-evaluated whenever
+// one of their dependencies changes.
+func (r *TaskReconciler) findDependentTasks(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dependents axonv1alpha1.TaskList
+	if err := r.List(ctx, &dependents,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{dependsOnIndexField: obj.GetName()},
+	); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(dependents.Items))
+	for _, t := range dependents.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: t.Namespace, Name: t.Name},
+		})
+	}
+	return requests
+}
+
+// indexDependsOn is the field indexer function for dependsOnIndexField.
+func indexDependsOn(obj client.Object) []string {
+	task, ok := obj.(*axonv1alpha1.Task)
+	if !ok {
+		return nil
+	}
+	return task.Spec.DependsOn
+}
@@ -0,0 +1,275 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func newDependencyTestScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(axonv1alpha1.AddToScheme(s))
+	return s
+}
+
+func newDependencyTask(name string, phase axonv1alpha1.TaskPhase, outputs []string, dependsOn ...string) *axonv1alpha1.Task {
+	return &axonv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpec{
+			Type:      AgentTypeClaudeCode,
+			Prompt:    "test",
+			DependsOn: dependsOn,
+		},
+		Status: axonv1alpha1.TaskStatus{
+			Phase:   phase,
+			Outputs: outputs,
+		},
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		deps        []client.Object
+		dependsOn   []string
+		wantReady   bool
+		wantFailed  string
+		wantWaiting []string
+		wantMessage string
+	}{
+		{
+			name: "All dependencies succeeded",
+			deps: []client.Object{
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseSucceeded, []string{"branch: fix-1"}),
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseSucceeded, nil),
+			},
+			dependsOn: []string{"investigate", "implement"},
+			wantReady: true,
+		},
+		{
+			name: "Dependency still running",
+			deps: []client.Object{
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseSucceeded, nil),
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseRunning, nil),
+			},
+			dependsOn:   []string{"investigate", "implement"},
+			wantWaiting: []string{"implement"},
+			wantMessage: "Waiting for dependencies: implement",
+		},
+		{
+			name:        "Dependency does not exist yet",
+			dependsOn:   []string{"investigate"},
+			wantWaiting: []string{"investigate"},
+			wantMessage: "Waiting for dependencies: investigate",
+		},
+		{
+			name: "Dependency failed",
+			deps: []client.Object{
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseFailed, nil),
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseRunning, nil),
+			},
+			dependsOn:   []string{"implement", "investigate"},
+			wantFailed:  "investigate",
+			wantWaiting: []string{"implement"},
+			wantMessage: `Dependency "investigate" did not succeed`,
+		},
+		{
+			name: "Dependency cancelled",
+			deps: []client.Object{
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseCancelled, nil),
+			},
+			dependsOn:   []string{"investigate"},
+			wantFailed:  "investigate",
+			wantMessage: `Dependency "investigate" did not succeed`,
+		},
+		{
+			name: "Dependency cycle",
+			deps: []client.Object{
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseWaiting, nil, "investigate"),
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseWaiting, nil, "review"),
+			},
+			dependsOn:   []string{"implement"},
+			wantFailed:  "implement",
+			wantWaiting: []string{"implement"},
+			wantMessage: "Dependency cycle detected: review -> implement -> investigate -> review",
+		},
+		{
+			name: "Cycle among other Tasks is not reported",
+			deps: []client.Object{
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseWaiting, nil, "investigate"),
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseWaiting, nil, "implement"),
+			},
+			dependsOn:   []string{"implement"},
+			wantWaiting: []string{"implement"},
+			wantMessage: "Waiting for dependencies: implement",
+		},
+		{
+			name: "Succeeded dependency breaks the cycle",
+			deps: []client.Object{
+				newDependencyTask("implement", axonv1alpha1.TaskPhaseRunning, nil, "investigate"),
+				newDependencyTask("investigate", axonv1alpha1.TaskPhaseSucceeded, nil, "review"),
+			},
+			dependsOn:   []string{"implement"},
+			wantWaiting: []string{"implement"},
+			wantMessage: "Waiting for dependencies: implement",
+		},
+		{
+			name:        "Depends on itself",
+			dependsOn:   []string{"review"},
+			wantFailed:  "review",
+			wantMessage: "Task cannot depend on itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().
+				WithScheme(newDependencyTestScheme()).
+				WithObjects(tt.deps...).
+				Build()
+			r := &TaskReconciler{Client: cl}
+
+			task := newDependencyTask("review", "", nil, tt.dependsOn...)
+			state, err := r.checkDependencies(context.Background(), task)
+			if err != nil {
+				t.Fatalf("checkDependencies() error = %v", err)
+			}
+			if state.Ready != tt.wantReady {
+				t.Errorf("Ready = %v, want %v", state.Ready, tt.wantReady)
+			}
+			if state.Failed != tt.wantFailed {
+				t.Errorf("Failed = %q, want %q", state.Failed, tt.wantFailed)
+			}
+			if len(state.Waiting) != len(tt.wantWaiting) {
+				t.Fatalf("Waiting = %v, want %v", state.Waiting, tt.wantWaiting)
+			}
+			for i := range tt.wantWaiting {
+				if state.Waiting[i] != tt.wantWaiting[i] {
+					t.Errorf("Waiting[%d] = %q, want %q", i, state.Waiting[i], tt.wantWaiting[i])
+				}
+			}
+			if !tt.wantReady {
+				if msg := dependencyMessage(task, state); msg != tt.wantMessage {
+					t.Errorf("dependencyMessage() = %q, want %q", msg, tt.wantMessage)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckDependenciesCollectsOutputs(t *testing.T) {
+	cl := fake.NewClientBuilder().
+		WithScheme(newDependencyTestScheme()).
+		WithObjects(newDependencyTask("investigate", axonv1alpha1.TaskPhaseSucceeded, []string{
+			"branch: fix-1",
+			"https://github.com/org/repo/pull/1",
+		})).
+		Build()
+	r := &TaskReconciler{Client: cl}
+
+	state, err := r.checkDependencies(context.Background(), newDependencyTask("review", "", nil, "investigate"))
+	if err != nil {
+		t.Fatalf("checkDependencies() error = %v", err)
+	}
+	outputs := state.Results["investigate"].Outputs
+	if len(outputs) != 2 || outputs[0] != "branch: fix-1" {
+		t.Errorf("Results[investigate].Outputs = %v, want branch and PR URL", outputs)
+	}
+}
+
+func TestRenderDependencyPrompt(t *testing.T) {
+	results := map[string]dependencyResult{
+		"investigate": {Outputs: []string{"branch: fix-1", "https://github.com/org/repo/pull/1"}},
+	}
+
+	tests := []struct {
+		name    string
+		prompt  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Plain prompt",
+			prompt: "Review the change",
+			want:   "Review the change",
+		},
+		{
+			name:   "Dependency outputs",
+			prompt: `Review:{{range (index .Deps "investigate").Outputs}} {{.}}{{end}}`,
+			want:   "Review: branch: fix-1 https://github.com/org/repo/pull/1",
+		},
+		{
+			name:   "Unknown dependency renders empty",
+			prompt: `Review:{{range (index .Deps "other").Outputs}} {{.}}{{end}}`,
+			want:   "Review:",
+		},
+		{
+			name:    "Invalid template",
+			prompt:  "Review {{.Deps",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderDependencyPrompt(tt.prompt, results)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderDependencyPrompt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderDependencyPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateJobPromptRenderFailure(t *testing.T) {
+	ttl := int32(60)
+	task := newDependencyTask("review", axonv1alpha1.TaskPhasePending, nil, "investigate")
+	task.Spec.Prompt = "Review {{.Deps"
+	task.Spec.TTLSecondsAfterFinished = &ttl
+	cl := fake.NewClientBuilder().
+		WithScheme(newDependencyTestScheme()).
+		WithObjects(task).
+		WithStatusSubresource(task).
+		Build()
+	r := &TaskReconciler{Client: cl, Recorder: events.NewFakeRecorder(10)}
+
+	result, err := r.createJob(context.Background(), task, nil)
+	if err != nil {
+		t.Fatalf("createJob() error = %v", err)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("Expected a requeue for the TTL, got %+v", result)
+	}
+
+	var got axonv1alpha1.Task
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(task), &got); err != nil {
+		t.Fatalf("Getting Task: %v", err)
+	}
+	if got.Status.Phase != axonv1alpha1.TaskPhaseFailed {
+		t.Errorf("Phase = %q, want %q", got.Status.Phase, axonv1alpha1.TaskPhaseFailed)
+	}
+	if got.Status.CompletionTime == nil {
+		t.Error("Expected CompletionTime to be set so that the TTL applies")
+	}
+}
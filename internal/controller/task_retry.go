@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+const (
+	// defaultRetryBackoff is the delay before the first retry when
+	// retryPolicy.backoffSeconds is unset.
+	defaultRetryBackoff = 10 * time.Second
+
+	// defaultMaxRetryBackoff caps the delay between retries when
+	// retryPolicy.maxBackoffSeconds is unset.
+	defaultMaxRetryBackoff = 5 * time.Minute
+)
+
+// attemptJobName returns the name of the Job for the given attempt. The
+// first attempt uses the Task name so that Tasks without a retry policy
+// keep their existing Job name.
+func attemptJobName(taskName string, attempt int32) string {
+	if attempt <= 1 {
+		return taskName
+	}
+	return fmt.Sprintf("%s-attempt-%d", taskName, attempt)
+}
+
+// currentJobName returns the name of the Job for the Task's current attempt.
+func currentJobName(task *axonv1alpha1.Task) string {
+	if task.Status.JobName != "" {
+		return task.Status.JobName
+	}
+	return task.Name
+}
+
+// currentAttempt returns the record of the Task's current attempt, or nil
+// if no attempt has been recorded.
+func currentAttempt(task *axonv1alpha1.Task) *axonv1alpha1.TaskAttempt {
+	if len(task.Status.Attempts) == 0 {
+		return nil
+	}
+	return &task.Status.Attempts[len(task.Status.Attempts)-1]
+}
+
+// attemptCount returns the number of attempts started for the Task.
+func attemptCount(task *axonv1alpha1.Task) int32 {
+	if n := int32(len(task.Status.Attempts)); n > 0 {
+		return n
+	}
+	// Tasks created before attempts were recorded have a single Job.
+	if task.Status.JobName != "" {
+		return 1
+	}
+	return 0
+}
+
+// jobFailureReason determines why a failed Job failed, using the Job
+// conditions and the terminated state of the agent container in pod.
+// pod may be nil if it could not be found.
+func jobFailureReason(job *batchv1.Job, pod *corev1.Pod, container string) axonv1alpha1.FailureReason {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue && c.Reason == batchv1.JobReasonDeadlineExceeded {
+			return axonv1alpha1.FailureReasonDeadlineExceeded
+		}
+	}
+	if pod != nil {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == container && cs.State.Terminated != nil && cs.State.Terminated.Reason == "OOMKilled" {
+				return axonv1alpha1.FailureReasonOOMKilled
+			}
+		}
+	}
+	return axonv1alpha1.FailureReasonError
+}
+
+// shouldRetry reports whether a Task whose current attempt failed with the
+// given reason has retries left under its retry policy.
+func shouldRetry(task *axonv1alpha1.Task, reason axonv1alpha1.FailureReason) bool {
+	policy := task.Spec.RetryPolicy
+	if policy == nil || attemptCount(task) >= policy.MaxAttempts {
+		return false
+	}
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, r := range policy.RetryOn {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// retryBackoff returns the delay before the attempt that follows the given
+// failed attempt. The delay doubles with each attempt and is capped.
+func retryBackoff(policy *axonv1alpha1.RetryPolicy, failedAttempt int32) time.Duration {
+	backoff := defaultRetryBackoff
+	maxBackoff := defaultMaxRetryBackoff
+	if policy != nil && policy.BackoffSeconds != nil {
+		backoff = time.Duration(*policy.BackoffSeconds) * time.Second
+	}
+	if policy != nil && policy.MaxBackoffSeconds != nil {
+		maxBackoff = time.Duration(*policy.MaxBackoffSeconds) * time.Second
+	}
+
+	for i := int32(1); i < failedAttempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// retryDelay returns how long to wait before starting the next attempt of
+// a Task in the Retrying phase. It returns 0 if the next attempt is due.
+func retryDelay(task *axonv1alpha1.Task, now time.Time) time.Duration {
+	last := currentAttempt(task)
+	if last == nil || last.CompletionTime == nil {
+		return 0
+	}
+	next := last.CompletionTime.Add(retryBackoff(task.Spec.RetryPolicy, last.Attempt))
+	if remaining := next.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestAttemptJobName(t *testing.T) {
+	tests := []struct {
+		attempt int32
+		want    string
+	}{
+		{attempt: 0, want: "my-task"},
+		{attempt: 1, want: "my-task"},
+		{attempt: 2, want: "my-task-attempt-2"},
+		{attempt: 10, want: "my-task-attempt-10"},
+	}
+
+	for _, tt := range tests {
+		if got := attemptJobName("my-task", tt.attempt); got != tt.want {
+			t.Errorf("attemptJobName(%d) = %q, want %q", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestJobFailureReason(t *testing.T) {
+	failedJob := func(reason string) *batchv1.Job {
+		return &batchv1.Job{
+			Status: batchv1.JobStatus{
+				Failed: 1,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: reason},
+				},
+			},
+		}
+	}
+	terminatedPod := func(container, reason string) *corev1.Pod {
+		return &corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: container,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: reason},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		job  *batchv1.Job
+		pod  *corev1.Pod
+		want axonv1alpha1.FailureReason
+	}{
+		{
+			name: "Deadline exceeded",
+			job:  failedJob(batchv1.JobReasonDeadlineExceeded),
+			want: axonv1alpha1.FailureReasonDeadlineExceeded,
+		},
+		{
+			name: "OOM killed agent container",
+			job:  failedJob(batchv1.JobReasonBackoffLimitExceeded),
+			pod:  terminatedPod("claude-code", "OOMKilled"),
+			want: axonv1alpha1.FailureReasonOOMKilled,
+		},
+		{
+			name: "OOM killed other container",
+			job:  failedJob(batchv1.JobReasonBackoffLimitExceeded),
+			pod:  terminatedPod("git-clone", "OOMKilled"),
+			want: axonv1alpha1.FailureReasonError,
+		},
+		{
+			name: "Non-zero exit",
+			job:  failedJob(batchv1.JobReasonBackoffLimitExceeded),
+			pod:  terminatedPod("claude-code", "Error"),
+			want: axonv1alpha1.FailureReasonError,
+		},
+		{
+			name: "Pod not found",
+			job:  failedJob(batchv1.JobReasonBackoffLimitExceeded),
+			want: axonv1alpha1.FailureReasonError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobFailureReason(tt.job, tt.pod, "claude-code"); got != tt.want {
+				t.Errorf("jobFailureReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	taskWithAttempts := func(policy *axonv1alpha1.RetryPolicy, attempts int) *axonv1alpha1.Task {
+		task := &axonv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-task"},
+			Spec:       axonv1alpha1.TaskSpec{RetryPolicy: policy},
+		}
+		for i := 1; i <= attempts; i++ {
+			task.Status.Attempts = append(task.Status.Attempts, axonv1alpha1.TaskAttempt{
+				Attempt: int32(i),
+				JobName: attemptJobName("my-task", int32(i)),
+			})
+		}
+		return task
+	}
+
+	tests := []struct {
+		name   string
+		task   *axonv1alpha1.Task
+		reason axonv1alpha1.FailureReason
+		want   bool
+	}{
+		{
+			name:   "No retry policy",
+			task:   taskWithAttempts(nil, 1),
+			reason: axonv1alpha1.FailureReasonError,
+			want:   false,
+		},
+		{
+			name:   "Attempts remaining",
+			task:   taskWithAttempts(&axonv1alpha1.RetryPolicy{MaxAttempts: 3}, 2),
+			reason: axonv1alpha1.FailureReasonError,
+			want:   true,
+		},
+		{
+			name:   "Budget used up",
+			task:   taskWithAttempts(&axonv1alpha1.RetryPolicy{MaxAttempts: 3}, 3),
+			reason: axonv1alpha1.FailureReasonError,
+			want:   false,
+		},
+		{
+			name: "Reason is retryable",
+			task: taskWithAttempts(&axonv1alpha1.RetryPolicy{
+				MaxAttempts: 3,
+				RetryOn:     []axonv1alpha1.FailureReason{axonv1alpha1.FailureReasonError, axonv1alpha1.FailureReasonOOMKilled},
+			}, 1),
+			reason: axonv1alpha1.FailureReasonOOMKilled,
+			want:   true,
+		},
+		{
+			name: "Reason is not retryable",
+			task: taskWithAttempts(&axonv1alpha1.RetryPolicy{
+				MaxAttempts: 3,
+				RetryOn:     []axonv1alpha1.FailureReason{axonv1alpha1.FailureReasonError},
+			}, 1),
+			reason: axonv1alpha1.FailureReasonDeadlineExceeded,
+			want:   false,
+		},
+		{
+			name: "Task created before attempts were recorded",
+			task: &axonv1alpha1.Task{
+				Spec:   axonv1alpha1.TaskSpec{RetryPolicy: &axonv1alpha1.RetryPolicy{MaxAttempts: 2}},
+				Status: axonv1alpha1.TaskStatus{JobName: "my-task"},
+			},
+			reason: axonv1alpha1.FailureReasonError,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.task, tt.reason); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name          string
+		policy        *axonv1alpha1.RetryPolicy
+		failedAttempt int32
+		want          time.Duration
+	}{
+		{
+			name:          "Default backoff after first attempt",
+			policy:        &axonv1alpha1.RetryPolicy{MaxAttempts: 3},
+			failedAttempt: 1,
+			want:          10 * time.Second,
+		},
+		{
+			name:          "Default backoff doubles",
+			policy:        &axonv1alpha1.RetryPolicy{MaxAttempts: 3},
+			failedAttempt: 3,
+			want:          40 * time.Second,
+		},
+		{
+			name:          "Default backoff is capped",
+			policy:        &axonv1alpha1.RetryPolicy{MaxAttempts: 20},
+			failedAttempt: 10,
+			want:          5 * time.Minute,
+		},
+		{
+			name: "Custom backoff and cap",
+			policy: &axonv1alpha1.RetryPolicy{
+				MaxAttempts:       5,
+				BackoffSeconds:    int32Ptr(30),
+				MaxBackoffSeconds: int32Ptr(60),
+			},
+			failedAttempt: 3,
+			want:          60 * time.Second,
+		},
+		{
+			name: "Zero backoff",
+			policy: &axonv1alpha1.RetryPolicy{
+				MaxAttempts:    5,
+				BackoffSeconds: int32Ptr(0),
+			},
+			failedAttempt: 2,
+			want:          0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryBackoff(tt.policy, tt.failedAttempt); got != tt.want {
+				t.Errorf("retryBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Now()
+	completed := metav1.NewTime(now.Add(-4 * time.Second))
+	task := &axonv1alpha1.Task{
+		Spec: axonv1alpha1.TaskSpec{
+			RetryPolicy: &axonv1alpha1.RetryPolicy{MaxAttempts: 3},
+		},
+		Status: axonv1alpha1.TaskStatus{
+			Phase: axonv1alpha1.TaskPhaseRetrying,
+			Attempts: []axonv1alpha1.TaskAttempt{
+				{Attempt: 1, JobName: "my-task", CompletionTime: &completed},
+			},
+		},
+	}
+
+	if got := retryDelay(task, now); got != 6*time.Second {
+		t.Errorf("retryDelay() = %v, want %v", got, 6*time.Second)
+	}
+	if got := retryDelay(task, now.Add(time.Minute)); got != 0 {
+		t.Errorf("retryDelay() after backoff = %v, want 0", got)
+	}
+}
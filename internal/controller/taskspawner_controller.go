@@ -2,15 +2,18 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,15 +33,21 @@ type TaskSpawnerReconciler struct {
 	client.Client
 	Scheme            *runtime.Scheme
 	DeploymentBuilder *DeploymentBuilder
+	Recorder          events.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=axon.io,resources=taskspawners,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=axon.io,resources=taskspawners/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=axon.io,resources=taskspawners/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=axon.io,resources=workspaces,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
+// +kubebuilder:rbac:groups=apps,resources=replicasets;statefulsets;daemonsets,verbs=get;list
 
 // Reconcile handles TaskSpawner reconciliation.
 func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -89,6 +98,7 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Resolve workspace if workspaceRef is set in taskTemplate
 	var workspace *axonv1alpha1.WorkspaceSpec
 	var isGitHubApp bool
+	var conditions []metav1.Condition
 	if ts.Spec.TaskTemplate.WorkspaceRef != nil {
 		workspaceRefName := ts.Spec.TaskTemplate.WorkspaceRef.Name
 		var ws axonv1alpha1.Workspace
@@ -98,12 +108,25 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}, &ws); err != nil {
 			if apierrors.IsNotFound(err) {
 				logger.Info("Workspace not found yet, requeuing", "workspace", workspaceRefName)
+				r.Recorder.Eventf(&ts, nil, corev1.EventTypeWarning, reasonWorkspaceNotFound, "Resolve", "Workspace %q not found", workspaceRefName)
+				if err := r.updateConditions(ctx, &ts, func(conditions *[]metav1.Condition) {
+					setCondition(conditions, ts.Generation, axonv1alpha1.ConditionWorkspaceResolved, metav1.ConditionFalse, reasonWorkspaceNotFound,
+						fmt.Sprintf("Workspace %q not found", workspaceRefName))
+				}); err != nil {
+					logger.Error(err, "Unable to update TaskSpawner status")
+					return ctrl.Result{}, err
+				}
 				return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 			}
 			logger.Error(err, "Unable to fetch Workspace for TaskSpawner", "workspace", workspaceRefName)
 			return ctrl.Result{}, err
 		}
 		workspace = &ws.Spec
+		conditions = append(conditions, metav1.Condition{
+			Type:   axonv1alpha1.ConditionWorkspaceResolved,
+			Status: metav1.ConditionTrue,
+			Reason: "Resolved",
+		})
 
 		// Detect GitHub App auth
 		if workspace.SecretRef != nil {
@@ -116,7 +139,18 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					logger.Error(err, "Unable to fetch workspace secret", "secret", workspace.SecretRef.Name)
 					return ctrl.Result{}, err
 				}
+				conditions = append(conditions, metav1.Condition{
+					Type:    axonv1alpha1.ConditionCredentialsResolved,
+					Status:  metav1.ConditionFalse,
+					Reason:  "SecretNotFound",
+					Message: fmt.Sprintf("Secret %q not found", workspace.SecretRef.Name),
+				})
 			} else {
+				conditions = append(conditions, metav1.Condition{
+					Type:   axonv1alpha1.ConditionCredentialsResolved,
+					Status: metav1.ConditionTrue,
+					Reason: "Resolved",
+				})
 				isGitHubApp = githubapp.IsGitHubApp(secret.Data)
 				if isGitHubApp {
 					logger.Info("Detected GitHub App secret for TaskSpawner", "secret", workspace.SecretRef.Name)
@@ -136,8 +170,38 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	// Update status with deployment name if not set
-	if ts.Status.DeploymentName != deploy.Name {
+	serviceConflict, err := r.reconcileWebhookService(ctx, &ts)
+	if err != nil {
+		logger.Error(err, "Unable to reconcile webhook Service")
+		return ctrl.Result{}, err
+	}
+
+	if serviceConflict != "" {
+		conditions = append(conditions, metav1.Condition{
+			Type:    axonv1alpha1.ConditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonWebhookServiceConflict,
+			Message: serviceConflict,
+		})
+	} else if deploy.Status.AvailableReplicas > 0 {
+		conditions = append(conditions, metav1.Condition{
+			Type:    axonv1alpha1.ConditionReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  "DeploymentAvailable",
+			Message: fmt.Sprintf("Deployment %s is available", deploy.Name),
+		})
+	} else {
+		conditions = append(conditions, metav1.Condition{
+			Type:    axonv1alpha1.ConditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "DeploymentUnavailable",
+			Message: fmt.Sprintf("Deployment %s has no available replicas", deploy.Name),
+		})
+	}
+
+	// Update status with deployment name and conditions if they changed
+	if ts.Status.DeploymentName != deploy.Name || ts.Status.ObservedGeneration != ts.Generation ||
+		conditionsChanged(ts.Status.Conditions, conditions) {
 		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 			if getErr := r.Get(ctx, req.NamespacedName, &ts); getErr != nil {
 				return getErr
@@ -146,6 +210,10 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if ts.Status.Phase == "" {
 				ts.Status.Phase = axonv1alpha1.TaskSpawnerPhasePending
 			}
+			ts.Status.ObservedGeneration = ts.Generation
+			for _, c := range conditions {
+				setCondition(&ts.Status.Conditions, ts.Generation, c.Type, c.Status, c.Reason, c.Message)
+			}
 			return r.Status().Update(ctx, &ts)
 		}); err != nil {
 			logger.Error(err, "Unable to update TaskSpawner status")
@@ -156,6 +224,22 @@ func (r *TaskSpawnerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// updateConditions applies mutate to the conditions of ts and persists them.
+func (r *TaskSpawnerReconciler) updateConditions(ctx context.Context, ts *axonv1alpha1.TaskSpawner, mutate func(conditions *[]metav1.Condition)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(ts), ts); getErr != nil {
+			return getErr
+		}
+		before := ts.Status.DeepCopy()
+		ts.Status.ObservedGeneration = ts.Generation
+		mutate(&ts.Status.Conditions)
+		if equality.Semantic.DeepEqual(before, &ts.Status) {
+			return nil
+		}
+		return r.Status().Update(ctx, ts)
+	})
+}
+
 // handleDeletion handles TaskSpawner deletion.
 func (r *TaskSpawnerReconciler) handleDeletion(ctx context.Context, ts *axonv1alpha1.TaskSpawner) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -194,6 +278,7 @@ func (r *TaskSpawnerReconciler) createDeployment(ctx context.Context, ts *axonv1
 	}
 
 	logger.Info("created Deployment", "deployment", deploy.Name)
+	r.Recorder.Eventf(ts, deploy, corev1.EventTypeNormal, reasonDeploymentCreated, "Create", "Created Deployment %s", deploy.Name)
 
 	// Update status
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -226,7 +311,8 @@ func (r *TaskSpawnerReconciler) updateDeployment(ctx context.Context, ts *axonv1
 
 	needsUpdate := current.Image != target.Image ||
 		!equalStringSlices(current.Args, target.Args) ||
-		!equalEnvVars(current.Env, target.Env)
+		!equalEnvVars(current.Env, target.Env) ||
+		!equality.Semantic.DeepEqual(current.Ports, target.Ports)
 
 	if !needsUpdate {
 		return nil
@@ -235,15 +321,95 @@ func (r *TaskSpawnerReconciler) updateDeployment(ctx context.Context, ts *axonv1
 	deploy.Spec.Template.Spec.Containers[0].Image = target.Image
 	deploy.Spec.Template.Spec.Containers[0].Args = target.Args
 	deploy.Spec.Template.Spec.Containers[0].Env = target.Env
+	deploy.Spec.Template.Spec.Containers[0].Ports = target.Ports
 
 	if err := r.Update(ctx, deploy); err != nil {
 		return err
 	}
 
 	logger.Info("updated Deployment", "deployment", deploy.Name)
+	r.Recorder.Eventf(ts, deploy, corev1.EventTypeNormal, reasonDeploymentUpdated, "Update", "Updated Deployment %s", deploy.Name)
 	return nil
 }
 
+// reconcileWebhookService creates or updates the Service exposing the
+// GitHub webhook endpoint of the spawner when the webhook is enabled, and
+// deletes it otherwise. It returns a message describing the conflict if a
+// Service the TaskSpawner does not own already has its name.
+func (r *TaskSpawnerReconciler) reconcileWebhookService(ctx context.Context, ts *axonv1alpha1.TaskSpawner) (string, error) {
+	logger := log.FromContext(ctx)
+
+	var svc corev1.Service
+	exists := true
+	if err := r.Get(ctx, client.ObjectKeyFromObject(ts), &svc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		exists = false
+	}
+
+	if webhookSpec(ts) == nil {
+		if !exists || !metav1.IsControlledBy(&svc, ts) {
+			return "", nil
+		}
+		if err := r.Delete(ctx, &svc); err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		logger.Info("deleted webhook Service", "service", svc.Name)
+		return "", nil
+	}
+
+	desired := r.DeploymentBuilder.BuildWebhookService(ts)
+	if exists {
+		if !metav1.IsControlledBy(&svc, ts) {
+			msg := fmt.Sprintf("Service %s already exists and is not owned by the TaskSpawner; webhook deliveries may not reach the spawner", svc.Name)
+			logger.Info("webhook Service name is taken", "service", svc.Name)
+			r.Recorder.Eventf(ts, &svc, corev1.EventTypeWarning, reasonWebhookServiceConflict, "Create", "%s", msg)
+			return msg, nil
+		}
+		if equalServicePorts(svc.Spec.Ports, desired.Spec.Ports) && equality.Semantic.DeepEqual(svc.Spec.Selector, desired.Spec.Selector) {
+			return "", nil
+		}
+		svc.Spec.Ports = desired.Spec.Ports
+		svc.Spec.Selector = desired.Spec.Selector
+		if err := r.Update(ctx, &svc); err != nil {
+			return "", err
+		}
+		logger.Info("updated webhook Service", "service", svc.Name)
+		r.Recorder.Eventf(ts, &svc, corev1.EventTypeNormal, reasonWebhookServiceUpdated, "Update", "Updated webhook Service %s", svc.Name)
+		return "", nil
+	}
+
+	if err := controllerutil.SetControllerReference(ts, desired, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := r.Create(ctx, desired); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	logger.Info("created webhook Service", "service", desired.Name)
+	return "", nil
+}
+
+// equalServicePorts reports whether the ports of a Service match the
+// desired ports, ignoring the fields defaulted by the API server.
+func equalServicePorts(current, desired []corev1.ServicePort) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for i := range desired {
+		if current[i].Name != desired[i].Name ||
+			current[i].Port != desired[i].Port ||
+			current[i].TargetPort != desired[i].TargetPort ||
+			current[i].Protocol != desired[i].Protocol {
+			return false
+		}
+	}
+	return true
+}
+
 func equalStringSlices(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -352,5 +518,6 @@ func (r *TaskSpawnerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&axonv1alpha1.TaskSpawner{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
 		Complete(r)
 }
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestReconcileWebhookService(t *testing.T) {
+	newSpawner := func() *axonv1alpha1.TaskSpawner {
+		return &axonv1alpha1.TaskSpawner{
+			ObjectMeta: metav1.ObjectMeta{Name: "spawner", Namespace: "default", UID: "uid-spawner"},
+			Spec: axonv1alpha1.TaskSpawnerSpec{
+				When: axonv1alpha1.When{GitHubIssues: &axonv1alpha1.GitHubIssues{
+					Webhook: &axonv1alpha1.GitHubWebhook{SecretRef: axonv1alpha1.SecretReference{Name: "webhook-secret"}},
+				}},
+			},
+		}
+	}
+	staleService := func() *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "spawner", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "other"},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		owned        bool
+		wantConflict bool
+		wantEvent    string
+		wantUpdated  bool
+	}{
+		{
+			name:        "Owned Service is brought back in line",
+			owned:       true,
+			wantEvent:   "Normal WebhookServiceUpdated",
+			wantUpdated: true,
+		},
+		{
+			name:         "Service not owned by the TaskSpawner is reported",
+			wantConflict: true,
+			wantEvent:    "Warning WebhookServiceConflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := newDependencyTestScheme()
+			ts := newSpawner()
+			svc := staleService()
+			if tt.owned {
+				if err := controllerutil.SetControllerReference(ts, svc, scheme); err != nil {
+					t.Fatalf("Setting owner reference: %v", err)
+				}
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ts, svc).Build()
+			recorder := events.NewFakeRecorder(10)
+			r := &TaskSpawnerReconciler{Client: cl, Scheme: scheme, DeploymentBuilder: NewDeploymentBuilder(), Recorder: recorder}
+
+			conflict, err := r.reconcileWebhookService(context.Background(), ts)
+			if err != nil {
+				t.Fatalf("reconcileWebhookService() error = %v", err)
+			}
+			if (conflict != "") != tt.wantConflict {
+				t.Errorf("conflict = %q, want conflict %v", conflict, tt.wantConflict)
+			}
+
+			select {
+			case e := <-recorder.Events:
+				if !strings.HasPrefix(e, tt.wantEvent) {
+					t.Errorf("Event = %q, want prefix %q", e, tt.wantEvent)
+				}
+			default:
+				t.Errorf("Expected a %s event to be recorded", tt.wantEvent)
+			}
+
+			var got corev1.Service
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(svc), &got); err != nil {
+				t.Fatalf("Getting Service: %v", err)
+			}
+			desired := r.DeploymentBuilder.BuildWebhookService(ts)
+			updated := equalServicePorts(got.Spec.Ports, desired.Spec.Ports) && got.Spec.Selector["axon.io/taskspawner"] == "spawner"
+			if updated != tt.wantUpdated {
+				t.Errorf("Service updated = %v, want %v: %+v", updated, tt.wantUpdated, got.Spec)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
 )
@@ -25,6 +26,27 @@ const (
 
 	// SpawnerClusterRole is the ClusterRole referenced by spawner RoleBindings.
 	SpawnerClusterRole = "axon-spawner-role"
+
+	// SpawnerMetricsPort is the port on which the spawner serves metrics.
+	SpawnerMetricsPort = 8080
+
+	// SpawnerWebhookPort is the port on which the spawner receives GitHub
+	// webhook deliveries.
+	SpawnerWebhookPort = 8082
+
+	// webhookSecretKey is the key of the Secret referenced by
+	// githubIssues.webhook.secretRef that holds the webhook secret.
+	webhookSecretKey = "GITHUB_WEBHOOK_SECRET"
+
+	// jiraTokenKey and jiraUserKey are the keys of the Secret referenced by
+	// jira.secretRef that hold the Jira API token and the optional account
+	// email used with it.
+	jiraTokenKey = "JIRA_TOKEN"
+	jiraUserKey  = "JIRA_USER"
+
+	// httpHeadersDir is where the Secret referenced by httpJSON.secretRef is
+	// mounted, one file per request header.
+	httpHeadersDir = "/etc/axon/http-headers"
 )
 
 // DeploymentBuilder constructs Kubernetes Deployments for TaskSpawners.
@@ -45,7 +67,7 @@ func NewDeploymentBuilder() *DeploymentBuilder {
 
 // Build creates a Deployment for the given TaskSpawner.
 // The workspace parameter provides the repository URL and optional secretRef
-// for GitHub API authentication. The isGitHubApp parameter indicates whether
+// for GitHub or GitLab API authentication. The isGitHubApp parameter indicates whether
 // the workspace secret contains GitHub App credentials, which requires a
 // token refresher sidecar.
 func (b *DeploymentBuilder) Build(ts *axonv1alpha1.TaskSpawner, workspace *axonv1alpha1.WorkspaceSpec, isGitHubApp bool) *appsv1.Deployment {
@@ -61,7 +83,27 @@ func (b *DeploymentBuilder) Build(ts *axonv1alpha1.TaskSpawner, workspace *axonv
 	var volumeMounts []corev1.VolumeMount
 	var initContainers []corev1.Container
 
-	if workspace != nil {
+	if workspace != nil && workspaceProvider(workspace) == axonv1alpha1.WorkspaceProviderGitLab {
+		host, project := parseGitLabRepo(workspace.Repo)
+		args = append(args, "--gitlab-project="+project)
+		if apiBaseURL := gitLabAPIBaseURL(host); apiBaseURL != "" {
+			args = append(args, "--gitlab-api-base-url="+apiBaseURL)
+		}
+
+		if workspace.SecretRef != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: "GITLAB_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: workspace.SecretRef.Name,
+						},
+						Key: "GITLAB_TOKEN",
+					},
+				},
+			})
+		}
+	} else if workspace != nil {
 		host, owner, repo := parseGitHubRepo(workspace.Repo)
 		args = append(args,
 			"--github-owner="+owner,
@@ -158,13 +200,74 @@ func (b *DeploymentBuilder) Build(ts *axonv1alpha1.TaskSpawner, workspace *axonv
 		}
 	}
 
-	labels := map[string]string{
-		"app.kubernetes.io/name":       "axon",
-		"app.kubernetes.io/component":  "spawner",
-		"app.kubernetes.io/managed-by": "axon-controller",
-		"axon.io/taskspawner":          ts.Name,
+	ports := []corev1.ContainerPort{
+		{Name: "metrics", ContainerPort: SpawnerMetricsPort, Protocol: corev1.ProtocolTCP},
+	}
+
+	if webhook := webhookSpec(ts); webhook != nil {
+		args = append(args, fmt.Sprintf("--webhook-bind-address=:%d", SpawnerWebhookPort))
+		envVars = append(envVars, corev1.EnvVar{
+			Name: webhookSecretKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: webhook.SecretRef.Name,
+					},
+					Key: webhookSecretKey,
+				},
+			},
+		})
+		ports = append(ports, corev1.ContainerPort{Name: "webhook", ContainerPort: SpawnerWebhookPort, Protocol: corev1.ProtocolTCP})
+	}
+
+	if jira := ts.Spec.When.Jira; jira != nil && jira.SecretRef != nil {
+		optional := true
+		envVars = append(envVars,
+			corev1.EnvVar{
+				Name: jiraTokenKey,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: jira.SecretRef.Name,
+						},
+						Key: jiraTokenKey,
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: jiraUserKey,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: jira.SecretRef.Name,
+						},
+						Key:      jiraUserKey,
+						Optional: &optional,
+					},
+				},
+			},
+		)
+	}
+
+	if hj := ts.Spec.When.HTTPJSON; hj != nil && hj.SecretRef != nil {
+		args = append(args, "--http-headers-dir="+httpHeadersDir)
+		volumes = append(volumes, corev1.Volume{
+			Name: "http-headers",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: hj.SecretRef.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "http-headers",
+			MountPath: httpHeadersDir,
+			ReadOnly:  true,
+		})
 	}
 
+	labels := spawnerLabels(ts)
+
 	spawnerContainer := corev1.Container{
 		Name:            "spawner",
 		Image:           b.SpawnerImage,
@@ -172,6 +275,7 @@ func (b *DeploymentBuilder) Build(ts *axonv1alpha1.TaskSpawner, workspace *axonv
 		Args:            args,
 		Env:             envVars,
 		VolumeMounts:    volumeMounts,
+		Ports:           ports,
 	}
 
 	return &appsv1.Deployment{
@@ -201,6 +305,48 @@ func (b *DeploymentBuilder) Build(ts *axonv1alpha1.TaskSpawner, workspace *axonv
 	}
 }
 
+// BuildWebhookService creates the Service that exposes the GitHub webhook
+// endpoint of the spawner for the given TaskSpawner.
+func (b *DeploymentBuilder) BuildWebhookService(ts *axonv1alpha1.TaskSpawner) *corev1.Service {
+	labels := spawnerLabels(ts)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ts.Name,
+			Namespace: ts.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "webhook",
+					Port:       80,
+					TargetPort: intstr.FromInt32(SpawnerWebhookPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// webhookSpec returns the GitHub webhook configuration of ts, or nil if the
+// webhook is not enabled.
+func webhookSpec(ts *axonv1alpha1.TaskSpawner) *axonv1alpha1.GitHubWebhook {
+	if ts.Spec.When.GitHubIssues == nil {
+		return nil
+	}
+	return ts.Spec.When.GitHubIssues.Webhook
+}
+
+func spawnerLabels(ts *axonv1alpha1.TaskSpawner) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "axon",
+		"app.kubernetes.io/component":  "spawner",
+		"app.kubernetes.io/managed-by": "axon-controller",
+		"axon.io/taskspawner":          ts.Name,
+	}
+}
+
 // httpsRepoRe matches HTTPS-style repository URLs: https://host/owner/repo
 var httpsRepoRe = regexp.MustCompile(`https?://([^/]+)/([^/]+)/([^/.]+)`)
 
@@ -236,6 +382,48 @@ func parseGitHubOwnerRepo(repoURL string) (owner, repo string) {
 	return owner, repo
 }
 
+// workspaceProvider returns the git hosting provider of the workspace,
+// detecting GitLab from the repo host when the provider is not set.
+func workspaceProvider(workspace *axonv1alpha1.WorkspaceSpec) string {
+	if workspace.Provider != "" {
+		return workspace.Provider
+	}
+	host, _ := parseGitLabRepo(workspace.Repo)
+	if host == "gitlab.com" || strings.HasPrefix(host, "gitlab.") {
+		return axonv1alpha1.WorkspaceProviderGitLab
+	}
+	return axonv1alpha1.WorkspaceProviderGitHub
+}
+
+// parseGitLabRepo extracts the host and project path from a GitLab
+// repository URL. Unlike GitHub, GitLab projects may be nested in
+// subgroups, so the project path is everything after the host.
+// Supports HTTPS (https://gitlab.com/group/sub/project.git) and SSH
+// (git@gitlab.com:group/sub/project.git).
+func parseGitLabRepo(repoURL string) (host, project string) {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+
+	if u, err := url.Parse(repoURL); err == nil && u.Host != "" {
+		return u.Host, strings.Trim(u.Path, "/")
+	}
+	if rest, ok := strings.CutPrefix(repoURL, "git@"); ok {
+		if host, path, ok := strings.Cut(rest, ":"); ok {
+			return host, strings.Trim(path, "/")
+		}
+	}
+	return "", repoURL
+}
+
+// gitLabAPIBaseURL returns the GitLab API base URL for the given host.
+// For gitlab.com (or empty host) it returns an empty string, as the spawner uses the default API endpoint.
+// For self-hosted GitLab it returns "https://<host>/api/v4".
+func gitLabAPIBaseURL(host string) string {
+	if host == "" || host == "gitlab.com" {
+		return ""
+	}
+	return (&url.URL{Scheme: "https", Host: host, Path: "/api/v4"}).String()
+}
+
 // gitHubAPIBaseURL returns the GitHub API base URL for the given host.
 // For github.com (or empty host) it returns an empty string, as the spawner uses the default API endpoint.
 // For GitHub Enterprise hosts it returns "https://<host>/api/v3".
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 
 	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
@@ -146,6 +148,70 @@ func TestParseGitHubRepo(t *testing.T) {
 	}
 }
 
+func TestParseGitLabRepo(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoURL     string
+		wantHost    string
+		wantProject string
+	}{
+		{
+			name:        "gitlab.com HTTPS",
+			repoURL:     "https://gitlab.com/group/project.git",
+			wantHost:    "gitlab.com",
+			wantProject: "group/project",
+		},
+		{
+			name:        "nested subgroups",
+			repoURL:     "https://gitlab.com/group/sub/project",
+			wantHost:    "gitlab.com",
+			wantProject: "group/sub/project",
+		},
+		{
+			name:        "SSH",
+			repoURL:     "git@gitlab.example.com:group/sub/project.git",
+			wantHost:    "gitlab.example.com",
+			wantProject: "group/sub/project",
+		},
+		{
+			name:        "self-hosted with port",
+			repoURL:     "https://gitlab.example.com:8443/group/project.git",
+			wantHost:    "gitlab.example.com:8443",
+			wantProject: "group/project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, project := parseGitLabRepo(tt.repoURL)
+			if host != tt.wantHost {
+				t.Errorf("host = %q, want %q", host, tt.wantHost)
+			}
+			if project != tt.wantProject {
+				t.Errorf("project = %q, want %q", project, tt.wantProject)
+			}
+		})
+	}
+}
+
+func TestWorkspaceProvider(t *testing.T) {
+	tests := []struct {
+		workspace axonv1alpha1.WorkspaceSpec
+		want      string
+	}{
+		{workspace: axonv1alpha1.WorkspaceSpec{Repo: "https://github.com/axon-core/axon.git"}, want: axonv1alpha1.WorkspaceProviderGitHub},
+		{workspace: axonv1alpha1.WorkspaceSpec{Repo: "https://gitlab.com/group/project.git"}, want: axonv1alpha1.WorkspaceProviderGitLab},
+		{workspace: axonv1alpha1.WorkspaceSpec{Repo: "git@gitlab.example.com:group/project.git"}, want: axonv1alpha1.WorkspaceProviderGitLab},
+		{workspace: axonv1alpha1.WorkspaceSpec{Repo: "https://git.example.com/group/project.git", Provider: axonv1alpha1.WorkspaceProviderGitLab}, want: axonv1alpha1.WorkspaceProviderGitLab},
+	}
+
+	for _, tt := range tests {
+		if got := workspaceProvider(&tt.workspace); got != tt.want {
+			t.Errorf("workspaceProvider(%q) = %q, want %q", tt.workspace.Repo, got, tt.want)
+		}
+	}
+}
+
 func TestGitHubAPIBaseURL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -371,3 +437,174 @@ func TestDeploymentBuilder_PAT(t *testing.T) {
 		t.Errorf("expected 0 volumes, got %d", len(deploy.Spec.Template.Spec.Volumes))
 	}
 }
+
+func TestDeploymentBuilder_Webhook(t *testing.T) {
+	builder := NewDeploymentBuilder()
+	ts := &axonv1alpha1.TaskSpawner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-spawner",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpawnerSpec{
+			When: axonv1alpha1.When{
+				GitHubIssues: &axonv1alpha1.GitHubIssues{
+					Webhook: &axonv1alpha1.GitHubWebhook{
+						SecretRef: axonv1alpha1.SecretReference{Name: "webhook-secret"},
+					},
+				},
+			},
+			TaskTemplate: axonv1alpha1.TaskTemplate{
+				Type:         "claude-code",
+				WorkspaceRef: &axonv1alpha1.WorkspaceReference{Name: "ws"},
+			},
+		},
+	}
+	workspace := &axonv1alpha1.WorkspaceSpec{
+		Repo: "https://github.com/axon-core/axon.git",
+	}
+
+	deploy := builder.Build(ts, workspace, false)
+	spawner := deploy.Spec.Template.Spec.Containers[0]
+
+	found := false
+	for _, arg := range spawner.Args {
+		if arg == "--webhook-bind-address=:8082" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected --webhook-bind-address=:8082 in args, got %v", spawner.Args)
+	}
+
+	if len(spawner.Env) != 1 || spawner.Env[0].Name != "GITHUB_WEBHOOK_SECRET" {
+		t.Fatalf("expected GITHUB_WEBHOOK_SECRET env var, got %v", spawner.Env)
+	}
+	ref := spawner.Env[0].ValueFrom.SecretKeyRef
+	if ref.Name != "webhook-secret" || ref.Key != "GITHUB_WEBHOOK_SECRET" {
+		t.Errorf("unexpected secret key ref: %+v", ref)
+	}
+
+	if len(spawner.Ports) != 2 || spawner.Ports[1].ContainerPort != SpawnerWebhookPort {
+		t.Errorf("expected webhook container port, got %v", spawner.Ports)
+	}
+
+	svc := builder.BuildWebhookService(ts)
+	if svc.Name != "test-spawner" || svc.Namespace != "default" {
+		t.Errorf("unexpected Service %s/%s", svc.Namespace, svc.Name)
+	}
+	if svc.Spec.Selector["axon.io/taskspawner"] != "test-spawner" {
+		t.Errorf("unexpected Service selector %v", svc.Spec.Selector)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].TargetPort.IntValue() != SpawnerWebhookPort {
+		t.Errorf("unexpected Service ports %v", svc.Spec.Ports)
+	}
+}
+
+func TestDeploymentBuilder_GitLab(t *testing.T) {
+	builder := NewDeploymentBuilder()
+	ts := &axonv1alpha1.TaskSpawner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-spawner",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpawnerSpec{
+			When: axonv1alpha1.When{
+				GitLabIssues: &axonv1alpha1.GitLabIssues{},
+			},
+		},
+	}
+	workspace := &axonv1alpha1.WorkspaceSpec{
+		Repo:      "https://gitlab.example.com/group/sub/project.git",
+		SecretRef: &axonv1alpha1.SecretReference{Name: "gitlab-creds"},
+	}
+
+	dep := builder.Build(ts, workspace, false)
+	container := dep.Spec.Template.Spec.Containers[0]
+
+	wantArgs := []string{
+		"--taskspawner-name=test-spawner",
+		"--taskspawner-namespace=default",
+		"--gitlab-project=group/sub/project",
+		"--gitlab-api-base-url=https://gitlab.example.com/api/v4",
+	}
+	if !reflect.DeepEqual(container.Args, wantArgs) {
+		t.Errorf("Args = %v, want %v", container.Args, wantArgs)
+	}
+
+	if len(container.Env) != 1 {
+		t.Fatalf("Expected 1 env var, got %d", len(container.Env))
+	}
+	env := container.Env[0]
+	if env.Name != "GITLAB_TOKEN" || env.ValueFrom.SecretKeyRef.Name != "gitlab-creds" || env.ValueFrom.SecretKeyRef.Key != "GITLAB_TOKEN" {
+		t.Errorf("Unexpected env var %+v", env)
+	}
+}
+
+func TestDeploymentBuilder_Jira(t *testing.T) {
+	builder := NewDeploymentBuilder()
+	ts := &axonv1alpha1.TaskSpawner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-spawner",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpawnerSpec{
+			When: axonv1alpha1.When{
+				Jira: &axonv1alpha1.Jira{
+					BaseURL:   "https://example.atlassian.net",
+					JQL:       "project = PROJ",
+					SecretRef: &axonv1alpha1.SecretReference{Name: "jira-creds"},
+				},
+			},
+		},
+	}
+
+	dep := builder.Build(ts, nil, false)
+	env := dep.Spec.Template.Spec.Containers[0].Env
+
+	if len(env) != 2 {
+		t.Fatalf("Expected 2 env vars, got %d", len(env))
+	}
+	token, user := env[0], env[1]
+	if token.Name != "JIRA_TOKEN" || token.ValueFrom.SecretKeyRef.Name != "jira-creds" || token.ValueFrom.SecretKeyRef.Key != "JIRA_TOKEN" {
+		t.Errorf("Unexpected token env var %+v", token)
+	}
+	if user.Name != "JIRA_USER" || user.ValueFrom.SecretKeyRef.Key != "JIRA_USER" {
+		t.Errorf("Unexpected user env var %+v", user)
+	}
+	if opt := user.ValueFrom.SecretKeyRef.Optional; opt == nil || !*opt {
+		t.Error("Expected JIRA_USER to be optional")
+	}
+}
+
+func TestDeploymentBuilder_HTTPJSON(t *testing.T) {
+	builder := NewDeploymentBuilder()
+	ts := &axonv1alpha1.TaskSpawner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-spawner",
+			Namespace: "default",
+		},
+		Spec: axonv1alpha1.TaskSpawnerSpec{
+			When: axonv1alpha1.When{
+				HTTPJSON: &axonv1alpha1.HTTPJSON{
+					URL:       "https://tickets.example.com/api/tickets",
+					SecretRef: &axonv1alpha1.SecretReference{Name: "ticket-headers"},
+					Fields:    axonv1alpha1.HTTPJSONFields{ID: ".id"},
+				},
+			},
+		},
+	}
+
+	dep := builder.Build(ts, nil, false)
+	spec := dep.Spec.Template.Spec
+	container := spec.Containers[0]
+
+	if !slices.Contains(container.Args, "--http-headers-dir=/etc/axon/http-headers") {
+		t.Errorf("Expected --http-headers-dir arg, got %v", container.Args)
+	}
+	if len(spec.Volumes) != 1 || spec.Volumes[0].Secret == nil || spec.Volumes[0].Secret.SecretName != "ticket-headers" {
+		t.Fatalf("Expected a volume for the headers Secret, got %+v", spec.Volumes)
+	}
+	if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != "/etc/axon/http-headers" || !container.VolumeMounts[0].ReadOnly {
+		t.Errorf("Expected the headers Secret to be mounted read-only, got %+v", container.VolumeMounts)
+	}
+}
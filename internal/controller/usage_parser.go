@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+// Usage holds the token usage and cost reported by an agent run.
+type Usage struct {
+	// CostUSD is the cost in US dollars. It is only meaningful when
+	// HasCost is true.
+	CostUSD      float64
+	HasCost      bool
+	NumTurns     int32
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// claudeResultEvent is the subset of the claude-code stream-json "result"
+// event that carries usage information.
+type claudeResultEvent struct {
+	Type         string  `json:"type"`
+	NumTurns     int32   `json:"num_turns"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	Usage        *struct {
+		InputTokens              int64 `json:"input_tokens"`
+		CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		OutputTokens             int64 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// codexTurnEvent is the subset of the codex exec --json "turn.completed"
+// event that carries usage information.
+type codexTurnEvent struct {
+	Type  string `json:"type"`
+	Usage *struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// geminiResultEvent is the subset of the gemini stream-json "result" event
+// that carries usage information.
+type geminiResultEvent struct {
+	Type  string `json:"type"`
+	Stats *struct {
+		TotalInputTokens  int64 `json:"totalInputTokens"`
+		TotalOutputTokens int64 `json:"totalOutputTokens"`
+	} `json:"stats"`
+}
+
+// ParseUsage extracts the token usage and cost from the NDJSON log output
+// of the given agent type. It returns nil if no usage information is found.
+func ParseUsage(agentType, logData string) *Usage {
+	var usage *Usage
+	for _, line := range strings.Split(logData, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		switch agentType {
+		case AgentTypeClaudeCode:
+			var event claudeResultEvent
+			if err := json.Unmarshal([]byte(line), &event); err != nil || event.Type != "result" {
+				continue
+			}
+			// The result event summarizes the whole session
+			usage = &Usage{
+				CostUSD:  event.TotalCostUSD,
+				HasCost:  true,
+				NumTurns: event.NumTurns,
+			}
+			if u := event.Usage; u != nil {
+				usage.InputTokens = u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+				usage.OutputTokens = u.OutputTokens
+			}
+		case AgentTypeCodex:
+			var event codexTurnEvent
+			if err := json.Unmarshal([]byte(line), &event); err != nil || event.Type != "turn.completed" {
+				continue
+			}
+			// Codex reports usage per turn
+			if usage == nil {
+				usage = &Usage{}
+			}
+			usage.NumTurns++
+			if u := event.Usage; u != nil {
+				usage.InputTokens += u.InputTokens
+				usage.OutputTokens += u.OutputTokens
+			}
+		case AgentTypeGemini:
+			var event geminiResultEvent
+			if err := json.Unmarshal([]byte(line), &event); err != nil || event.Type != "result" || event.Stats == nil {
+				continue
+			}
+			usage = &Usage{
+				InputTokens:  event.Stats.TotalInputTokens,
+				OutputTokens: event.Stats.TotalOutputTokens,
+			}
+		}
+	}
+	return usage
+}
+
+// addUsage adds usage to the totals recorded in status.
+func addUsage(status *axonv1alpha1.TaskStatus, usage *Usage) {
+	if usage == nil {
+		return
+	}
+	if usage.HasCost {
+		total := usage.CostUSD
+		if status.CostUSD != "" {
+			if prev, err := strconv.ParseFloat(status.CostUSD, 64); err == nil {
+				total += prev
+			}
+		}
+		// Round to a millionth of a dollar to avoid float noise in the total
+		status.CostUSD = strconv.FormatFloat(math.Round(total*1e6)/1e6, 'f', -1, 64)
+	}
+	status.NumTurns += usage.NumTurns
+	status.InputTokens += usage.InputTokens
+	status.OutputTokens += usage.OutputTokens
+}
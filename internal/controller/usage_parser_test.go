@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		agentType string
+		logData   string
+		expected  *Usage
+	}{
+		{
+			name:      "no usage",
+			agentType: AgentTypeClaudeCode,
+			logData:   "some random log output\n---AXON_OUTPUTS_START---\nbranch: main\n---AXON_OUTPUTS_END---\n",
+			expected:  nil,
+		},
+		{
+			name:      "claude-code result event",
+			agentType: AgentTypeClaudeCode,
+			logData: `{"type":"system","subtype":"init","model":"claude-sonnet-4-20250514"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Done"}]}}
+{"type":"result","subtype":"success","num_turns":7,"total_cost_usd":0.1234,"usage":{"input_tokens":100,"cache_creation_input_tokens":2000,"cache_read_input_tokens":30000,"output_tokens":450}}
+---AXON_OUTPUTS_START---
+branch: axon-task-123
+---AXON_OUTPUTS_END---
+`,
+			expected: &Usage{
+				CostUSD:      0.1234,
+				HasCost:      true,
+				NumTurns:     7,
+				InputTokens:  32100,
+				OutputTokens: 450,
+			},
+		},
+		{
+			name:      "codex turn.completed events are summed",
+			agentType: AgentTypeCodex,
+			logData: `{"type":"thread.started","thread_id":"abc"}
+{"type":"turn.started"}
+{"type":"turn.completed","usage":{"input_tokens":1000,"cached_input_tokens":200,"output_tokens":50}}
+{"type":"turn.started"}
+{"type":"turn.completed","usage":{"input_tokens":3000,"cached_input_tokens":500,"output_tokens":70}}
+`,
+			expected: &Usage{
+				NumTurns:     2,
+				InputTokens:  4000,
+				OutputTokens: 120,
+			},
+		},
+		{
+			name:      "gemini result event",
+			agentType: AgentTypeGemini,
+			logData: `{"type":"init","model":"gemini-2.5-pro"}
+{"type":"message","role":"assistant","content":"Done"}
+{"type":"result","status":"success","stats":{"totalInputTokens":5000,"totalOutputTokens":300,"totalCalls":4}}
+`,
+			expected: &Usage{
+				InputTokens:  5000,
+				OutputTokens: 300,
+			},
+		},
+		{
+			name:      "events of another agent are ignored",
+			agentType: AgentTypeGemini,
+			logData:   `{"type":"turn.completed","usage":{"input_tokens":1000,"output_tokens":50}}` + "\n",
+			expected:  nil,
+		},
+		{
+			name:      "malformed JSON is ignored",
+			agentType: AgentTypeClaudeCode,
+			logData:   "{\"type\":\"result\",\n",
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseUsage(tt.agentType, tt.logData)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseUsage() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddUsage(t *testing.T) {
+	status := &axonv1alpha1.TaskStatus{}
+
+	addUsage(status, nil)
+	if status.CostUSD != "" || status.NumTurns != 0 {
+		t.Fatalf("addUsage(nil) changed status: %+v", status)
+	}
+
+	addUsage(status, &Usage{CostUSD: 0.1, HasCost: true, NumTurns: 3, InputTokens: 100, OutputTokens: 10})
+	addUsage(status, &Usage{CostUSD: 0.2, HasCost: true, NumTurns: 2, InputTokens: 50, OutputTokens: 5})
+
+	if status.CostUSD != "0.3" {
+		t.Errorf("CostUSD = %q, want %q", status.CostUSD, "0.3")
+	}
+	if status.NumTurns != 5 {
+		t.Errorf("NumTurns = %d, want 5", status.NumTurns)
+	}
+	if status.InputTokens != 150 {
+		t.Errorf("InputTokens = %d, want 150", status.InputTokens)
+	}
+	if status.OutputTokens != 15 {
+		t.Errorf("OutputTokens = %d, want 15", status.OutputTokens)
+	}
+
+	addUsage(status, &Usage{InputTokens: 1})
+	if status.CostUSD != "0.3" {
+		t.Errorf("CostUSD changed by usage without cost: %q", status.CostUSD)
+	}
+}
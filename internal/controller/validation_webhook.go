@@ -0,0 +1,476 @@
+package controller
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/source"
+)
+
+var (
+	agentTypes      = []string{AgentTypeClaudeCode, AgentTypeCodex, AgentTypeGemini}
+	credentialTypes = []string{string(axonv1alpha1.CredentialTypeAPIKey), string(axonv1alpha1.CredentialTypeOAuth)}
+	gitlabTypes     = []string{"issues", "merge_requests"}
+)
+
+// SetupWebhooksWithManager registers the validating admission webhooks for
+// Task, TaskSpawner, Workspace and AgentConfig with the Manager.
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr, &axonv1alpha1.Task{}).
+		WithValidator(&TaskValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, &axonv1alpha1.TaskSpawner{}).
+		WithValidator(&TaskSpawnerValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, &axonv1alpha1.Workspace{}).
+		WithValidator(&WorkspaceValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr, &axonv1alpha1.AgentConfig{}).
+		WithValidator(&AgentConfigValidator{}).
+		Complete()
+}
+
+// TaskValidator validates Tasks on admission.
+type TaskValidator struct{}
+
+var _ admission.Validator[*axonv1alpha1.Task] = &TaskValidator{}
+
+// ValidateCreate validates a new Task.
+func (v *TaskValidator) ValidateCreate(_ context.Context, task *axonv1alpha1.Task) (admission.Warnings, error) {
+	return nil, invalidError("Task", task.Name, validateTaskSpec(task.Name, &task.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates an updated Task. Updates that do not change the
+// spec are always allowed so that Tasks created before the webhook was
+// installed can still be finalized and deleted.
+func (v *TaskValidator) ValidateUpdate(_ context.Context, oldTask, task *axonv1alpha1.Task) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldTask.Spec, task.Spec) {
+		return nil, nil
+	}
+	return nil, invalidError("Task", task.Name, validateTaskSpec(task.Name, &task.Spec, field.NewPath("spec")))
+}
+
+// ValidateDelete allows every Task to be deleted.
+func (v *TaskValidator) ValidateDelete(_ context.Context, _ *axonv1alpha1.Task) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// TaskSpawnerValidator validates TaskSpawners on admission.
+type TaskSpawnerValidator struct{}
+
+var _ admission.Validator[*axonv1alpha1.TaskSpawner] = &TaskSpawnerValidator{}
+
+// ValidateCreate validates a new TaskSpawner.
+func (v *TaskSpawnerValidator) ValidateCreate(_ context.Context, ts *axonv1alpha1.TaskSpawner) (admission.Warnings, error) {
+	return nil, invalidError("TaskSpawner", ts.Name, validateTaskSpawnerSpec(&ts.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates an updated TaskSpawner.
+func (v *TaskSpawnerValidator) ValidateUpdate(_ context.Context, oldTS, ts *axonv1alpha1.TaskSpawner) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldTS.Spec, ts.Spec) {
+		return nil, nil
+	}
+	return nil, invalidError("TaskSpawner", ts.Name, validateTaskSpawnerSpec(&ts.Spec, field.NewPath("spec")))
+}
+
+// ValidateDelete allows every TaskSpawner to be deleted.
+func (v *TaskSpawnerValidator) ValidateDelete(_ context.Context, _ *axonv1alpha1.TaskSpawner) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// WorkspaceValidator validates Workspaces on admission.
+type WorkspaceValidator struct{}
+
+var _ admission.Validator[*axonv1alpha1.Workspace] = &WorkspaceValidator{}
+
+// ValidateCreate validates a new Workspace.
+func (v *WorkspaceValidator) ValidateCreate(_ context.Context, ws *axonv1alpha1.Workspace) (admission.Warnings, error) {
+	return nil, invalidError("Workspace", ws.Name, validateWorkspaceSpec(&ws.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates an updated Workspace.
+func (v *WorkspaceValidator) ValidateUpdate(_ context.Context, oldWS, ws *axonv1alpha1.Workspace) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldWS.Spec, ws.Spec) {
+		return nil, nil
+	}
+	return nil, invalidError("Workspace", ws.Name, validateWorkspaceSpec(&ws.Spec, field.NewPath("spec")))
+}
+
+// ValidateDelete allows every Workspace to be deleted.
+func (v *WorkspaceValidator) ValidateDelete(_ context.Context, _ *axonv1alpha1.Workspace) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// AgentConfigValidator validates AgentConfigs on admission.
+type AgentConfigValidator struct{}
+
+var _ admission.Validator[*axonv1alpha1.AgentConfig] = &AgentConfigValidator{}
+
+// ValidateCreate validates a new AgentConfig.
+func (v *AgentConfigValidator) ValidateCreate(_ context.Context, ac *axonv1alpha1.AgentConfig) (admission.Warnings, error) {
+	return nil, invalidError("AgentConfig", ac.Name, validateAgentConfigSpec(&ac.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate validates an updated AgentConfig.
+func (v *AgentConfigValidator) ValidateUpdate(_ context.Context, oldAC, ac *axonv1alpha1.AgentConfig) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldAC.Spec, ac.Spec) {
+		return nil, nil
+	}
+	return nil, invalidError("AgentConfig", ac.Name, validateAgentConfigSpec(&ac.Spec, field.NewPath("spec")))
+}
+
+// ValidateDelete allows every AgentConfig to be deleted.
+func (v *AgentConfigValidator) ValidateDelete(_ context.Context, _ *axonv1alpha1.AgentConfig) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// invalidError returns an Invalid API error for the given kind and name, or
+// nil if errs is empty.
+func invalidError(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: axonv1alpha1.GroupVersion.Group, Kind: kind}, name, errs)
+}
+
+func validateTaskSpec(name string, spec *axonv1alpha1.TaskSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateAgentType(spec.Type, fldPath.Child("type"))...)
+	errs = append(errs, validateCredentials(&spec.Credentials, fldPath.Child("credentials"))...)
+
+	if strings.TrimSpace(spec.Prompt) == "" {
+		errs = append(errs, field.Required(fldPath.Child("prompt"), "prompt must not be empty"))
+	}
+	if spec.WorkspaceRef != nil && spec.WorkspaceRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("workspaceRef", "name"), ""))
+	}
+	if spec.AgentConfigRef != nil && spec.AgentConfigRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("agentConfigRef", "name"), ""))
+	}
+
+	seen := make(map[string]bool, len(spec.DependsOn))
+	for i, dep := range spec.DependsOn {
+		depPath := fldPath.Child("dependsOn").Index(i)
+		switch {
+		case dep == "":
+			errs = append(errs, field.Required(depPath, "dependency name must not be empty"))
+		case dep == name:
+			errs = append(errs, field.Invalid(depPath, dep, "a Task cannot depend on itself"))
+		case seen[dep]:
+			errs = append(errs, field.Duplicate(depPath, dep))
+		}
+		seen[dep] = true
+	}
+	if len(spec.DependsOn) > 0 {
+		if _, err := parseDependencyPrompt(spec.Prompt); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("prompt"), field.OmitValueType{}, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// validateHTTPJSON checks the URL and JSONPath expressions of an httpJSON
+// source.
+func validateHTTPJSON(hj *axonv1alpha1.HTTPJSON, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if u, err := url.Parse(hj.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(fldPath.Child("url"), hj.URL, "must be an http or https URL"))
+	}
+	if hj.SecretRef != nil && hj.SecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("secretRef", "name"), ""))
+	}
+	if strings.TrimSpace(hj.Fields.ID) == "" {
+		errs = append(errs, field.Required(fldPath.Child("fields", "id"), ""))
+	}
+	paths := []struct {
+		path    string
+		fldPath *field.Path
+	}{
+		{hj.ItemsPath, fldPath.Child("itemsPath")},
+		{hj.NextPath, fldPath.Child("nextPath")},
+		{hj.Fields.ID, fldPath.Child("fields", "id")},
+		{hj.Fields.Title, fldPath.Child("fields", "title")},
+		{hj.Fields.Body, fldPath.Child("fields", "body")},
+		{hj.Fields.URL, fldPath.Child("fields", "url")},
+		{hj.Fields.Labels, fldPath.Child("fields", "labels")},
+	}
+	for name, path := range hj.Extra {
+		paths = append(paths, struct {
+			path    string
+			fldPath *field.Path
+		}{path, fldPath.Child("extra").Key(name)})
+	}
+	for _, p := range paths {
+		if p.path == "" {
+			continue
+		}
+		if _, err := source.ParseJSONPath(p.path); err != nil {
+			errs = append(errs, field.Invalid(p.fldPath, p.path, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validateKubernetesEvents checks the kind, label selector and predicate of
+// a kubernetesEvents source.
+func validateKubernetesEvents(ke *axonv1alpha1.KubernetesEvents, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if _, err := schema.ParseGroupVersion(ke.APIVersion); err != nil || ke.APIVersion == "" {
+		errs = append(errs, field.Invalid(fldPath.Child("apiVersion"), ke.APIVersion, "must be a group and version such as v1 or apps/v1"))
+	}
+	if ke.Kind == "" {
+		errs = append(errs, field.Required(fldPath.Child("kind"), ""))
+	}
+	if ke.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(ke.LabelSelector); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("labelSelector"), field.OmitValueType{}, err.Error()))
+		}
+	}
+	if p := ke.Predicate; p != nil {
+		pathPath := fldPath.Child("predicate", "path")
+		if p.Path == "" {
+			errs = append(errs, field.Required(pathPath, ""))
+		} else if _, err := source.ParseJSONPath(p.Path); err != nil {
+			errs = append(errs, field.Invalid(pathPath, p.Path, err.Error()))
+		}
+	}
+	return errs
+}
+
+// whenSourcesMessage describes the sources of a TaskSpawner, exactly one of
+// which must be set.
+const whenSourcesMessage = "exactly one of githubIssues, githubPullRequests, gitlabIssues, jira, cron, httpJSON or kubernetesEvents must be set"
+
+func validateTaskSpawnerSpec(spec *axonv1alpha1.TaskSpawnerSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	whenPath := fldPath.Child("when")
+	var sources []string
+	if spec.When.GitHubIssues != nil {
+		sources = append(sources, "githubIssues")
+		if wh := spec.When.GitHubIssues.Webhook; wh != nil && wh.SecretRef.Name == "" {
+			errs = append(errs, field.Required(whenPath.Child("githubIssues", "webhook", "secretRef", "name"), ""))
+		}
+		errs = append(errs, validateGitHubRepos(spec.When.GitHubIssues.Repos, spec.When.GitHubIssues.Org, whenPath.Child("githubIssues"))...)
+	}
+	if spec.When.GitHubPullRequests != nil {
+		sources = append(sources, "githubPullRequests")
+		errs = append(errs, validateGitHubRepos(spec.When.GitHubPullRequests.Repos, spec.When.GitHubPullRequests.Org, whenPath.Child("githubPullRequests"))...)
+	}
+	if spec.When.GitLabIssues != nil {
+		sources = append(sources, "gitlabIssues")
+		for i, t := range spec.When.GitLabIssues.Types {
+			if !slices.Contains(gitlabTypes, t) {
+				errs = append(errs, field.NotSupported(whenPath.Child("gitlabIssues", "types").Index(i), t, gitlabTypes))
+			}
+		}
+	}
+	if jira := spec.When.Jira; jira != nil {
+		sources = append(sources, "jira")
+		jiraPath := whenPath.Child("jira")
+		if u, err := url.Parse(jira.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(jiraPath.Child("baseURL"), jira.BaseURL, "must be an http or https URL"))
+		}
+		if strings.TrimSpace(jira.JQL) == "" {
+			errs = append(errs, field.Required(jiraPath.Child("jql"), ""))
+		}
+		if jira.SecretRef != nil && jira.SecretRef.Name == "" {
+			errs = append(errs, field.Required(jiraPath.Child("secretRef", "name"), ""))
+		}
+	}
+	if spec.When.Cron != nil {
+		sources = append(sources, "cron")
+		if _, err := source.ParseSchedule(spec.When.Cron.Schedule); err != nil {
+			errs = append(errs, field.Invalid(whenPath.Child("cron", "schedule"), spec.When.Cron.Schedule, err.Error()))
+		}
+		if tz := spec.When.Cron.TimeZone; tz != "" {
+			if _, err := source.LoadTimeZone(tz); err != nil {
+				errs = append(errs, field.Invalid(whenPath.Child("cron", "timeZone"), tz, "unknown time zone"))
+			}
+			if strings.Contains(spec.When.Cron.Schedule, "TZ=") {
+				errs = append(errs, field.Invalid(whenPath.Child("cron", "schedule"), spec.When.Cron.Schedule, "CRON_TZ or TZ is not allowed when timeZone is set"))
+			}
+		}
+	}
+	if hj := spec.When.HTTPJSON; hj != nil {
+		sources = append(sources, "httpJSON")
+		errs = append(errs, validateHTTPJSON(hj, whenPath.Child("httpJSON"))...)
+	}
+	if ke := spec.When.KubernetesEvents; ke != nil {
+		sources = append(sources, "kubernetesEvents")
+		errs = append(errs, validateKubernetesEvents(ke, whenPath.Child("kubernetesEvents"))...)
+	}
+	switch len(sources) {
+	case 0:
+		errs = append(errs, field.Required(whenPath, whenSourcesMessage))
+	case 1:
+	default:
+		errs = append(errs, field.Forbidden(whenPath, whenSourcesMessage+", got "+strings.Join(sources, " and ")))
+	}
+
+	if spec.Retrigger != nil && spec.When.GitHubIssues == nil && spec.When.GitHubPullRequests == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("retrigger"), "retrigger is only supported with the githubIssues and githubPullRequests sources"))
+	}
+	if spec.Reporting != nil && spec.When.GitHubIssues == nil && spec.When.GitHubPullRequests == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("reporting"), "reporting is only supported with the githubIssues and githubPullRequests sources"))
+	}
+	// Only issues carry a reaction count; the pulls API has no reactions
+	// rollup.
+	if spec.Priority != nil && spec.Priority.Order == axonv1alpha1.PriorityOrderReactions && spec.When.GitHubIssues == nil && spec.When.GitLabIssues == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("priority", "order"), "reactions is only supported with the githubIssues and gitlabIssues sources"))
+	}
+
+	if _, err := source.ParsePollInterval(spec.PollInterval); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("pollInterval"), spec.PollInterval, err.Error()))
+	}
+
+	tmplPath := fldPath.Child("taskTemplate")
+	errs = append(errs, validateAgentType(spec.TaskTemplate.Type, tmplPath.Child("type"))...)
+	errs = append(errs, validateCredentials(&spec.TaskTemplate.Credentials, tmplPath.Child("credentials"))...)
+	if spec.TaskTemplate.WorkspaceRef == nil {
+		if spec.When.GitHubIssues != nil {
+			errs = append(errs, field.Required(tmplPath.Child("workspaceRef"), "workspaceRef is required when using githubIssues source"))
+		}
+		if spec.When.GitHubPullRequests != nil {
+			errs = append(errs, field.Required(tmplPath.Child("workspaceRef"), "workspaceRef is required when using githubPullRequests source"))
+		}
+		if spec.When.GitLabIssues != nil {
+			errs = append(errs, field.Required(tmplPath.Child("workspaceRef"), "workspaceRef is required when using gitlabIssues source"))
+		}
+	} else if spec.TaskTemplate.WorkspaceRef.Name == "" {
+		errs = append(errs, field.Required(tmplPath.Child("workspaceRef", "name"), ""))
+	}
+	if spec.TaskTemplate.AgentConfigRef != nil && spec.TaskTemplate.AgentConfigRef.Name == "" {
+		errs = append(errs, field.Required(tmplPath.Child("agentConfigRef", "name"), ""))
+	}
+	if _, err := source.ParsePromptTemplate(spec.TaskTemplate.PromptTemplate); err != nil {
+		errs = append(errs, field.Invalid(tmplPath.Child("promptTemplate"), field.OmitValueType{}, err.Error()))
+	}
+
+	return errs
+}
+
+// validateGitHubRepos validates the repositories and organization of a
+// GitHub source.
+func validateGitHubRepos(repos []axonv1alpha1.GitHubRepository, org *axonv1alpha1.GitHubOrganization, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool, len(repos))
+	for i, repo := range repos {
+		repoPath := fldPath.Child("repos").Index(i)
+		owner, name, ok := strings.Cut(repo.Name, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			errs = append(errs, field.Invalid(repoPath.Child("name"), repo.Name, "must be owner/name"))
+		} else if key := strings.ToLower(repo.Name); seen[key] {
+			errs = append(errs, field.Duplicate(repoPath.Child("name"), repo.Name))
+		} else {
+			seen[key] = true
+		}
+		if repo.WorkspaceRef != nil && repo.WorkspaceRef.Name == "" {
+			errs = append(errs, field.Required(repoPath.Child("workspaceRef", "name"), ""))
+		}
+	}
+	if org != nil {
+		if org.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("org", "name"), ""))
+		}
+		if _, err := regexp.Compile(org.RepoPattern); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("org", "repoPattern"), org.RepoPattern, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateWorkspaceSpec(spec *axonv1alpha1.WorkspaceSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.SecretRef != nil && spec.SecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("secretRef", "name"), ""))
+	}
+
+	seen := make(map[string]bool, len(spec.Files))
+	for i, file := range spec.Files {
+		filePath := fldPath.Child("files").Index(i).Child("path")
+		cleanPath, err := sanitizeWorkspaceFilePath(file.Path)
+		if err != nil {
+			errs = append(errs, field.Invalid(filePath, file.Path, err.Error()))
+			continue
+		}
+		if seen[cleanPath] {
+			errs = append(errs, field.Duplicate(filePath, file.Path))
+		}
+		seen[cleanPath] = true
+	}
+
+	return errs
+}
+
+func validateAgentConfigSpec(spec *axonv1alpha1.AgentConfigSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	plugins := make(map[string]bool, len(spec.Plugins))
+	for i, plugin := range spec.Plugins {
+		pluginPath := fldPath.Child("plugins").Index(i)
+		errs = append(errs, validateComponentName(plugin.Name, "plugin", pluginPath.Child("name"), plugins)...)
+
+		skills := make(map[string]bool, len(plugin.Skills))
+		for j, skill := range plugin.Skills {
+			errs = append(errs, validateComponentName(skill.Name, "skill", pluginPath.Child("skills").Index(j).Child("name"), skills)...)
+		}
+		agents := make(map[string]bool, len(plugin.Agents))
+		for j, agent := range plugin.Agents {
+			errs = append(errs, validateComponentName(agent.Name, "agent", pluginPath.Child("agents").Index(j).Child("name"), agents)...)
+		}
+	}
+
+	return errs
+}
+
+// validateComponentName validates a plugin, skill, or agent name with
+// sanitizeComponentName and rejects names already recorded in seen.
+func validateComponentName(name, kind string, fldPath *field.Path, seen map[string]bool) field.ErrorList {
+	if err := sanitizeComponentName(name, kind); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, name, err.Error())}
+	}
+	if seen[name] {
+		return field.ErrorList{field.Duplicate(fldPath, name)}
+	}
+	seen[name] = true
+	return nil
+}
+
+func validateAgentType(agentType string, fldPath *field.Path) field.ErrorList {
+	if !slices.Contains(agentTypes, agentType) {
+		return field.ErrorList{field.NotSupported(fldPath, agentType, agentTypes)}
+	}
+	return nil
+}
+
+func validateCredentials(creds *axonv1alpha1.Credentials, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if !slices.Contains(credentialTypes, string(creds.Type)) {
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), creds.Type, credentialTypes))
+	}
+	if creds.SecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("secretRef", "name"), ""))
+	}
+	return errs
+}
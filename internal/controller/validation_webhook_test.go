@@ -0,0 +1,476 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func validTask() *axonv1alpha1.Task {
+	return &axonv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"},
+		Spec: axonv1alpha1.TaskSpec{
+			Type:   AgentTypeClaudeCode,
+			Prompt: "Fix the bug",
+			Credentials: axonv1alpha1.Credentials{
+				Type:      axonv1alpha1.CredentialTypeAPIKey,
+				SecretRef: axonv1alpha1.SecretReference{Name: "creds"},
+			},
+		},
+	}
+}
+
+func validTaskSpawner() *axonv1alpha1.TaskSpawner {
+	return &axonv1alpha1.TaskSpawner{
+		ObjectMeta: metav1.ObjectMeta{Name: "spawner", Namespace: "default"},
+		Spec: axonv1alpha1.TaskSpawnerSpec{
+			When: axonv1alpha1.When{
+				GitHubIssues: &axonv1alpha1.GitHubIssues{},
+			},
+			TaskTemplate: axonv1alpha1.TaskTemplate{
+				Type: AgentTypeCodex,
+				Credentials: axonv1alpha1.Credentials{
+					Type:      axonv1alpha1.CredentialTypeOAuth,
+					SecretRef: axonv1alpha1.SecretReference{Name: "creds"},
+				},
+				WorkspaceRef:   &axonv1alpha1.WorkspaceReference{Name: "ws"},
+				PromptTemplate: "Fix {{.Title}}",
+			},
+			PollInterval: "5m",
+		},
+	}
+}
+
+func TestTaskValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*axonv1alpha1.Task)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*axonv1alpha1.Task) {},
+		},
+		{
+			name:    "unknown type",
+			mutate:  func(task *axonv1alpha1.Task) { task.Spec.Type = "copilot" },
+			wantErr: `spec.type: Unsupported value: "copilot"`,
+		},
+		{
+			name:    "empty prompt",
+			mutate:  func(task *axonv1alpha1.Task) { task.Spec.Prompt = "  " },
+			wantErr: "spec.prompt: Required value",
+		},
+		{
+			name:    "missing secret name",
+			mutate:  func(task *axonv1alpha1.Task) { task.Spec.Credentials.SecretRef.Name = "" },
+			wantErr: "spec.credentials.secretRef.name: Required value",
+		},
+		{
+			name:    "depends on itself",
+			mutate:  func(task *axonv1alpha1.Task) { task.Spec.DependsOn = []string{"task"} },
+			wantErr: "spec.dependsOn[0]: Invalid value: \"task\": a Task cannot depend on itself",
+		},
+		{
+			name:    "duplicate dependency",
+			mutate:  func(task *axonv1alpha1.Task) { task.Spec.DependsOn = []string{"a", "a"} },
+			wantErr: `spec.dependsOn[1]: Duplicate value: "a"`,
+		},
+		{
+			name: "invalid dependency prompt",
+			mutate: func(task *axonv1alpha1.Task) {
+				task.Spec.DependsOn = []string{"a"}
+				task.Spec.Prompt = "Review {{ (index .Deps \"a\").Outputs"
+			},
+			wantErr: "spec.prompt: Invalid value: parsing prompt template",
+		},
+		{
+			name: "prompt is not a template without dependencies",
+			mutate: func(task *axonv1alpha1.Task) {
+				task.Spec.Prompt = "Explain the {{ syntax"
+			},
+		},
+	}
+
+	v := &TaskValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := validTask()
+			tt.mutate(task)
+			_, err := v.ValidateCreate(context.Background(), task)
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTaskValidatorUpdate(t *testing.T) {
+	v := &TaskValidator{}
+
+	oldTask := validTask()
+	oldTask.Spec.Type = "copilot"
+	newTask := oldTask.DeepCopy()
+	newTask.Finalizers = []string{"axon.io/finalizer"}
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, newTask); err != nil {
+		t.Errorf("Expected update without spec change to be allowed, got %v", err)
+	}
+
+	newTask.Spec.Cancel = true
+	_, err := v.ValidateUpdate(context.Background(), oldTask, newTask)
+	checkValidationError(t, err, `spec.type: Unsupported value: "copilot"`)
+}
+
+func TestTaskSpawnerValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*axonv1alpha1.TaskSpawner)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*axonv1alpha1.TaskSpawner) {},
+		},
+		{
+			name: "valid cron",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "0 9 * * 1"}}
+				ts.Spec.TaskTemplate.WorkspaceRef = nil
+			},
+		},
+		{
+			name:    "no source",
+			mutate:  func(ts *axonv1alpha1.TaskSpawner) { ts.Spec.When = axonv1alpha1.When{} },
+			wantErr: "spec.when: Required value: exactly one of githubIssues, githubPullRequests, gitlabIssues, jira, cron, httpJSON or kubernetesEvents must be set",
+		},
+		{
+			name: "both sources",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When.Cron = &axonv1alpha1.Cron{Schedule: "0 9 * * 1"}
+			},
+			wantErr: "spec.when: Forbidden: exactly one of githubIssues, githubPullRequests, gitlabIssues, jira, cron, httpJSON or kubernetesEvents must be set, got githubIssues and cron",
+		},
+		{
+			name: "invalid schedule",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "every monday"}}
+			},
+			wantErr: `spec.when.cron.schedule: Invalid value: "every monday": parsing cron schedule`,
+		},
+		{
+			name: "valid cron time zone",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "0 9 * * 1-5", TimeZone: "Asia/Seoul"}}
+			},
+		},
+		{
+			name: "unknown cron time zone",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "0 9 * * 1-5", TimeZone: "Asia/Atlantis"}}
+			},
+			wantErr: `spec.when.cron.timeZone: Invalid value: "Asia/Atlantis": unknown time zone`,
+		},
+		{
+			name: "time zone in schedule and timeZone",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "CRON_TZ=Asia/Tokyo 0 9 * * 1-5", TimeZone: "Asia/Seoul"}}
+			},
+			wantErr: "CRON_TZ or TZ is not allowed when timeZone is set",
+		},
+		{
+			name:    "invalid poll interval",
+			mutate:  func(ts *axonv1alpha1.TaskSpawner) { ts.Spec.PollInterval = "5 minutes" },
+			wantErr: `spec.pollInterval: Invalid value: "5 minutes"`,
+		},
+		{
+			name:    "invalid prompt template",
+			mutate:  func(ts *axonv1alpha1.TaskSpawner) { ts.Spec.TaskTemplate.PromptTemplate = "Fix {{.Title" },
+			wantErr: "spec.taskTemplate.promptTemplate: Invalid value: parsing prompt template",
+		},
+		{
+			name:    "missing workspace for githubIssues",
+			mutate:  func(ts *axonv1alpha1.TaskSpawner) { ts.Spec.TaskTemplate.WorkspaceRef = nil },
+			wantErr: "spec.taskTemplate.workspaceRef: Required value",
+		},
+		{
+			name: "githubIssues and gitlabIssues",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When.GitLabIssues = &axonv1alpha1.GitLabIssues{}
+			},
+			wantErr: "got githubIssues and gitlabIssues",
+		},
+		{
+			name: "repos and org",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When.GitHubIssues.Repos = []axonv1alpha1.GitHubRepository{
+					{Name: "axon-core/axon"},
+					{Name: "axon-core/docs", WorkspaceRef: &axonv1alpha1.WorkspaceReference{Name: "docs"}},
+				}
+				ts.Spec.When.GitHubIssues.Org = &axonv1alpha1.GitHubOrganization{Name: "axon-core", RepoPattern: "^service-"}
+			},
+		},
+		{
+			name: "invalid repo name",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When.GitHubIssues.Repos = []axonv1alpha1.GitHubRepository{{Name: "axon"}}
+			},
+			wantErr: `spec.when.githubIssues.repos[0].name: Invalid value: "axon": must be owner/name`,
+		},
+		{
+			name: "duplicate repo",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{GitHubPullRequests: &axonv1alpha1.GitHubPullRequests{
+					Repos: []axonv1alpha1.GitHubRepository{{Name: "axon-core/axon"}, {Name: "Axon-Core/Axon"}},
+				}}
+			},
+			wantErr: `spec.when.githubPullRequests.repos[1].name: Duplicate value: "Axon-Core/Axon"`,
+		},
+		{
+			name: "invalid org repo pattern",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When.GitHubIssues.Org = &axonv1alpha1.GitHubOrganization{Name: "axon-core", RepoPattern: "service-("}
+			},
+			wantErr: `spec.when.githubIssues.org.repoPattern: Invalid value: "service-("`,
+		},
+		{
+			name: "retrigger with cron",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Cron: &axonv1alpha1.Cron{Schedule: "0 9 * * *"}}
+				ts.Spec.Retrigger = &axonv1alpha1.RetriggerPolicy{}
+			},
+			wantErr: "spec.retrigger: Forbidden",
+		},
+		{
+			name: "reporting with jira",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Jira: &axonv1alpha1.Jira{BaseURL: "https://example.atlassian.net", JQL: "project = PROJ"}}
+				ts.Spec.Reporting = &axonv1alpha1.Reporting{DoneLabel: "axon/done"}
+			},
+			wantErr: "spec.reporting: Forbidden",
+		},
+		{
+			name: "invalid jira baseURL",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{Jira: &axonv1alpha1.Jira{BaseURL: "example.atlassian.net", JQL: "project = PROJ"}}
+			},
+			wantErr: `spec.when.jira.baseURL: Invalid value: "example.atlassian.net": must be an http or https URL`,
+		},
+		{
+			name: "valid httpJSON",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{HTTPJSON: &axonv1alpha1.HTTPJSON{
+					URL:       "https://tickets.example.com/api/tickets",
+					ItemsPath: ".data",
+					Fields:    axonv1alpha1.HTTPJSONFields{ID: ".id", Labels: ".tags[*].name"},
+					Extra:     map[string]string{"severity": "{.severity}"},
+				}}
+				ts.Spec.TaskTemplate.WorkspaceRef = nil
+			},
+		},
+		{
+			name: "invalid httpJSON path",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{HTTPJSON: &axonv1alpha1.HTTPJSON{
+					URL:    "https://tickets.example.com/api/tickets",
+					Fields: axonv1alpha1.HTTPJSONFields{ID: ".id"},
+					Extra:  map[string]string{"severity": ".severity[unclosed"},
+				}}
+			},
+			wantErr: `spec.when.httpJSON.extra[severity]: Invalid value: ".severity[unclosed"`,
+		},
+		{
+			name: "valid kubernetesEvents",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{KubernetesEvents: &axonv1alpha1.KubernetesEvents{
+					APIVersion:    "apps/v1",
+					Kind:          "Deployment",
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+					Predicate:     &axonv1alpha1.FieldPredicate{Path: `.status.conditions[?(@.type=="Progressing")].reason`, Values: []string{"ProgressDeadlineExceeded"}},
+				}}
+				ts.Spec.TaskTemplate.WorkspaceRef = nil
+			},
+		},
+		{
+			name: "invalid kubernetesEvents label selector",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{KubernetesEvents: &axonv1alpha1.KubernetesEvents{
+					APIVersion: "v1",
+					Kind:       "Pod",
+					LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: "Near"},
+					}},
+				}}
+			},
+			wantErr: "spec.when.kubernetesEvents.labelSelector: Invalid value",
+		},
+		{
+			name: "invalid kubernetesEvents apiVersion",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{KubernetesEvents: &axonv1alpha1.KubernetesEvents{APIVersion: "apps/v1/extra", Kind: "Deployment"}}
+			},
+			wantErr: `spec.when.kubernetesEvents.apiVersion: Invalid value: "apps/v1/extra"`,
+		},
+		{
+			name: "missing workspace for gitlabIssues",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{GitLabIssues: &axonv1alpha1.GitLabIssues{}}
+				ts.Spec.TaskTemplate.WorkspaceRef = nil
+			},
+			wantErr: "workspaceRef is required when using gitlabIssues source",
+		},
+		{
+			name: "valid gitlabIssues types",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{GitLabIssues: &axonv1alpha1.GitLabIssues{Types: []string{"issues", "merge_requests"}}}
+			},
+		},
+		{
+			name: "unknown gitlabIssues type",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{GitLabIssues: &axonv1alpha1.GitLabIssues{Types: []string{"issues", "mergerequests"}}}
+			},
+			wantErr: `spec.when.gitlabIssues.types[1]: Unsupported value: "mergerequests"`,
+		},
+		{
+			name: "reactions order for githubIssues",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.Priority = &axonv1alpha1.Priority{Order: axonv1alpha1.PriorityOrderReactions}
+			},
+		},
+		{
+			name: "reactions order for githubPullRequests",
+			mutate: func(ts *axonv1alpha1.TaskSpawner) {
+				ts.Spec.When = axonv1alpha1.When{GitHubPullRequests: &axonv1alpha1.GitHubPullRequests{}}
+				ts.Spec.Priority = &axonv1alpha1.Priority{Order: axonv1alpha1.PriorityOrderReactions}
+			},
+			wantErr: "spec.priority.order: Forbidden: reactions is only supported with the githubIssues and gitlabIssues sources",
+		},
+		{
+			name:    "unknown type",
+			mutate:  func(ts *axonv1alpha1.TaskSpawner) { ts.Spec.TaskTemplate.Type = "" },
+			wantErr: `spec.taskTemplate.type: Unsupported value: ""`,
+		},
+	}
+
+	v := &TaskSpawnerValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := validTaskSpawner()
+			tt.mutate(ts)
+			_, err := v.ValidateCreate(context.Background(), ts)
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWorkspaceValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []axonv1alpha1.WorkspaceFile
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			files: []axonv1alpha1.WorkspaceFile{{Path: "CLAUDE.md"}, {Path: ".claude/skills/review/SKILL.md"}},
+		},
+		{
+			name:    "absolute path",
+			files:   []axonv1alpha1.WorkspaceFile{{Path: "/etc/passwd"}},
+			wantErr: `spec.files[0].path: Invalid value: "/etc/passwd": absolute paths are not allowed`,
+		},
+		{
+			name:    "escapes repository",
+			files:   []axonv1alpha1.WorkspaceFile{{Path: "docs/../../secret"}},
+			wantErr: "path escapes repository root",
+		},
+		{
+			name:    "duplicate path",
+			files:   []axonv1alpha1.WorkspaceFile{{Path: "AGENTS.md"}, {Path: "./AGENTS.md"}},
+			wantErr: `spec.files[1].path: Duplicate value: "./AGENTS.md"`,
+		},
+	}
+
+	v := &WorkspaceValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &axonv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws", Namespace: "default"},
+				Spec: axonv1alpha1.WorkspaceSpec{
+					Repo:  "https://github.com/axon-core/axon.git",
+					Files: tt.files,
+				},
+			}
+			_, err := v.ValidateCreate(context.Background(), ws)
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAgentConfigValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugins []axonv1alpha1.PluginSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			plugins: []axonv1alpha1.PluginSpec{{
+				Name:   "team",
+				Skills: []axonv1alpha1.SkillDefinition{{Name: "review"}},
+				Agents: []axonv1alpha1.AgentDefinition{{Name: "tester"}},
+			}},
+		},
+		{
+			name:    "traversal in plugin name",
+			plugins: []axonv1alpha1.PluginSpec{{Name: ".."}},
+			wantErr: `spec.plugins[0].name: Invalid value: "..": plugin name ".." is a path traversal`,
+		},
+		{
+			name: "separator in skill name",
+			plugins: []axonv1alpha1.PluginSpec{{
+				Name:   "team",
+				Skills: []axonv1alpha1.SkillDefinition{{Name: "a/b"}},
+			}},
+			wantErr: "spec.plugins[0].skills[0].name",
+		},
+		{
+			name:    "duplicate plugin",
+			plugins: []axonv1alpha1.PluginSpec{{Name: "team"}, {Name: "team"}},
+			wantErr: `spec.plugins[1].name: Duplicate value: "team"`,
+		},
+	}
+
+	v := &AgentConfigValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ac := &axonv1alpha1.AgentConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+				Spec:       axonv1alpha1.AgentConfigSpec{Plugins: tt.plugins},
+			}
+			_, err := v.ValidateCreate(context.Background(), ac)
+			checkValidationError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func checkValidationError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("Expected error containing %q, got nil", wantErr)
+	}
+	if !apierrors.IsInvalid(err) {
+		t.Errorf("Expected an Invalid error, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("Expected error containing %q, got %q", wantErr, err.Error())
+	}
+}
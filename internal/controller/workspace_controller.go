@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+	"github.com/axon-core/axon/internal/githubapp"
+)
+
+const (
+	// workspaceSecretIndexField is the field index used to look up
+	// Workspaces by the name of the Secret they reference.
+	workspaceSecretIndexField = "spec.secretRef.name"
+)
+
+// WorkspaceReconciler reconciles a Workspace object. It validates the
+// Workspace and its Secret and reports the result as status conditions.
+type WorkspaceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=axon.io,resources=workspaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=axon.io,resources=workspaces/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile handles Workspace reconciliation.
+func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ws axonv1alpha1.Workspace
+	if err := r.Get(ctx, req.NamespacedName, &ws); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch Workspace")
+		return ctrl.Result{}, err
+	}
+
+	ready := metav1.Condition{Type: axonv1alpha1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Valid"}
+	if _, err := buildWorkspaceFileInjectionScript(ws.Spec.Files); err != nil {
+		ready = metav1.Condition{Type: axonv1alpha1.ConditionReady, Status: metav1.ConditionFalse, Reason: "InvalidFiles", Message: err.Error()}
+	}
+
+	var credentials *metav1.Condition
+	if ws.Spec.SecretRef != nil {
+		c, err := r.credentialsCondition(ctx, &ws)
+		if err != nil {
+			logger.Error(err, "Unable to fetch workspace secret", "secret", ws.Spec.SecretRef.Name)
+			return ctrl.Result{}, err
+		}
+		credentials = c
+		if c.Status != metav1.ConditionTrue && ready.Status == metav1.ConditionTrue {
+			ready = metav1.Condition{Type: axonv1alpha1.ConditionReady, Status: metav1.ConditionFalse, Reason: c.Reason, Message: c.Message}
+		}
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := r.Get(ctx, req.NamespacedName, &ws); getErr != nil {
+			return getErr
+		}
+		before := ws.Status.DeepCopy()
+		ws.Status.ObservedGeneration = ws.Generation
+		if credentials != nil {
+			setCondition(&ws.Status.Conditions, ws.Generation, credentials.Type, credentials.Status, credentials.Reason, credentials.Message)
+		}
+		setCondition(&ws.Status.Conditions, ws.Generation, ready.Type, ready.Status, ready.Reason, ready.Message)
+		if equality.Semantic.DeepEqual(before, &ws.Status) {
+			return nil
+		}
+		return r.Status().Update(ctx, &ws)
+	}); err != nil {
+		logger.Error(err, "Unable to update Workspace status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// credentialsCondition returns the CredentialsResolved condition for the
+// Secret referenced by ws. The Secret must contain either a GITHUB_TOKEN
+// key or GitHub App credentials, or a GITLAB_TOKEN key for GitLab
+// workspaces.
+func (r *WorkspaceReconciler) credentialsCondition(ctx context.Context, ws *axonv1alpha1.Workspace) (*metav1.Condition, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ws.Namespace, Name: ws.Spec.SecretRef.Name}, &secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return &metav1.Condition{
+			Type:    axonv1alpha1.ConditionCredentialsResolved,
+			Status:  metav1.ConditionFalse,
+			Reason:  "SecretNotFound",
+			Message: fmt.Sprintf("Secret %q not found", ws.Spec.SecretRef.Name),
+		}, nil
+	}
+
+	if workspaceProvider(&ws.Spec) == axonv1alpha1.WorkspaceProviderGitLab {
+		if _, ok := secret.Data["GITLAB_TOKEN"]; ok {
+			return &metav1.Condition{
+				Type:    axonv1alpha1.ConditionCredentialsResolved,
+				Status:  metav1.ConditionTrue,
+				Reason:  "Token",
+				Message: "Secret contains a GITLAB_TOKEN",
+			}, nil
+		}
+		return &metav1.Condition{
+			Type:    axonv1alpha1.ConditionCredentialsResolved,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidSecret",
+			Message: fmt.Sprintf("Secret %q does not contain GITLAB_TOKEN", ws.Spec.SecretRef.Name),
+		}, nil
+	}
+
+	if githubapp.IsGitHubApp(secret.Data) {
+		return &metav1.Condition{
+			Type:    axonv1alpha1.ConditionCredentialsResolved,
+			Status:  metav1.ConditionTrue,
+			Reason:  "GitHubApp",
+			Message: "Secret contains GitHub App credentials",
+		}, nil
+	}
+	if _, ok := secret.Data["GITHUB_TOKEN"]; ok {
+		return &metav1.Condition{
+			Type:    axonv1alpha1.ConditionCredentialsResolved,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Token",
+			Message: "Secret contains a GITHUB_TOKEN",
+		}, nil
+	}
+	return &metav1.Condition{
+		Type:    axonv1alpha1.ConditionCredentialsResolved,
+		Status:  metav1.ConditionFalse,
+		Reason:  "InvalidSecret",
+		Message: fmt.Sprintf("Secret %q contains neither GITHUB_TOKEN nor GitHub App credentials", ws.Spec.SecretRef.Name),
+	}, nil
+}
+
+// findWorkspacesForSecret returns reconcile requests for the Workspaces
+// that reference the given Secret.
+func (r *WorkspaceReconciler) findWorkspacesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var workspaces axonv1alpha1.WorkspaceList
+	if err := r.List(ctx, &workspaces,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{workspaceSecretIndexField: obj.GetName()},
+	); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(workspaces.Items))
+	for _, ws := range workspaces.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: ws.Namespace, Name: ws.Name},
+		})
+	}
+	return requests
+}
+
+// indexWorkspaceSecret is the field indexer function for
+// workspaceSecretIndexField.
+func indexWorkspaceSecret(obj client.Object) []string {
+	ws, ok := obj.(*axonv1alpha1.Workspace)
+	if !ok || ws.Spec.SecretRef == nil {
+		return nil
+	}
+	return []string{ws.Spec.SecretRef.Name}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &axonv1alpha1.Workspace{}, workspaceSecretIndexField, indexWorkspaceSecret); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&axonv1alpha1.Workspace{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findWorkspacesForSecret)).
+		Complete(r)
+}
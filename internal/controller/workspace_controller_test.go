@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	axonv1alpha1 "github.com/axon-core/axon/api/v1alpha1"
+)
+
+func TestWorkspaceReconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            axonv1alpha1.WorkspaceSpec
+		secret          *corev1.Secret
+		wantReady       metav1.ConditionStatus
+		wantReason      string
+		wantCredentials metav1.ConditionStatus
+	}{
+		{
+			name:       "no secret",
+			spec:       axonv1alpha1.WorkspaceSpec{Repo: "https://github.com/org/repo.git"},
+			wantReady:  metav1.ConditionTrue,
+			wantReason: "Valid",
+		},
+		{
+			name: "secret with token",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:      "https://github.com/org/repo.git",
+				SecretRef: &axonv1alpha1.SecretReference{Name: "gh"},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gh", Namespace: "default"},
+				Data:       map[string][]byte{"GITHUB_TOKEN": []byte("token")},
+			},
+			wantReady:       metav1.ConditionTrue,
+			wantReason:      "Valid",
+			wantCredentials: metav1.ConditionTrue,
+		},
+		{
+			name: "missing secret",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:      "https://github.com/org/repo.git",
+				SecretRef: &axonv1alpha1.SecretReference{Name: "gh"},
+			},
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      "SecretNotFound",
+			wantCredentials: metav1.ConditionFalse,
+		},
+		{
+			name: "secret without token",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:      "https://github.com/org/repo.git",
+				SecretRef: &axonv1alpha1.SecretReference{Name: "gh"},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gh", Namespace: "default"},
+				Data:       map[string][]byte{"OTHER": []byte("x")},
+			},
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      "InvalidSecret",
+			wantCredentials: metav1.ConditionFalse,
+		},
+		{
+			name: "gitlab secret with token",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:      "https://gitlab.com/group/repo.git",
+				SecretRef: &axonv1alpha1.SecretReference{Name: "gl"},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gl", Namespace: "default"},
+				Data:       map[string][]byte{"GITLAB_TOKEN": []byte("token")},
+			},
+			wantReady:       metav1.ConditionTrue,
+			wantReason:      "Valid",
+			wantCredentials: metav1.ConditionTrue,
+		},
+		{
+			name: "gitlab secret with GitHub token",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:      "https://gitlab.com/group/repo.git",
+				SecretRef: &axonv1alpha1.SecretReference{Name: "gl"},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gl", Namespace: "default"},
+				Data:       map[string][]byte{"GITHUB_TOKEN": []byte("token")},
+			},
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      "InvalidSecret",
+			wantCredentials: metav1.ConditionFalse,
+		},
+		{
+			name: "invalid file path",
+			spec: axonv1alpha1.WorkspaceSpec{
+				Repo:  "https://github.com/org/repo.git",
+				Files: []axonv1alpha1.WorkspaceFile{{Path: "../escape", Content: "x"}},
+			},
+			wantReady:  metav1.ConditionFalse,
+			wantReason: "InvalidFiles",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &axonv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws", Namespace: "default", Generation: 2},
+				Spec:       tt.spec,
+			}
+			objs := []client.Object{ws}
+			if tt.secret != nil {
+				objs = append(objs, tt.secret)
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(newDependencyTestScheme()).
+				WithObjects(objs...).
+				WithStatusSubresource(ws).
+				Build()
+			r := &WorkspaceReconciler{Client: cl}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ws)}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var got axonv1alpha1.Workspace
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(ws), &got); err != nil {
+				t.Fatalf("Getting Workspace: %v", err)
+			}
+			ready := meta.FindStatusCondition(got.Status.Conditions, axonv1alpha1.ConditionReady)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready = %+v, want status %s reason %s", ready, tt.wantReady, tt.wantReason)
+			}
+			credentials := meta.FindStatusCondition(got.Status.Conditions, axonv1alpha1.ConditionCredentialsResolved)
+			if tt.wantCredentials == "" {
+				if credentials != nil {
+					t.Errorf("Expected no CredentialsResolved condition, got %+v", credentials)
+				}
+			} else if credentials == nil || credentials.Status != tt.wantCredentials {
+				t.Errorf("CredentialsResolved = %+v, want status %s", credentials, tt.wantCredentials)
+			}
+			if got.Status.ObservedGeneration != 2 {
+				t.Errorf("ObservedGeneration = %d, want 2", got.Status.ObservedGeneration)
+			}
+		})
+	}
+}
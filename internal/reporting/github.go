@@ -0,0 +1,134 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const defaultBaseURL = "https://api.github.com"
+
+// ErrNotFound is returned when the comment or label to act on does not
+// exist.
+var ErrNotFound = errors.New("not found")
+
+// GitHubReporter writes status comments and labels on the issues and pull
+// requests of a GitHub repository.
+type GitHubReporter struct {
+	Owner string
+	Repo  string
+	Token string
+	// BaseURL is the GitHub API base URL. Defaults to https://api.github.com.
+	BaseURL string
+	Client  *http.Client
+}
+
+func (r *GitHubReporter) baseURL() string {
+	if r.BaseURL != "" {
+		return r.BaseURL
+	}
+	return defaultBaseURL
+}
+
+func (r *GitHubReporter) httpClient() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+func (r *GitHubReporter) repoURL() string {
+	return fmt.Sprintf("%s/repos/%s/%s", r.baseURL(), r.Owner, r.Repo)
+}
+
+// CreateComment posts a comment with body on issue or pull request number
+// and returns its ID.
+func (r *GitHubReporter) CreateComment(ctx context.Context, number int, body string) (int64, error) {
+	var comment struct {
+		ID int64 `json:"id"`
+	}
+	reqURL := fmt.Sprintf("%s/issues/%d/comments", r.repoURL(), number)
+	if err := r.send(ctx, http.MethodPost, reqURL, map[string]string{"body": body}, &comment); err != nil {
+		return 0, fmt.Errorf("creating comment on #%d: %w", number, err)
+	}
+	return comment.ID, nil
+}
+
+// UpdateComment replaces the body of comment id. It returns ErrNotFound if
+// the comment was deleted.
+func (r *GitHubReporter) UpdateComment(ctx context.Context, id int64, body string) error {
+	reqURL := fmt.Sprintf("%s/issues/comments/%d", r.repoURL(), id)
+	if err := r.send(ctx, http.MethodPatch, reqURL, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("updating comment %d: %w", id, err)
+	}
+	return nil
+}
+
+// AddLabel adds label to issue or pull request number.
+func (r *GitHubReporter) AddLabel(ctx context.Context, number int, label string) error {
+	reqURL := fmt.Sprintf("%s/issues/%d/labels", r.repoURL(), number)
+	if err := r.send(ctx, http.MethodPost, reqURL, map[string][]string{"labels": {label}}, nil); err != nil {
+		return fmt.Errorf("adding label %q to #%d: %w", label, number, err)
+	}
+	return nil
+}
+
+// RemoveLabel removes label from issue or pull request number. Removing a
+// label that is not set is not an error.
+func (r *GitHubReporter) RemoveLabel(ctx context.Context, number int, label string) error {
+	reqURL := fmt.Sprintf("%s/issues/%d/labels/%s", r.repoURL(), number, url.PathEscape(label))
+	if err := r.send(ctx, http.MethodDelete, reqURL, nil, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("removing label %q from #%d: %w", label, number, err)
+	}
+	return nil
+}
+
+// send sends a request with the JSON encoding of in, if set, and decodes
+// the response into out, if set.
+func (r *GitHubReporter) send(ctx context.Context, method, reqURL string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.Token != "" {
+		req.Header.Set("Authorization", "token "+r.Token)
+	}
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(data))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	var requests []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token ghp" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /repos/owner/repo/issues/42/comments":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]int64{"id": 7})
+		case "PATCH /repos/owner/repo/issues/comments/7", "POST /repos/owner/repo/issues/42/labels":
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &GitHubReporter{Owner: "owner", Repo: "repo", Token: "ghp", BaseURL: server.URL}
+	ctx := context.Background()
+
+	id, err := r.CreateComment(ctx, 42, "Running")
+	if err != nil || id != 7 {
+		t.Fatalf("CreateComment = %d, %v", id, err)
+	}
+	if err := r.UpdateComment(ctx, 7, "Succeeded"); err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if err := r.UpdateComment(ctx, 8, "Succeeded"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted comment, got %v", err)
+	}
+	if err := r.AddLabel(ctx, 42, "axon/done"); err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+	if err := r.RemoveLabel(ctx, 42, "axon/in-progress"); err != nil {
+		t.Errorf("expected removing a missing label to succeed, got %v", err)
+	}
+
+	wantRequests := []string{
+		"POST /repos/owner/repo/issues/42/comments",
+		"PATCH /repos/owner/repo/issues/comments/7",
+		"PATCH /repos/owner/repo/issues/comments/8",
+		"POST /repos/owner/repo/issues/42/labels",
+		"DELETE /repos/owner/repo/issues/42/labels/axon%2Fin-progress",
+	}
+	if len(requests) != len(wantRequests) {
+		t.Fatalf("got requests %v, want %v", requests, wantRequests)
+	}
+	for i := range wantRequests {
+		if requests[i] != wantRequests[i] {
+			t.Errorf("request %d = %q, want %q", i, requests[i], wantRequests[i])
+		}
+	}
+	if bodies[0] != `{"body":"Running"}` || bodies[3] != `{"labels":["axon/done"]}` {
+		t.Errorf("unexpected request bodies %q", bodies)
+	}
+}
+
+func TestGitHubReporterAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	r := &GitHubReporter{Owner: "owner", Repo: "repo", BaseURL: server.URL}
+	if _, err := r.CreateComment(context.Background(), 1, "Running"); err == nil {
+		t.Fatal("expected error for API failure")
+	}
+	if err := r.RemoveLabel(context.Background(), 1, "axon/done"); err == nil {
+		t.Fatal("expected error for API failure")
+	}
+}
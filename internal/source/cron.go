@@ -3,55 +3,101 @@ package source
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
 const (
-	// maxCronTicks limits the number of ticks returned per discovery to prevent
-	// unbounded iteration when LastDiscoveryTime is zero or far in the past.
+	// maxCronTicks limits the number of ticks returned per discovery when
+	// LastDiscoveryTime is far in the past. The most recent ticks are kept.
 	maxCronTicks = 1000
 )
 
 // CronSource discovers work items based on cron schedule ticks since the last discovery.
 type CronSource struct {
-	Schedule          string
+	Schedule string
+	// TimeZone is the IANA name of the time zone the schedule is
+	// evaluated in (e.g. "Asia/Seoul"). Defaults to UTC.
+	TimeZone          string
 	LastDiscoveryTime time.Time
 }
 
-// Discover returns a WorkItem for each cron tick between LastDiscoveryTime and now.
+// Discover returns a WorkItem for each cron tick between LastDiscoveryTime
+// and now, oldest first, or for the most recent maxCronTicks of them after
+// a long outage. The Time of each item is in TimeZone.
 func (s *CronSource) Discover(_ context.Context) ([]WorkItem, error) {
 	if s.LastDiscoveryTime.IsZero() {
 		return nil, fmt.Errorf("LastDiscoveryTime must not be zero")
 	}
 
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	sched, err := parser.Parse(s.Schedule)
+	sched, err := ParseSchedule(s.Schedule)
 	if err != nil {
-		return nil, fmt.Errorf("parsing cron schedule %q: %w", s.Schedule, err)
+		return nil, err
 	}
 
-	now := time.Now().UTC()
-	cursor := s.LastDiscoveryTime.UTC()
+	loc, err := LoadTimeZone(s.TimeZone)
+	if err != nil {
+		return nil, err
+	}
 
-	var items []WorkItem
+	now := time.Now().In(loc)
+	cursor := s.LastDiscoveryTime.In(loc)
+
+	// Walk up to now, keeping the last maxCronTicks ticks in a ring.
+	ticks := make([]time.Time, 0, maxCronTicks)
+	var total int
 	for {
 		next := sched.Next(cursor)
-		if next.After(now) {
+		if next.IsZero() || next.After(now) {
 			break
 		}
+		if len(ticks) < maxCronTicks {
+			ticks = append(ticks, next)
+		} else {
+			ticks[total%maxCronTicks] = next
+		}
+		total++
+		cursor = next
+	}
+	if total > maxCronTicks {
+		oldest := total % maxCronTicks
+		ticks = slices.Concat(ticks[oldest:], ticks[:oldest])
+	}
+
+	var items []WorkItem
+	for _, tick := range ticks {
+		// IDs use UTC so that they stay unique when the clock is set back.
 		items = append(items, WorkItem{
-			ID:       next.Format("20060102-1504"),
-			Title:    next.Format(time.RFC3339),
-			Time:     next.Format(time.RFC3339),
+			ID:       tick.UTC().Format("20060102-1504"),
+			Title:    tick.Format(time.RFC3339),
+			Time:     tick.Format(time.RFC3339),
 			Schedule: s.Schedule,
 		})
-		cursor = next
-		if len(items) >= maxCronTicks {
-			break
-		}
 	}
-
 	return items, nil
 }
+
+// LoadTimeZone returns the time zone with the IANA name name, or UTC if
+// name is empty.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("loading time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// ParseSchedule parses a standard five-field cron expression.
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	sched, err := parser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cron schedule %q: %w", schedule, err)
+	}
+	return sched, nil
+}
@@ -2,6 +2,7 @@ package source
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -137,7 +138,8 @@ func TestCronDiscoverZeroLastDiscoveryTime(t *testing.T) {
 
 func TestCronDiscoverMaxTicks(t *testing.T) {
 	// A very old LastDiscoveryTime with a minutely schedule would produce
-	// millions of ticks, but we cap at maxCronTicks.
+	// hundreds of thousands of ticks, but we cap at the most recent
+	// maxCronTicks.
 	s := &CronSource{
 		Schedule:          "* * * * *",
 		LastDiscoveryTime: time.Now().UTC().Add(-24 * 365 * time.Hour), // ~1 year ago
@@ -149,7 +151,19 @@ func TestCronDiscoverMaxTicks(t *testing.T) {
 	}
 
 	if len(items) != maxCronTicks {
-		t.Errorf("expected %d items (capped), got %d", maxCronTicks, len(items))
+		t.Fatalf("expected %d items (capped), got %d", maxCronTicks, len(items))
+	}
+	latest, err := time.Parse(time.RFC3339, items[len(items)-1].Time)
+	if err != nil {
+		t.Fatalf("parsing time of the last item: %v", err)
+	}
+	if age := time.Since(latest); age < 0 || age > 2*time.Minute {
+		t.Errorf("expected the last item to be the most recent tick, got %s", latest)
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i].Time <= items[i-1].Time {
+			t.Fatalf("expected items oldest first, got %s after %s", items[i].Time, items[i-1].Time)
+		}
 	}
 }
 
@@ -192,3 +206,64 @@ func TestCronDiscoverWorkItemFields(t *testing.T) {
 		t.Errorf("expected no Labels, got %v", item.Labels)
 	}
 }
+
+func TestCronDiscoverTimeZone(t *testing.T) {
+	// 9:00 in Seoul is 0:00 UTC.
+	s := &CronSource{
+		Schedule:          "0 9 * * *",
+		TimeZone:          "Asia/Seoul",
+		LastDiscoveryTime: time.Now().UTC().Add(-48 * time.Hour),
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	for _, item := range items {
+		if !strings.HasSuffix(item.Time, "T09:00:00+09:00") {
+			t.Errorf("expected Time at 9:00 Seoul time, got %q", item.Time)
+		}
+		if !strings.HasSuffix(item.ID, "-0000") {
+			t.Errorf("expected ID in UTC, got %q", item.ID)
+		}
+	}
+}
+
+func TestCronDiscoverTimeZoneDST(t *testing.T) {
+	// New York switches to daylight saving time on 2026-03-08, moving 9:00
+	// local time from 14:00 to 13:00 UTC.
+	s := &CronSource{
+		Schedule:          "0 9 7,9 3 *",
+		TimeZone:          "America/New_York",
+		LastDiscoveryTime: time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) < 2 {
+		t.Skip("Test depends on current date being after Mar 9, 2026")
+	}
+	if items[0].ID != "20260307-1400" || items[0].Time != "2026-03-07T09:00:00-05:00" {
+		t.Errorf("unexpected item before DST: %+v", items[0])
+	}
+	if items[1].ID != "20260309-1300" || items[1].Time != "2026-03-09T09:00:00-04:00" {
+		t.Errorf("unexpected item after DST: %+v", items[1])
+	}
+}
+
+func TestCronDiscoverInvalidTimeZone(t *testing.T) {
+	s := &CronSource{
+		Schedule:          "* * * * *",
+		TimeZone:          "Mars/Olympus_Mons",
+		LastDiscoveryTime: time.Now().UTC().Add(-time.Hour),
+	}
+
+	if _, err := s.Discover(context.Background()); err == nil {
+		t.Fatal("expected error for unknown time zone, got nil")
+	}
+}
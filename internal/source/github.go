@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -21,6 +22,10 @@ const (
 
 	// maxCommentBytes limits the total size of concatenated comments per issue.
 	maxCommentBytes = 64 * 1024
+
+	// commentUpdateSkew is how much later than a comment's creation time
+	// the updated_at of its issue may be when the comment was the update.
+	commentUpdateSkew = 5 * time.Second
 )
 
 // GitHubSource discovers issues from a GitHub repository.
@@ -34,6 +39,36 @@ type GitHubSource struct {
 	Token         string
 	BaseURL       string
 	Client        *http.Client
+
+	// Authors, if set, only selects items opened by one of these logins.
+	// The author filters also apply to comments: comments by other authors
+	// are left out of WorkItem.Comments and are not counted as activity.
+	Authors []string
+	// ExcludeAuthors filters out items opened by any of these logins.
+	ExcludeAuthors []string
+	// AuthorAssociations, if set, only selects items whose author has one
+	// of these author_association values (e.g. OWNER, MEMBER).
+	AuthorAssociations []string
+
+	// IgnoreAuthors lists logins whose comments are not counted as
+	// activity on an item (see WorkItem.LastCommentID). Comments by GitHub
+	// App bots are never counted.
+	IgnoreAuthors []string
+	// IgnoreSelf also ignores comments by the user the Token belongs to.
+	IgnoreSelf bool
+
+	// Cache, if set, keeps responses, comments and the rate limit between
+	// discoveries to reduce the use of the GitHub API rate limit.
+	Cache *GitHubCache
+
+	// ignored is the resolved set of ignored logins, lowercased.
+	ignored map[string]struct{}
+
+	// rateLimit is the rate limit reported by the last response that
+	// carried it.
+	rateLimit    RateLimit
+	hasRateLimit bool
+	apiErrors    int
 }
 
 type githubIssue struct {
@@ -41,8 +76,19 @@ type githubIssue struct {
 	Title       string        `json:"title"`
 	Body        string        `json:"body"`
 	HTMLURL     string        `json:"html_url"`
+	State       string        `json:"state"`
+	CreatedAt   string        `json:"created_at"`
+	UpdatedAt   string        `json:"updated_at"`
 	Labels      []githubLabel `json:"labels"`
 	PullRequest *struct{}     `json:"pull_request,omitempty"`
+	User        githubUser    `json:"user"`
+	// AuthorAssociation is the association of User with the repository.
+	AuthorAssociation string          `json:"author_association"`
+	Reactions         githubReactions `json:"reactions"`
+}
+
+type githubReactions struct {
+	TotalCount int `json:"total_count"`
 }
 
 type githubLabel struct {
@@ -50,7 +96,17 @@ type githubLabel struct {
 }
 
 type githubComment struct {
-	Body string `json:"body"`
+	ID        int64      `json:"id"`
+	Body      string     `json:"body"`
+	User      githubUser `json:"user"`
+	CreatedAt string     `json:"created_at"`
+	// AuthorAssociation is the association of User with the repository.
+	AuthorAssociation string `json:"author_association"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+	Type  string `json:"type"`
 }
 
 func (s *GitHubSource) baseURL() string {
@@ -67,45 +123,231 @@ func (s *GitHubSource) httpClient() *http.Client {
 	return http.DefaultClient
 }
 
-// Discover fetches issues from GitHub and returns them as WorkItems.
+// RateLimitRemaining returns the number of requests remaining in the
+// current GitHub API rate limit window, as reported by the last response,
+// and whether it is known.
+func (s *GitHubSource) RateLimitRemaining() (int, bool) {
+	return s.rateLimit.Remaining, s.hasRateLimit
+}
+
+// RateLimit returns the GitHub API rate limit reported by the last
+// response, and whether it is known.
+func (s *GitHubSource) RateLimit() (RateLimit, bool) {
+	return s.rateLimit, s.hasRateLimit
+}
+
+// APIErrors returns the number of GitHub API requests that failed or
+// returned a non-OK status.
+func (s *GitHubSource) APIErrors() int {
+	return s.apiErrors
+}
+
+// do sends req and records the rate limit and errors of the response. With
+// a Cache, GET requests are sent as conditional requests and a Not Modified
+// response is replaced with the cached one.
+func (s *GitHubSource) do(req *http.Request) (*http.Response, error) {
+	cached := s.Cache.validators(req)
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		s.apiErrors++
+		return nil, err
+	}
+	if rl, ok := parseRateLimit(resp.Header); ok {
+		s.rateLimit = rl
+		s.hasRateLimit = true
+		s.Cache.setRateLimit(rl)
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return cached.response(req), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		s.apiErrors++
+		return resp, nil
+	}
+	return s.Cache.store(req, resp)
+}
+
+// itemComments returns the comments of item number, reusing the ones
+// cached for it unless its updated_at changed.
+func (s *GitHubSource) itemComments(ctx context.Context, number int, updatedAt string) ([]githubComment, error) {
+	key := s.commentsKey(number)
+	if comments, ok := s.Cache.cachedItemComments(key, updatedAt); ok {
+		return comments, nil
+	}
+	comments, err := s.fetchComments(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	s.Cache.setItemComments(key, updatedAt, comments)
+	return comments, nil
+}
+
+// commentsPrefix is the prefix of the keys of the comments of the
+// repository's items in the Cache.
+func (s *GitHubSource) commentsPrefix() string {
+	return s.Owner + "/" + s.Repo + "#"
+}
+
+func (s *GitHubSource) commentsKey(number int) string {
+	return s.commentsPrefix() + strconv.Itoa(number)
+}
+
+// Discover fetches issues from GitHub and returns them as WorkItems. It
+// returns a *RateLimitError without calling the API if the Cache recorded
+// that the rate limit is nearly exhausted.
 func (s *GitHubSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	if err := s.Cache.checkRateLimit(time.Now()); err != nil {
+		return nil, err
+	}
+
 	issues, err := s.fetchAllIssues(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	seen := make(map[string]struct{}, len(issues))
+	for _, issue := range issues {
+		seen[s.commentsKey(issue.Number)] = struct{}{}
+	}
+	s.Cache.pruneComments(s.commentsPrefix(), seen)
+
 	issues = s.filterItems(issues)
 
+	if err := s.resolveIgnoredAuthors(ctx); err != nil {
+		return nil, err
+	}
+
 	var items []WorkItem
 	for _, issue := range issues {
-		var labels []string
-		for _, l := range issue.Labels {
-			labels = append(labels, l.Name)
-		}
-
-		comments, err := s.fetchComments(ctx, issue.Number)
+		item, err := s.workItem(ctx, issue)
 		if err != nil {
-			return nil, fmt.Errorf("fetching comments for issue #%d: %w", issue.Number, err)
+			return nil, err
 		}
+		items = append(items, item)
+	}
 
-		kind := "Issue"
-		if issue.PullRequest != nil {
-			kind = "PR"
-		}
+	return items, nil
+}
 
-		items = append(items, WorkItem{
-			ID:       strconv.Itoa(issue.Number),
-			Number:   issue.Number,
-			Title:    issue.Title,
-			Body:     issue.Body,
-			URL:      issue.HTMLURL,
-			Labels:   labels,
-			Comments: comments,
-			Kind:     kind,
-		})
+// workItem converts issue into a WorkItem, fetching its comments.
+func (s *GitHubSource) workItem(ctx context.Context, issue githubIssue) (WorkItem, error) {
+	var labels []string
+	for _, l := range issue.Labels {
+		labels = append(labels, l.Name)
 	}
 
-	return items, nil
+	comments, err := s.itemComments(ctx, issue.Number, issue.UpdatedAt)
+	if err != nil {
+		return WorkItem{}, fmt.Errorf("fetching comments for issue #%d: %w", issue.Number, err)
+	}
+
+	kind := "Issue"
+	if issue.PullRequest != nil {
+		kind = "PR"
+	}
+
+	item := WorkItem{
+		ID:        strconv.Itoa(issue.Number),
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		URL:       issue.HTMLURL,
+		Labels:    labels,
+		Comments:  joinComments(s.allowedComments(comments)),
+		Kind:      kind,
+		Author:    issue.User.Login,
+		CreatedAt: issue.CreatedAt,
+		Reactions: issue.Reactions.TotalCount,
+	}
+	s.setActivity(&item, issue.UpdatedAt, comments)
+	return item, nil
+}
+
+// resolveIgnoredAuthors resolves the logins whose comments are ignored,
+// looking up the authenticated user if IgnoreSelf is set. The lookup is
+// best effort: GitHub App installation tokens cannot query /user, but their
+// comments are made by a bot user and ignored anyway.
+func (s *GitHubSource) resolveIgnoredAuthors(ctx context.Context) error {
+	if s.ignored != nil {
+		return nil
+	}
+	s.ignored = make(map[string]struct{}, len(s.IgnoreAuthors)+1)
+	for _, login := range s.IgnoreAuthors {
+		s.ignored[strings.ToLower(login)] = struct{}{}
+	}
+	if !s.IgnoreSelf || s.Token == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL()+"/user", nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+s.Token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	var user githubUser
+	if resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&user) == nil && user.Login != "" {
+		s.ignored[strings.ToLower(user.Login)] = struct{}{}
+	}
+	return nil
+}
+
+// isIgnoredAuthor reports whether comments by u do not count as activity.
+func (s *GitHubSource) isIgnoredAuthor(u githubUser) bool {
+	if u.Type == "Bot" {
+		return true
+	}
+	_, ok := s.ignored[strings.ToLower(u.Login)]
+	return ok
+}
+
+// allowedComments returns the comments whose authors pass the author
+// filters, so that users who could not have started a Task cannot steer it
+// through the prompt either.
+func (s *GitHubSource) allowedComments(comments []githubComment) []githubComment {
+	allowed := make([]githubComment, 0, len(comments))
+	for _, c := range comments {
+		if s.matchesAuthor(c.User.Login, c.AuthorAssociation) {
+			allowed = append(allowed, c)
+		}
+	}
+	return allowed
+}
+
+// isActivity reports whether c counts as activity on its item: it must not
+// be by an ignored author and its author must pass the author filters.
+func (s *GitHubSource) isActivity(c githubComment) bool {
+	return !s.isIgnoredAuthor(c.User) && s.matchesAuthor(c.User.Login, c.AuthorAssociation)
+}
+
+// setActivity records the update time and the latest comment that counts
+// as activity on item.
+func (s *GitHubSource) setActivity(item *WorkItem, updatedAt string, comments []githubComment) {
+	item.UpdatedAt = updatedAt
+	for i := len(comments) - 1; i >= 0; i-- {
+		if s.isActivity(comments[i]) {
+			item.LastCommentID = strconv.FormatInt(comments[i].ID, 10)
+			break
+		}
+	}
+	// Comments update the item, so a trailing comment that is not activity
+	// is the latest update.
+	if n := len(comments); n > 0 && !s.isActivity(comments[n-1]) {
+		created, err1 := time.Parse(time.RFC3339, comments[n-1].CreatedAt)
+		updated, err2 := time.Parse(time.RFC3339, updatedAt)
+		// updated_at may trail the comment's creation by a moment.
+		if err1 == nil && err2 == nil && !created.Before(updated.Add(-commentUpdateSkew)) {
+			item.UpdatedByIgnoredAuthor = true
+		}
+	}
 }
 
 func (s *GitHubSource) resolvedTypes() map[string]struct{} {
@@ -141,6 +383,10 @@ func (s *GitHubSource) filterItems(issues []githubIssue) []githubIssue {
 			}
 		}
 
+		if !s.matchesAuthor(issue.User.Login, issue.AuthorAssociation) {
+			continue
+		}
+
 		// Exclude-label filtering
 		skip := false
 		for _, l := range issue.Labels {
@@ -156,6 +402,31 @@ func (s *GitHubSource) filterItems(issues []githubIssue) []githubIssue {
 	return filtered
 }
 
+// matchesAuthor reports whether an item opened, or a comment written, by
+// login with the given author_association passes the author filters.
+func (s *GitHubSource) matchesAuthor(login, association string) bool {
+	if len(s.Authors) > 0 && !containsFold(s.Authors, login) {
+		return false
+	}
+	if containsFold(s.ExcludeAuthors, login) {
+		return false
+	}
+	if len(s.AuthorAssociations) > 0 && !containsFold(s.AuthorAssociations, association) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *GitHubSource) fetchAllIssues(ctx context.Context) ([]githubIssue, error) {
 	var allIssues []githubIssue
 
@@ -203,7 +474,7 @@ func (s *GitHubSource) fetchIssuesPage(ctx context.Context, pageURL string) ([]g
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := s.httpClient().Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, "", fmt.Errorf("fetching issues: %w", err)
 	}
@@ -224,9 +495,21 @@ func (s *GitHubSource) fetchIssuesPage(ctx context.Context, pageURL string) ([]g
 	return issues, nextURL, nil
 }
 
-func (s *GitHubSource) fetchComments(ctx context.Context, issueNumber int) (string, error) {
+// fetchComments returns the comments of an issue or pull request, oldest
+// first, following the Link header so that the newest comments of busy
+// items are included.
+func (s *GitHubSource) fetchComments(ctx context.Context, issueNumber int) ([]githubComment, error) {
 	u := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100", s.baseURL(), s.Owner, s.Repo, issueNumber)
+	comments, err := getAllJSON[githubComment](ctx, s, u)
+	if err != nil {
+		return nil, fmt.Errorf("fetching comments: %w", err)
+	}
+	return comments, nil
+}
 
+// getJSON fetches u from the GitHub API, decodes the JSON response into v
+// and returns the URL of the next page, if any.
+func (s *GitHubSource) getJSON(ctx context.Context, u string, v any) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return "", fmt.Errorf("creating request: %w", err)
@@ -237,9 +520,9 @@ func (s *GitHubSource) fetchComments(ctx context.Context, issueNumber int) (stri
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := s.httpClient().Do(req)
+	resp, err := s.do(req)
 	if err != nil {
-		return "", fmt.Errorf("fetching comments: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
@@ -248,11 +531,30 @@ func (s *GitHubSource) fetchComments(ctx context.Context, issueNumber int) (stri
 		return "", fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var comments []githubComment
-	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
-		return "", fmt.Errorf("decoding comments: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
 	}
+	return parseNextLink(resp.Header.Get("Link")), nil
+}
+
+// getAllJSON fetches u and the pages that follow it in the Link header, up
+// to maxPages, and returns the items of all pages.
+func getAllJSON[T any](ctx context.Context, s *GitHubSource, u string) ([]T, error) {
+	var all []T
+	for page := 0; u != "" && page < maxPages; page++ {
+		var items []T
+		next, err := s.getJSON(ctx, u, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		u = next
+	}
+	return all, nil
+}
 
+// joinComments concatenates the bodies of comments, up to maxCommentBytes.
+func joinComments(comments []githubComment) string {
 	var parts []string
 	totalBytes := 0
 	for _, c := range comments {
@@ -263,7 +565,7 @@ func (s *GitHubSource) fetchComments(ctx context.Context, issueNumber int) (stri
 		parts = append(parts, c.Body)
 	}
 
-	return strings.Join(parts, "\n---\n"), nil
+	return strings.Join(parts, "\n---\n")
 }
 
 var linkNextRe = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
@@ -0,0 +1,209 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCachedResponses bounds the number of GitHub API responses kept
+	// for conditional requests.
+	maxCachedResponses = 1000
+
+	// minRateLimitRemaining is the number of remaining GitHub API requests
+	// below which discovery backs off until the rate limit resets.
+	minRateLimitRemaining = 100
+)
+
+// RateLimit is the GitHub API rate limit reported by a response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError is returned by Discover when the remaining GitHub API quota
+// is too low to poll until the rate limit resets.
+type RateLimitError struct {
+	RateLimit RateLimit
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitHub API rate limit low (%d of %d requests remaining), backing off until %s",
+		e.RateLimit.Remaining, e.RateLimit.Limit, e.RateLimit.Reset.UTC().Format(time.RFC3339))
+}
+
+// GitHubCache keeps GitHub API state between discoveries: the responses to
+// GET requests, revalidated with conditional requests that do not count
+// against the rate limit when nothing changed; the comments of each item,
+// refetched only when its updated_at changes; and the last known rate
+// limit. It is safe for concurrent use. A nil *GitHubCache caches nothing.
+type GitHubCache struct {
+	mu        sync.Mutex
+	responses map[string]*cachedResponse
+	comments  map[string]cachedComments
+	rateLimit *RateLimit
+}
+
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+type cachedComments struct {
+	updatedAt string
+	comments  []githubComment
+}
+
+// NewGitHubCache returns an empty GitHubCache.
+func NewGitHubCache() *GitHubCache {
+	return &GitHubCache{
+		responses: make(map[string]*cachedResponse),
+		comments:  make(map[string]cachedComments),
+	}
+}
+
+// validators adds the validators of the cached response to req, if any,
+// and returns the cached response.
+func (c *GitHubCache) validators(req *http.Request) *cachedResponse {
+	if c == nil || req.Method != http.MethodGet {
+		return nil
+	}
+	c.mu.Lock()
+	cached := c.responses[req.URL.String()]
+	c.mu.Unlock()
+	if cached == nil {
+		return nil
+	}
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
+	}
+	return cached
+}
+
+// store caches resp, the OK response to req, if it carries validators, and
+// returns a response whose body can still be read.
+func (c *GitHubCache) store(req *http.Request, resp *http.Response) (*http.Response, error) {
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if c == nil || req.Method != http.MethodGet || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := req.URL.String()
+	if _, ok := c.responses[key]; !ok && len(c.responses) >= maxCachedResponses {
+		for k := range c.responses {
+			delete(c.responses, k)
+			break
+		}
+	}
+	c.responses[key] = &cachedResponse{etag: etag, lastModified: lastModified, header: resp.Header.Clone(), body: body}
+	return resp, nil
+}
+
+// response returns the cached response as the response to req.
+func (r *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     r.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(r.body)),
+		Request:    req,
+	}
+}
+
+// cachedItemComments returns the comments cached for the item key if its
+// updated_at has not changed since.
+func (c *GitHubCache) cachedItemComments(key, updatedAt string) ([]githubComment, bool) {
+	if c == nil || updatedAt == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.comments[key]
+	if !ok || cached.updatedAt != updatedAt {
+		return nil, false
+	}
+	return cached.comments, true
+}
+
+func (c *GitHubCache) setItemComments(key, updatedAt string, comments []githubComment) {
+	if c == nil || updatedAt == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.comments[key] = cachedComments{updatedAt: updatedAt, comments: comments}
+}
+
+// pruneComments drops the comments cached for the items whose key starts
+// with prefix and that are not in keep.
+func (c *GitHubCache) pruneComments(prefix string, keep map[string]struct{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.comments {
+		if _, ok := keep[key]; !ok && strings.HasPrefix(key, prefix) {
+			delete(c.comments, key)
+		}
+	}
+}
+
+func (c *GitHubCache) setRateLimit(rl RateLimit) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = &rl
+}
+
+// checkRateLimit returns a *RateLimitError if the last known rate limit has
+// fewer than minRateLimitRemaining requests left and has not reset yet.
+func (c *GitHubCache) checkRateLimit(now time.Time) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rateLimit == nil || c.rateLimit.Remaining >= minRateLimitRemaining || !c.rateLimit.Reset.After(now) {
+		return nil
+	}
+	return &RateLimitError{RateLimit: *c.rateLimit}
+}
+
+// parseRateLimit parses the X-RateLimit-* headers of a GitHub API response.
+func parseRateLimit(h http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	rl := RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		rl.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl, true
+}
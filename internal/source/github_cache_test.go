@@ -0,0 +1,115 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiscoverConditionalRequests(t *testing.T) {
+	var mu sync.Mutex
+	updatedAt := "2026-01-01T10:00:00Z"
+	requests := make(map[string]int)
+	notModified := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues":
+			etag := `"` + updatedAt + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			json.NewEncoder(w).Encode([]githubIssue{{Number: 1, Title: "Bug", UpdatedAt: updatedAt}})
+		case "/repos/owner/repo/issues/1/comments":
+			json.NewEncoder(w).Encode([]githubComment{{ID: 1, Body: "details"}})
+		}
+	}))
+	defer server.Close()
+
+	cache := NewGitHubCache()
+	discover := func() []WorkItem {
+		t.Helper()
+		s := &GitHubSource{Owner: "owner", Repo: "repo", BaseURL: server.URL, Cache: cache}
+		items, err := s.Discover(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.APIErrors() != 0 {
+			t.Errorf("expected Not Modified responses not to count as API errors, got %d", s.APIErrors())
+		}
+		return items
+	}
+
+	discover()
+	items := discover()
+	if len(items) != 1 || items[0].Title != "Bug" || items[0].Comments != "details" {
+		t.Fatalf("unexpected items from cached responses: %+v", items)
+	}
+	if notModified != 1 {
+		t.Errorf("expected the second issues request to be revalidated, got %d Not Modified responses", notModified)
+	}
+	if got := requests["/repos/owner/repo/issues/1/comments"]; got != 1 {
+		t.Errorf("expected comments of an unchanged issue to be fetched once, got %d requests", got)
+	}
+
+	mu.Lock()
+	updatedAt = "2026-01-02T10:00:00Z"
+	mu.Unlock()
+	discover()
+	if got := requests["/repos/owner/repo/issues/1/comments"]; got != 2 {
+		t.Errorf("expected comments to be refetched after an update, got %d requests", got)
+	}
+}
+
+func TestDiscoverRateLimitBackoff(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	remaining := 50
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		json.NewEncoder(w).Encode([]githubIssue{})
+	}))
+	defer server.Close()
+
+	cache := NewGitHubCache()
+	s := &GitHubSource{Owner: "owner", Repo: "repo", BaseURL: server.URL, Cache: cache}
+	if _, err := s.Discover(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rl, ok := s.RateLimit()
+	if !ok || rl.Limit != 5000 || rl.Remaining != 50 || rl.Reset.Unix() != reset {
+		t.Errorf("unexpected rate limit %+v", rl)
+	}
+
+	s = &GitHubSource{Owner: "owner", Repo: "repo", BaseURL: server.URL, Cache: cache}
+	_, err := s.Discover(context.Background())
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RateLimit.Remaining != 50 {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no request while backing off, got %d requests", requests)
+	}
+
+	// Once the rate limit has reset, discovery resumes.
+	cache.setRateLimit(RateLimit{Limit: 5000, Remaining: 50, Reset: time.Now().Add(-time.Second)})
+	remaining = 4999
+	if _, err := s.Discover(context.Background()); err != nil {
+		t.Fatalf("unexpected error after reset: %v", err)
+	}
+}
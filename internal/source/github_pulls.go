@@ -0,0 +1,284 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Review states of a pull request, derived from the latest review of each
+// reviewer.
+const (
+	ReviewStateApproved         = "approved"
+	ReviewStateChangesRequested = "changes_requested"
+)
+
+// GitHubPullRequestSource discovers pull requests from a GitHub repository,
+// together with their reviews and inline review comments.
+type GitHubPullRequestSource struct {
+	// GitHubSource provides the repository, credentials, API client and the
+	// Labels, ExcludeLabels and State filters. Its Types are ignored.
+	GitHubSource
+
+	// ReviewState, if set, only selects pull requests whose review state
+	// is ReviewStateApproved or ReviewStateChangesRequested.
+	ReviewState string
+	// Draft, if set, only selects draft (true) or ready (false) pull
+	// requests.
+	Draft *bool
+	// BaseBranch, if set, only selects pull requests targeting this branch.
+	BaseBranch string
+	// Author, if set, only selects pull requests opened by this user.
+	Author string
+}
+
+type githubPullRequest struct {
+	Number    int           `json:"number"`
+	Title     string        `json:"title"`
+	Body      string        `json:"body"`
+	HTMLURL   string        `json:"html_url"`
+	State     string        `json:"state"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+	Draft     bool          `json:"draft"`
+	Labels    []githubLabel `json:"labels"`
+	User      githubUser    `json:"user"`
+	// AuthorAssociation is the association of User with the repository.
+	AuthorAssociation string    `json:"author_association"`
+	Head              githubRef `json:"head"`
+	Base              githubRef `json:"base"`
+}
+
+type githubRef struct {
+	Ref  string `json:"ref"`
+	Repo *struct {
+		FullName string `json:"full_name"`
+	} `json:"repo"`
+}
+
+type githubReview struct {
+	User  githubUser `json:"user"`
+	State string     `json:"state"`
+}
+
+type githubReviewComment struct {
+	Body     string     `json:"body"`
+	Path     string     `json:"path"`
+	Line     *int       `json:"line"`
+	DiffHunk string     `json:"diff_hunk"`
+	User     githubUser `json:"user"`
+}
+
+// Discover fetches pull requests from GitHub and returns the ones that
+// pass the filters as WorkItems.
+func (s *GitHubPullRequestSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	if err := s.Cache.checkRateLimit(time.Now()); err != nil {
+		return nil, err
+	}
+
+	var pulls []githubPullRequest
+	pageURL := s.buildPullsURL()
+	for page := 0; pageURL != "" && page < maxPages; page++ {
+		var prs []githubPullRequest
+		next, err := s.getJSON(ctx, pageURL, &prs)
+		if err != nil {
+			return nil, fmt.Errorf("fetching pull requests: %w", err)
+		}
+		pulls = append(pulls, prs...)
+		pageURL = next
+	}
+
+	seen := make(map[string]struct{}, len(pulls))
+	for _, pr := range pulls {
+		seen[s.commentsKey(pr.Number)] = struct{}{}
+	}
+	s.Cache.pruneComments(s.commentsPrefix(), seen)
+
+	if err := s.resolveIgnoredAuthors(ctx); err != nil {
+		return nil, err
+	}
+
+	var items []WorkItem
+	for _, pr := range pulls {
+		if !s.matches(pr) {
+			continue
+		}
+
+		if s.ReviewState != "" {
+			reviewState, err := s.fetchReviewState(ctx, pr.Number)
+			if err != nil {
+				return nil, fmt.Errorf("fetching reviews for pull request #%d: %w", pr.Number, err)
+			}
+			if reviewState != s.ReviewState {
+				continue
+			}
+		}
+
+		item, err := s.workItem(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (s *GitHubPullRequestSource) buildPullsURL() string {
+	params := url.Values{}
+	params.Set("per_page", "100")
+
+	state := s.State
+	if state == "" {
+		state = "open"
+	}
+	params.Set("state", state)
+
+	if s.BaseBranch != "" {
+		params.Set("base", s.BaseBranch)
+	}
+
+	return fmt.Sprintf("%s/repos/%s/%s/pulls?%s", s.baseURL(), s.Owner, s.Repo, params.Encode())
+}
+
+// matches reports whether pr passes the filters that do not require
+// further API calls. The pulls API does not filter by label, so Labels is
+// applied here.
+func (s *GitHubPullRequestSource) matches(pr githubPullRequest) bool {
+	if s.Draft != nil && pr.Draft != *s.Draft {
+		return false
+	}
+	if s.Author != "" && !strings.EqualFold(pr.User.Login, s.Author) {
+		return false
+	}
+	issue := githubIssue{Labels: pr.Labels, PullRequest: &struct{}{}}
+	if !s.hasLabels(issue) {
+		return false
+	}
+	excluded := make(map[string]struct{}, len(s.ExcludeLabels))
+	for _, l := range s.ExcludeLabels {
+		excluded[l] = struct{}{}
+	}
+	for _, l := range pr.Labels {
+		if _, ok := excluded[l.Name]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchReviewState returns the review state of a pull request: changes
+// requested if any reviewer's latest review requests changes, approved if
+// any reviewer's latest review approves, and empty otherwise.
+func (s *GitHubPullRequestSource) fetchReviewState(ctx context.Context, number int) (string, error) {
+	u := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews?per_page=100", s.baseURL(), s.Owner, s.Repo, number)
+	reviews, err := getAllJSON[githubReview](ctx, &s.GitHubSource, u)
+	if err != nil {
+		return "", err
+	}
+
+	// Reviews are returned in chronological order. Comment-only reviews
+	// do not change a reviewer's state.
+	latest := make(map[string]string)
+	for _, r := range reviews {
+		switch r.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[r.User.Login] = r.State
+		}
+	}
+
+	state := ""
+	for _, st := range latest {
+		switch st {
+		case "CHANGES_REQUESTED":
+			return ReviewStateChangesRequested, nil
+		case "APPROVED":
+			state = ReviewStateApproved
+		}
+	}
+	return state, nil
+}
+
+// workItem converts pr into a WorkItem, fetching its conversation and
+// review comments.
+func (s *GitHubPullRequestSource) workItem(ctx context.Context, pr githubPullRequest) (WorkItem, error) {
+	var labels []string
+	for _, l := range pr.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	comments, err := s.itemComments(ctx, pr.Number, pr.UpdatedAt)
+	if err != nil {
+		return WorkItem{}, fmt.Errorf("fetching comments for pull request #%d: %w", pr.Number, err)
+	}
+
+	reviewComments, diffHunks, err := s.fetchReviewComments(ctx, pr.Number)
+	if err != nil {
+		return WorkItem{}, fmt.Errorf("fetching review comments for pull request #%d: %w", pr.Number, err)
+	}
+
+	item := WorkItem{
+		ID:             strconv.Itoa(pr.Number),
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Body:           pr.Body,
+		URL:            pr.HTMLURL,
+		Labels:         labels,
+		Comments:       joinComments(comments),
+		Kind:           "PR",
+		ReviewComments: reviewComments,
+		DiffHunks:      diffHunks,
+		HeadBranch:     pr.Head.Ref,
+		Author:         pr.User.Login,
+		CreatedAt:      pr.CreatedAt,
+	}
+	s.setActivity(&item, pr.UpdatedAt, comments)
+	// The head branch of a pull request from a fork does not exist in the
+	// repository, so it can only be checked out from the same repository.
+	if pr.Head.Repo != nil && pr.Base.Repo != nil && strings.EqualFold(pr.Head.Repo.FullName, pr.Base.Repo.FullName) {
+		item.Ref = pr.Head.Ref
+	}
+	return item, nil
+}
+
+// fetchReviewComments returns the inline review comments of a pull request,
+// each prefixed with the file and line it is attached to, and the distinct
+// diff hunks they comment on.
+func (s *GitHubPullRequestSource) fetchReviewComments(ctx context.Context, number int) (string, string, error) {
+	u := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/comments?per_page=100", s.baseURL(), s.Owner, s.Repo, number)
+	comments, err := getAllJSON[githubReviewComment](ctx, &s.GitHubSource, u)
+	if err != nil {
+		return "", "", err
+	}
+
+	var parts, hunks []string
+	seenHunks := make(map[string]struct{})
+	totalBytes := 0
+	for _, c := range comments {
+		location := c.Path
+		if c.Line != nil {
+			location += ":" + strconv.Itoa(*c.Line)
+		}
+		part := fmt.Sprintf("%s (@%s):\n%s", location, c.User.Login, c.Body)
+
+		hunk := ""
+		if _, ok := seenHunks[c.DiffHunk]; !ok && c.DiffHunk != "" {
+			hunk = fmt.Sprintf("%s\n%s", c.Path, c.DiffHunk)
+		}
+
+		totalBytes += len(part) + len(hunk)
+		if totalBytes > maxCommentBytes {
+			break
+		}
+		parts = append(parts, part)
+		if hunk != "" {
+			seenHunks[c.DiffHunk] = struct{}{}
+			hunks = append(hunks, hunk)
+		}
+	}
+
+	return strings.Join(parts, "\n---\n"), strings.Join(hunks, "\n---\n"), nil
+}
@@ -0,0 +1,179 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitHubPullRequestDiscover(t *testing.T) {
+	line := 12
+	pulls := `[
+		{"number": 1, "title": "Fix login", "body": "Fixes #3", "html_url": "https://github.com/owner/repo/pull/1", "state": "open",
+		 "labels": [{"name": "axon"}], "user": {"login": "alice"},
+		 "head": {"ref": "fix-login", "repo": {"full_name": "owner/repo"}}, "base": {"ref": "main", "repo": {"full_name": "owner/repo"}}},
+		{"number": 2, "title": "From fork", "state": "open", "labels": [{"name": "axon"}], "user": {"login": "bob"},
+		 "head": {"ref": "patch-1", "repo": {"full_name": "bob/repo"}}, "base": {"ref": "main", "repo": {"full_name": "owner/repo"}}}
+	]`
+
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls":
+			gotQuery = r.URL.RawQuery
+			w.Write([]byte(pulls))
+		case "/repos/owner/repo/issues/1/comments":
+			json.NewEncoder(w).Encode([]githubComment{{Body: "looks good overall"}})
+		case "/repos/owner/repo/pulls/1/comments":
+			json.NewEncoder(w).Encode([]githubReviewComment{
+				{Body: "Handle the nil case", Path: "auth.go", Line: &line, DiffHunk: "@@ -10,3 +10,4 @@\n+if u == nil {", User: githubUser{Login: "carol"}},
+				{Body: "And log it", Path: "auth.go", DiffHunk: "@@ -10,3 +10,4 @@\n+if u == nil {", User: githubUser{Login: "carol"}},
+			})
+		default:
+			json.NewEncoder(w).Encode([]any{})
+		}
+	}))
+	defer server.Close()
+
+	s := &GitHubPullRequestSource{
+		GitHubSource: GitHubSource{Owner: "owner", Repo: "repo", Labels: []string{"axon"}, BaseURL: server.URL},
+		BaseBranch:   "main",
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotQuery != "base=main&per_page=100&state=open" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	pr := items[0]
+	if pr.ID != "1" || pr.Kind != "PR" || pr.HeadBranch != "fix-login" || pr.Ref != "fix-login" {
+		t.Errorf("unexpected item[0]: %+v", pr)
+	}
+	if pr.Comments != "looks good overall" {
+		t.Errorf("unexpected comments %q", pr.Comments)
+	}
+	wantReview := "auth.go:12 (@carol):\nHandle the nil case\n---\nauth.go (@carol):\nAnd log it"
+	if pr.ReviewComments != wantReview {
+		t.Errorf("ReviewComments = %q, want %q", pr.ReviewComments, wantReview)
+	}
+	if pr.DiffHunks != "auth.go\n@@ -10,3 +10,4 @@\n+if u == nil {" {
+		t.Errorf("expected the shared diff hunk once, got %q", pr.DiffHunks)
+	}
+
+	if items[1].HeadBranch != "patch-1" || items[1].Ref != "" {
+		t.Errorf("expected no checkout ref for a pull request from a fork, got %+v", items[1])
+	}
+}
+
+func TestGitHubPullRequestDiscoverFilters(t *testing.T) {
+	pulls := `[
+		{"number": 1, "state": "open", "draft": true, "user": {"login": "alice"}},
+		{"number": 2, "state": "open", "user": {"login": "alice"}, "labels": [{"name": "wip"}]},
+		{"number": 3, "state": "open", "user": {"login": "bob"}},
+		{"number": 4, "state": "open", "user": {"login": "Alice"}},
+		{"number": 5, "state": "open", "user": {"login": "alice"}}
+	]`
+	reviews := map[string]string{
+		// Changes requested, then approved by the same reviewer.
+		"/repos/owner/repo/pulls/4/reviews": `[{"user": {"login": "r1"}, "state": "CHANGES_REQUESTED"}, {"user": {"login": "r1"}, "state": "COMMENTED"}, {"user": {"login": "r1"}, "state": "APPROVED"}]`,
+		// Approved by one reviewer, changes requested by another.
+		"/repos/owner/repo/pulls/5/reviews": `[{"user": {"login": "r1"}, "state": "APPROVED"}, {"user": {"login": "r2"}, "state": "CHANGES_REQUESTED"}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/pulls" {
+			w.Write([]byte(pulls))
+			return
+		}
+		if body, ok := reviews[r.URL.Path]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	notDraft := false
+	tests := []struct {
+		name        string
+		reviewState string
+		wantIDs     string
+	}{
+		{name: "no review filter", wantIDs: "4,5"},
+		{name: "approved", reviewState: ReviewStateApproved, wantIDs: "4"},
+		{name: "changes requested", reviewState: ReviewStateChangesRequested, wantIDs: "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &GitHubPullRequestSource{
+				GitHubSource: GitHubSource{Owner: "owner", Repo: "repo", ExcludeLabels: []string{"wip"}, BaseURL: server.URL},
+				ReviewState:  tt.reviewState,
+				Draft:        &notDraft,
+				Author:       "alice",
+			}
+			items, err := s.Discover(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantIDs {
+				t.Errorf("got items %q, want %q", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestGitHubPullRequestDiscoverPaginatesReviews(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch {
+		case r.URL.Path == "/repos/owner/repo/pulls":
+			w.Write([]byte(`[{"number": 1, "state": "open", "user": {"login": "alice"}}]`))
+		case r.URL.Path == "/repos/owner/repo/pulls/1/reviews" && page == "":
+			w.Header().Set("Link", "<"+server.URL+"/repos/owner/repo/pulls/1/reviews?page=2>; rel=\"next\"")
+			w.Write([]byte(`[{"user": {"login": "r1"}, "state": "CHANGES_REQUESTED"}]`))
+		case r.URL.Path == "/repos/owner/repo/pulls/1/reviews":
+			w.Write([]byte(`[{"user": {"login": "r1"}, "state": "APPROVED"}]`))
+		case r.URL.Path == "/repos/owner/repo/pulls/1/comments" && page == "":
+			w.Header().Set("Link", "<"+server.URL+"/repos/owner/repo/pulls/1/comments?page=2>; rel=\"next\"")
+			w.Write([]byte(`[{"body": "First", "path": "a.go", "user": {"login": "r1"}}]`))
+		case r.URL.Path == "/repos/owner/repo/pulls/1/comments":
+			w.Write([]byte(`[{"body": "Latest", "path": "b.go", "diff_hunk": "@@ -1 +1 @@", "user": {"login": "r1"}}]`))
+		default:
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	s := &GitHubPullRequestSource{
+		GitHubSource: GitHubSource{Owner: "owner", Repo: "repo", BaseURL: server.URL},
+		ReviewState:  ReviewStateApproved,
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected the pull request approved on the second page of reviews, got %d items", len(items))
+	}
+	if want := "a.go (@r1):\nFirst\n---\nb.go (@r1):\nLatest"; items[0].ReviewComments != want {
+		t.Errorf("ReviewComments = %q, want %q", items[0].ReviewComments, want)
+	}
+	if want := "b.go\n@@ -1 +1 @@"; items[0].DiffHunks != want {
+		t.Errorf("DiffHunks = %q, want %q", items[0].DiffHunks, want)
+	}
+}
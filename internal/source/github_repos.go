@@ -0,0 +1,280 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxRepoKeyNameLength bounds the length of the repository name in the
+// key prefixed to the IDs of the items of a repository, to keep the names
+// of their Tasks short.
+const maxRepoKeyNameLength = 20
+
+// GitHubReposSource discovers items from several GitHub repositories: the
+// ones listed in Repos and the repositories of the organization Org whose
+// name matches RepoPattern. Each repository is discovered by the source
+// returned by NewSource. The IDs of the items are prefixed with a key
+// derived from their repository, so that they stay unique across
+// repositories, and their Repo is set.
+type GitHubReposSource struct {
+	// GitHubSource provides the credentials, API client and Cache used to
+	// list the repositories of Org. Its repository and filters are ignored.
+	GitHubSource
+
+	// Repos lists repositories as "owner/name".
+	Repos []string
+	// Org, if set, also selects the repositories of this organization,
+	// except archived ones.
+	Org string
+	// RepoPattern, if set, is a regular expression that the names of the
+	// repositories of Org must match.
+	RepoPattern string
+
+	// NewSource returns the source that discovers the items of repository
+	// owner/repo.
+	NewSource func(owner, repo string) Source
+
+	// sources are the sources of the repositories of the last discovery.
+	sources []Source
+	// repoErrs are the errors of the repositories whose discovery failed
+	// in the last discovery.
+	repoErrs []error
+}
+
+type githubRepository struct {
+	Name     string     `json:"name"`
+	Owner    githubUser `json:"owner"`
+	Archived bool       `json:"archived"`
+}
+
+// githubAPIStats is implemented by the sources that record the state of
+// the GitHub API.
+type githubAPIStats interface {
+	RateLimit() (RateLimit, bool)
+	APIErrors() int
+}
+
+// Discover discovers the items of each selected repository. A repository
+// that cannot be discovered, for example because it was renamed or the
+// token lost access to it, does not prevent the discovery of the others:
+// its error is recorded for RepoErrors and its items are left out. Discover
+// only fails if every repository failed or the rate limit is exhausted.
+func (s *GitHubReposSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	if err := s.Cache.checkRateLimit(time.Now()); err != nil {
+		return nil, err
+	}
+
+	repos, err := s.repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sources = nil
+	s.repoErrs = nil
+	var items []WorkItem
+	for _, repo := range repos {
+		owner, name, _ := strings.Cut(repo, "/")
+		src := s.NewSource(owner, name)
+		s.sources = append(s.sources, src)
+		repoItems, err := src.Discover(ctx)
+		if err != nil {
+			// The remaining repositories would hit the same limit.
+			var rateLimitErr *RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				return nil, err
+			}
+			s.repoErrs = append(s.repoErrs, fmt.Errorf("discovering items of %s: %w", repo, err))
+			continue
+		}
+		for i := range repoItems {
+			setRepo(&repoItems[i], owner, name)
+		}
+		items = append(items, repoItems...)
+	}
+	if len(repos) > 0 && len(s.repoErrs) == len(repos) {
+		return nil, errors.Join(s.repoErrs...)
+	}
+	return items, nil
+}
+
+// RepoErrors returns the errors of the repositories that could not be
+// discovered by the last call to Discover. Their items were left out of
+// its result.
+func (s *GitHubReposSource) RepoErrors() []error {
+	return s.repoErrs
+}
+
+// ItemFromWebhook converts a GitHub webhook delivery into a WorkItem with
+// the source of its repository, if the repository is selected and the
+// source handles webhook deliveries. It returns nil otherwise.
+func (s *GitHubReposSource) ItemFromWebhook(ctx context.Context, event string, payload []byte) (*WorkItem, error) {
+	if !IsSupportedGitHubEvent(event) {
+		return nil, nil
+	}
+
+	var p githubWebhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("decoding %s event: %w", event, err)
+	}
+	owner, name := p.Repository.Owner.Login, p.Repository.Name
+	selected, err := s.selects(owner, name)
+	if err != nil || !selected {
+		return nil, err
+	}
+
+	src, ok := s.NewSource(owner, name).(interface {
+		ItemFromWebhook(ctx context.Context, event string, payload []byte) (*WorkItem, error)
+	})
+	if !ok {
+		return nil, nil
+	}
+	item, err := src.ItemFromWebhook(ctx, event, payload)
+	if err != nil || item == nil {
+		return nil, err
+	}
+	setRepo(item, owner, name)
+	return item, nil
+}
+
+// RateLimitRemaining returns the number of requests remaining in the
+// current GitHub API rate limit window, as reported by the last response,
+// and whether it is known.
+func (s *GitHubReposSource) RateLimitRemaining() (int, bool) {
+	rl, ok := s.RateLimit()
+	return rl.Remaining, ok
+}
+
+// RateLimit returns the GitHub API rate limit reported by the last
+// response, and whether it is known.
+func (s *GitHubReposSource) RateLimit() (RateLimit, bool) {
+	rl, known := s.GitHubSource.RateLimit()
+	for _, src := range s.sources {
+		if stats, ok := src.(githubAPIStats); ok {
+			if srcRL, ok := stats.RateLimit(); ok {
+				rl, known = srcRL, true
+			}
+		}
+	}
+	return rl, known
+}
+
+// APIErrors returns the number of GitHub API requests that failed or
+// returned an error status, across all repositories.
+func (s *GitHubReposSource) APIErrors() int {
+	n := s.GitHubSource.APIErrors()
+	for _, src := range s.sources {
+		if stats, ok := src.(githubAPIStats); ok {
+			n += stats.APIErrors()
+		}
+	}
+	return n
+}
+
+// repositories returns the selected repositories as "owner/name", without
+// duplicates.
+func (s *GitHubReposSource) repositories(ctx context.Context) ([]string, error) {
+	var repos []string
+	seen := make(map[string]struct{})
+	add := func(owner, name string) {
+		key := strings.ToLower(owner + "/" + name)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		repos = append(repos, owner+"/"+name)
+	}
+
+	for _, repo := range s.Repos {
+		owner, name, ok := strings.Cut(repo, "/")
+		if !ok || owner == "" || name == "" {
+			return nil, fmt.Errorf("invalid repository %q: must be owner/name", repo)
+		}
+		add(owner, name)
+	}
+
+	if s.Org == "" {
+		return repos, nil
+	}
+	pattern, err := s.repoPattern()
+	if err != nil {
+		return nil, err
+	}
+	pageURL := fmt.Sprintf("%s/orgs/%s/repos?%s", s.baseURL(), url.PathEscape(s.Org), url.Values{"per_page": {"100"}, "type": {"all"}}.Encode())
+	for page := 0; pageURL != "" && page < maxPages; page++ {
+		var orgRepos []githubRepository
+		next, err := s.getJSON(ctx, pageURL, &orgRepos)
+		if err != nil {
+			return nil, fmt.Errorf("listing repositories of %s: %w", s.Org, err)
+		}
+		for _, r := range orgRepos {
+			if !r.Archived && pattern.MatchString(r.Name) {
+				add(r.Owner.Login, r.Name)
+			}
+		}
+		pageURL = next
+	}
+	return repos, nil
+}
+
+// selects reports whether repository owner/name is selected, without
+// checking whether it is archived.
+func (s *GitHubReposSource) selects(owner, name string) (bool, error) {
+	for _, repo := range s.Repos {
+		if strings.EqualFold(repo, owner+"/"+name) {
+			return true, nil
+		}
+	}
+	if s.Org == "" || !strings.EqualFold(owner, s.Org) {
+		return false, nil
+	}
+	pattern, err := s.repoPattern()
+	if err != nil {
+		return false, err
+	}
+	return pattern.MatchString(name), nil
+}
+
+func (s *GitHubReposSource) repoPattern() (*regexp.Regexp, error) {
+	pattern, err := regexp.Compile(s.RepoPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository pattern %q: %w", s.RepoPattern, err)
+	}
+	return pattern, nil
+}
+
+// setRepo records the repository owner/name on item and prefixes its ID
+// with the key of the repository.
+func setRepo(item *WorkItem, owner, name string) {
+	item.Repo = owner + "/" + name
+	item.ID = RepoKey(owner, name) + "-" + item.ID
+}
+
+// RepoKey returns a key for repository owner/name that can be used in
+// Kubernetes names: its lowercased, truncated name followed by a hash of
+// owner/name that keeps it unique across owners and names that only
+// differ in characters Kubernetes names do not allow.
+func RepoKey(owner, name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(owner + "/" + name)))
+
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+	if len(prefix) > maxRepoKeyNameLength {
+		prefix = prefix[:maxRepoKeyNameLength]
+	}
+	if prefix = strings.Trim(prefix, "-"); prefix == "" {
+		return fmt.Sprintf("%08x", h.Sum32())
+	}
+	return fmt.Sprintf("%s-%08x", prefix, h.Sum32())
+}
@@ -0,0 +1,151 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newReposTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/axon-core/repos":
+			json.NewEncoder(w).Encode([]githubRepository{
+				{Name: "service-api", Owner: githubUser{Login: "axon-core"}},
+				{Name: "service-old", Owner: githubUser{Login: "axon-core"}, Archived: true},
+				{Name: "website", Owner: githubUser{Login: "axon-core"}},
+			})
+		case "/repos/axon-core/service-api/issues", "/repos/other/lib/issues":
+			json.NewEncoder(w).Encode([]githubIssue{{Number: 1, Title: "Bug in " + r.URL.Path}})
+		default:
+			json.NewEncoder(w).Encode([]githubComment{})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestReposSource(baseURL string) *GitHubReposSource {
+	return &GitHubReposSource{
+		GitHubSource: GitHubSource{BaseURL: baseURL},
+		Repos:        []string{"other/lib", "axon-core/service-api"},
+		Org:          "axon-core",
+		RepoPattern:  "^service-",
+		NewSource: func(owner, repo string) Source {
+			return &GitHubSource{Owner: owner, Repo: repo, BaseURL: baseURL}
+		},
+	}
+}
+
+func TestGitHubReposSourceDiscover(t *testing.T) {
+	server := newReposTestServer(t)
+	s := newTestReposSource(server.URL)
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected one item from each of the two selected repositories, got %d: %+v", len(items), items)
+	}
+
+	want := []struct {
+		repo, id string
+	}{
+		{repo: "other/lib", id: RepoKey("other", "lib") + "-1"},
+		{repo: "axon-core/service-api", id: RepoKey("axon-core", "service-api") + "-1"},
+	}
+	for i, w := range want {
+		if items[i].Repo != w.repo || items[i].ID != w.id || items[i].Number != 1 {
+			t.Errorf("item %d: expected repo %q and ID %q, got %+v", i, w.repo, w.id, items[i])
+		}
+	}
+}
+
+func TestGitHubReposSourceDiscoverSkipsFailingRepository(t *testing.T) {
+	server := newReposTestServer(t)
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	s := newTestReposSource(server.URL)
+	s.NewSource = func(owner, repo string) Source {
+		if repo == "lib" {
+			return &GitHubSource{Owner: owner, Repo: repo, BaseURL: notFound.URL}
+		}
+		return &GitHubSource{Owner: owner, Repo: repo, BaseURL: server.URL}
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Repo != "axon-core/service-api" {
+		t.Fatalf("expected the item of the healthy repository, got %+v", items)
+	}
+	if errs := s.RepoErrors(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "other/lib") {
+		t.Errorf("expected the error of other/lib, got %v", errs)
+	}
+
+	s.NewSource = func(owner, repo string) Source {
+		return &GitHubSource{Owner: owner, Repo: repo, BaseURL: notFound.URL}
+	}
+	if _, err := s.Discover(context.Background()); err == nil {
+		t.Error("expected an error when every repository fails")
+	}
+}
+
+func TestGitHubReposSourceItemFromWebhook(t *testing.T) {
+	server := newReposTestServer(t)
+	s := newTestReposSource(server.URL)
+
+	tests := []struct {
+		name    string
+		owner   string
+		repo    string
+		wantNil bool
+	}{
+		{name: "listed repository", owner: "other", repo: "lib"},
+		{name: "organization repository", owner: "axon-core", repo: "service-billing"},
+		{name: "repository not matching the pattern", owner: "axon-core", repo: "website", wantNil: true},
+		{name: "other owner", owner: "someone", repo: "service-api", wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := `{"action":"opened","issue":{"number":7,"title":"Bug","state":"open"},"repository":{"name":"` + tt.repo + `","owner":{"login":"` + tt.owner + `"}}}`
+			item, err := s.ItemFromWebhook(context.Background(), GitHubEventIssues, []byte(payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil {
+				if item != nil {
+					t.Errorf("expected no item, got %+v", item)
+				}
+				return
+			}
+			if item == nil || item.Repo != tt.owner+"/"+tt.repo || item.ID != RepoKey(tt.owner, tt.repo)+"-7" {
+				t.Errorf("unexpected item %+v", item)
+			}
+		})
+	}
+}
+
+func TestRepoKey(t *testing.T) {
+	key := RepoKey("axon-core", "Axon.Docs")
+	if !strings.HasPrefix(key, "axon-docs-") || len(key) != len("axon-docs-")+8 {
+		t.Errorf("unexpected key %q", key)
+	}
+	if RepoKey("Axon-Core", "axon.docs") != key {
+		t.Error("expected keys to be case-insensitive")
+	}
+	for _, other := range []string{RepoKey("axon-core", "axon-docs"), RepoKey("axon", "core-axon.docs")} {
+		if other == key {
+			t.Errorf("expected repositories with similar names to have different keys, got %q", other)
+		}
+	}
+	if long := RepoKey("axon-core", strings.Repeat("a", 100)); len(long) != maxRepoKeyNameLength+9 {
+		t.Errorf("expected long names to be truncated, got %q", long)
+	}
+}
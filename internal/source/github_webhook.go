@@ -0,0 +1,140 @@
+package source
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GitHub webhook event types that are turned into work items.
+const (
+	GitHubEventIssues            = "issues"
+	GitHubEventIssueComment      = "issue_comment"
+	GitHubEventPullRequest       = "pull_request"
+	GitHubEventPullRequestReview = "pull_request_review"
+)
+
+// githubWebhookPayload holds the fields of a webhook delivery that are
+// common to the supported event types.
+type githubWebhookPayload struct {
+	Issue       *githubIssue       `json:"issue"`
+	PullRequest *githubPullRequest `json:"pull_request"`
+	Repository  struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+// IsSupportedGitHubEvent reports whether deliveries of the given
+// X-GitHub-Event type can be turned into work items.
+func IsSupportedGitHubEvent(event string) bool {
+	switch event {
+	case GitHubEventIssues, GitHubEventIssueComment, GitHubEventPullRequest, GitHubEventPullRequestReview:
+		return true
+	}
+	return false
+}
+
+// VerifyGitHubSignature reports whether signature, the value of the
+// X-Hub-Signature-256 header of a delivery, is the HMAC-SHA256 of payload
+// keyed with secret.
+func VerifyGitHubSignature(secret, payload []byte, signature string) bool {
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	sum, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
+
+// ItemFromWebhook converts a GitHub webhook delivery of the given event type
+// into a WorkItem, fetching the comments of the issue or pull request. It
+// returns nil if the event is not supported, concerns another repository,
+// or the item does not pass the filters of the source.
+func (s *GitHubSource) ItemFromWebhook(ctx context.Context, event string, payload []byte) (*WorkItem, error) {
+	if !IsSupportedGitHubEvent(event) {
+		return nil, nil
+	}
+
+	var p githubWebhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("decoding %s event: %w", event, err)
+	}
+	if !strings.EqualFold(p.Repository.Owner.Login, s.Owner) || !strings.EqualFold(p.Repository.Name, s.Repo) {
+		return nil, nil
+	}
+
+	var issue githubIssue
+	switch {
+	case p.Issue != nil:
+		issue = *p.Issue
+	case p.PullRequest != nil:
+		issue = githubIssue{
+			Number:            p.PullRequest.Number,
+			Title:             p.PullRequest.Title,
+			Body:              p.PullRequest.Body,
+			HTMLURL:           p.PullRequest.HTMLURL,
+			State:             p.PullRequest.State,
+			CreatedAt:         p.PullRequest.CreatedAt,
+			UpdatedAt:         p.PullRequest.UpdatedAt,
+			Labels:            p.PullRequest.Labels,
+			PullRequest:       &struct{}{},
+			User:              p.PullRequest.User,
+			AuthorAssociation: p.PullRequest.AuthorAssociation,
+		}
+	default:
+		return nil, fmt.Errorf("%s event has neither an issue nor a pull request", event)
+	}
+
+	if !s.matchesState(issue) || !s.hasLabels(issue) || len(s.filterItems([]githubIssue{issue})) == 0 {
+		return nil, nil
+	}
+
+	if err := s.resolveIgnoredAuthors(ctx); err != nil {
+		return nil, err
+	}
+	item, err := s.workItem(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// matchesState reports whether issue matches the State filter, which the
+// GitHub API applies when polling.
+func (s *GitHubSource) matchesState(issue githubIssue) bool {
+	switch s.State {
+	case "all":
+		return true
+	case "", "open":
+		return issue.State == "open"
+	default:
+		return issue.State == s.State
+	}
+}
+
+// hasLabels reports whether issue has all the labels of the Labels filter,
+// which the GitHub API applies when polling.
+func (s *GitHubSource) hasLabels(issue githubIssue) bool {
+	have := make(map[string]struct{}, len(issue.Labels))
+	for _, l := range issue.Labels {
+		have[l.Name] = struct{}{}
+	}
+	for _, l := range s.Labels {
+		if _, ok := have[l]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package source
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	secret := []byte("s3cr3t")
+	payload := []byte(`{"action":"opened"}`)
+
+	if !VerifyGitHubSignature(secret, payload, sign(secret, payload)) {
+		t.Error("expected valid signature to verify")
+	}
+	if VerifyGitHubSignature(secret, payload, sign([]byte("other"), payload)) {
+		t.Error("expected signature with another secret to fail")
+	}
+	if VerifyGitHubSignature(secret, []byte(`{"action":"closed"}`), sign(secret, payload)) {
+		t.Error("expected signature of another payload to fail")
+	}
+	for _, sig := range []string{"", "sha1=abc", "sha256=not-hex"} {
+		if VerifyGitHubSignature(secret, payload, sig) {
+			t.Errorf("expected malformed signature %q to fail", sig)
+		}
+	}
+}
+
+func TestItemFromWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues/7/comments":
+			json.NewEncoder(w).Encode([]githubComment{{Body: "please fix"}})
+		default:
+			json.NewEncoder(w).Encode([]githubComment{})
+		}
+	}))
+	defer server.Close()
+
+	issuePayload := `{
+		"action": "labeled",
+		"issue": {"number": 7, "title": "Crash", "body": "It crashes", "html_url": "https://github.com/owner/repo/issues/7", "state": "open", "labels": [{"name": "bug"}],
+		          "user": {"login": "alice"}, "author_association": "NONE"},
+		"repository": {"name": "repo", "owner": {"login": "Owner"}}
+	}`
+	prPayload := `{
+		"action": "opened",
+		"pull_request": {"number": 8, "title": "Fix", "body": "Fixes #7", "html_url": "https://github.com/owner/repo/pull/8", "state": "open", "labels": [{"name": "bug"}],
+		                 "user": {"login": "bob"}, "author_association": "MEMBER"},
+		"repository": {"name": "repo", "owner": {"login": "owner"}}
+	}`
+
+	tests := []struct {
+		name     string
+		source   GitHubSource
+		event    string
+		payload  string
+		wantID   string
+		wantKind string
+	}{
+		{
+			name:     "issue event",
+			event:    GitHubEventIssues,
+			payload:  issuePayload,
+			wantID:   "7",
+			wantKind: "Issue",
+		},
+		{
+			name:     "issue comment event",
+			event:    GitHubEventIssueComment,
+			payload:  issuePayload,
+			wantID:   "7",
+			wantKind: "Issue",
+		},
+		{
+			name:     "pull request event",
+			source:   GitHubSource{Types: []string{"pulls"}},
+			event:    GitHubEventPullRequest,
+			payload:  prPayload,
+			wantID:   "8",
+			wantKind: "PR",
+		},
+		{
+			name:    "pull request filtered by type",
+			event:   GitHubEventPullRequestReview,
+			payload: prPayload,
+		},
+		{
+			name:    "unsupported event",
+			event:   "push",
+			payload: issuePayload,
+		},
+		{
+			name:    "missing label",
+			source:  GitHubSource{Labels: []string{"bug", "axon"}},
+			event:   GitHubEventIssues,
+			payload: issuePayload,
+		},
+		{
+			name:    "excluded label",
+			source:  GitHubSource{ExcludeLabels: []string{"bug"}},
+			event:   GitHubEventIssues,
+			payload: issuePayload,
+		},
+		{
+			name:    "author association filter",
+			source:  GitHubSource{AuthorAssociations: []string{"OWNER", "MEMBER"}},
+			event:   GitHubEventIssues,
+			payload: issuePayload,
+		},
+		{
+			name:     "pull request by member",
+			source:   GitHubSource{Types: []string{"pulls"}, AuthorAssociations: []string{"OWNER", "MEMBER"}},
+			event:    GitHubEventPullRequest,
+			payload:  prPayload,
+			wantID:   "8",
+			wantKind: "PR",
+		},
+		{
+			name:    "closed state filter",
+			source:  GitHubSource{State: "closed"},
+			event:   GitHubEventIssues,
+			payload: issuePayload,
+		},
+		{
+			name:   "other repository",
+			source: GitHubSource{Repo: "other"},
+			event:  GitHubEventIssues,
+			payload: `{
+				"issue": {"number": 7, "state": "open"},
+				"repository": {"name": "repo", "owner": {"login": "owner"}}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.source
+			s.Owner = "owner"
+			if s.Repo == "" {
+				s.Repo = "repo"
+			}
+			s.BaseURL = server.URL
+
+			item, err := s.ItemFromWebhook(context.Background(), tt.event, []byte(tt.payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantID == "" {
+				if item != nil {
+					t.Fatalf("expected no item, got %+v", item)
+				}
+				return
+			}
+			if item == nil {
+				t.Fatal("expected an item, got nil")
+			}
+			if item.ID != tt.wantID || item.Kind != tt.wantKind {
+				t.Errorf("expected item %s of kind %s, got %+v", tt.wantID, tt.wantKind, item)
+			}
+			if tt.wantID == "7" && item.Comments != "please fix" {
+				t.Errorf("expected comments to be fetched, got %q", item.Comments)
+			}
+			if tt.wantID == "7" && item.Author != "alice" {
+				t.Errorf("expected author alice, got %q", item.Author)
+			}
+		})
+	}
+}
+
+func TestItemFromWebhookInvalidPayload(t *testing.T) {
+	s := &GitHubSource{Owner: "owner", Repo: "repo"}
+	if _, err := s.ItemFromWebhook(context.Background(), GitHubEventIssues, []byte("not json")); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+}
@@ -0,0 +1,256 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultGitLabBaseURL = "https://gitlab.com/api/v4"
+
+// GitLabSource discovers issues and merge requests from a GitLab project.
+type GitLabSource struct {
+	// Project is the path of the project, e.g. "group/subgroup/project".
+	Project       string
+	Types         []string
+	Labels        []string
+	ExcludeLabels []string
+	State         string
+	Token         string
+	BaseURL       string
+	Client        *http.Client
+}
+
+type gitlabItem struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	WebURL      string   `json:"web_url"`
+	State       string   `json:"state"`
+	Labels      []string `json:"labels"`
+	Author      struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Upvotes   int    `json:"upvotes"`
+}
+
+type gitlabNote struct {
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+}
+
+func (s *GitLabSource) baseURL() string {
+	if s.BaseURL != "" {
+		return strings.TrimSuffix(s.BaseURL, "/")
+	}
+	return defaultGitLabBaseURL
+}
+
+func (s *GitLabSource) httpClient() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// projectURL returns the API URL of the project, with the project path
+// URL-encoded as required by the GitLab API.
+func (s *GitLabSource) projectURL() string {
+	return s.baseURL() + "/projects/" + url.PathEscape(s.Project)
+}
+
+// Discover fetches issues and merge requests from GitLab and returns them as
+// WorkItems. Merge request IDs are prefixed with "mr-", since issues and
+// merge requests are numbered independently.
+func (s *GitLabSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	types := s.resolvedTypes()
+
+	var items []WorkItem
+	for _, t := range []string{"issues", "merge_requests"} {
+		if _, ok := types[t]; !ok {
+			continue
+		}
+		found, err := s.fetchAll(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range s.filterItems(found) {
+			item, err := s.workItem(ctx, t, it)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// workItem converts an issue or merge request into a WorkItem, fetching its
+// notes.
+func (s *GitLabSource) workItem(ctx context.Context, itemType string, it gitlabItem) (WorkItem, error) {
+	comments, err := s.fetchNotes(ctx, itemType, it.IID)
+	if err != nil {
+		return WorkItem{}, fmt.Errorf("fetching notes for %s !%d: %w", itemType, it.IID, err)
+	}
+
+	id := strconv.Itoa(it.IID)
+	kind := "Issue"
+	if itemType == "merge_requests" {
+		id = "mr-" + id
+		kind = "MR"
+	}
+
+	return WorkItem{
+		ID:        id,
+		Number:    it.IID,
+		Title:     it.Title,
+		Body:      it.Description,
+		URL:       it.WebURL,
+		Labels:    it.Labels,
+		Comments:  comments,
+		Kind:      kind,
+		Author:    it.Author.Username,
+		CreatedAt: it.CreatedAt,
+		UpdatedAt: it.UpdatedAt,
+		Reactions: it.Upvotes,
+	}, nil
+}
+
+func (s *GitLabSource) resolvedTypes() map[string]struct{} {
+	types := s.Types
+	if len(types) == 0 {
+		types = []string{"issues"}
+	}
+	m := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		m[t] = struct{}{}
+	}
+	return m
+}
+
+func (s *GitLabSource) filterItems(items []gitlabItem) []gitlabItem {
+	excluded := make(map[string]struct{}, len(s.ExcludeLabels))
+	for _, l := range s.ExcludeLabels {
+		excluded[l] = struct{}{}
+	}
+
+	filtered := make([]gitlabItem, 0, len(items))
+	for _, it := range items {
+		skip := false
+		for _, l := range it.Labels {
+			if _, ok := excluded[l]; ok {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			filtered = append(filtered, it)
+		}
+	}
+	return filtered
+}
+
+func (s *GitLabSource) fetchAll(ctx context.Context, itemType string) ([]gitlabItem, error) {
+	var all []gitlabItem
+
+	pageURL := s.buildListURL(itemType)
+
+	for page := 0; pageURL != "" && page < maxPages; page++ {
+		var items []gitlabItem
+		nextURL, err := s.get(ctx, pageURL, &items)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", itemType, err)
+		}
+		all = append(all, items...)
+		pageURL = nextURL
+	}
+
+	return all, nil
+}
+
+func (s *GitLabSource) buildListURL(itemType string) string {
+	params := url.Values{}
+	params.Set("per_page", "100")
+
+	state := s.State
+	if state == "" {
+		state = "opened"
+	}
+	params.Set("state", state)
+
+	if len(s.Labels) > 0 {
+		params.Set("labels", strings.Join(s.Labels, ","))
+	}
+
+	return s.projectURL() + "/" + itemType + "?" + params.Encode()
+}
+
+func (s *GitLabSource) fetchNotes(ctx context.Context, itemType string, iid int) (string, error) {
+	u := fmt.Sprintf("%s/%s/%d/notes?sort=asc&order_by=created_at&per_page=100", s.projectURL(), itemType, iid)
+
+	var notes []gitlabNote
+	for page := 0; u != "" && page < maxPages; page++ {
+		var pageNotes []gitlabNote
+		next, err := s.get(ctx, u, &pageNotes)
+		if err != nil {
+			return "", err
+		}
+		notes = append(notes, pageNotes...)
+		u = next
+	}
+
+	var parts []string
+	totalBytes := 0
+	for _, n := range notes {
+		// System notes record events such as label changes, not comments.
+		if n.System {
+			continue
+		}
+		totalBytes += len(n.Body)
+		if totalBytes > maxCommentBytes {
+			break
+		}
+		parts = append(parts, n.Body)
+	}
+
+	return strings.Join(parts, "\n---\n"), nil
+}
+
+// get fetches u from the GitLab API, decodes the JSON response into v and
+// returns the URL of the next page, if any.
+func (s *GitLabSource) get(ctx context.Context, u string, v any) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	if s.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", s.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("GitLab API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	return parseNextLink(resp.Header.Get("Link")), nil
+}
@@ -0,0 +1,164 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabDiscover(t *testing.T) {
+	issues := []gitlabItem{
+		{IID: 1, Title: "Bug", Description: "Crashes", WebURL: "https://gitlab.com/group/sub/project/-/issues/1", Labels: []string{"bug"}, CreatedAt: "2026-01-01T10:00:00.000Z", Upvotes: 2},
+		{IID: 2, Title: "Skip me", Labels: []string{"bug", "wontfix"}},
+	}
+	mrs := []gitlabItem{
+		{IID: 1, Title: "Fix bug", Description: "Closes #1", WebURL: "https://gitlab.com/group/sub/project/-/merge_requests/1", Labels: []string{"bug"}},
+	}
+
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat" {
+			t.Errorf("expected PRIVATE-TOKEN header, got %q", r.Header.Get("PRIVATE-TOKEN"))
+		}
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fsub%2Fproject/issues":
+			gotQuery = r.URL.RawQuery
+			json.NewEncoder(w).Encode(issues)
+		case "/projects/group%2Fsub%2Fproject/merge_requests":
+			json.NewEncoder(w).Encode(mrs)
+		case "/projects/group%2Fsub%2Fproject/issues/1/notes":
+			json.NewEncoder(w).Encode([]gitlabNote{{Body: "added ~bug label", System: true}, {Body: "still broken"}})
+		case "/projects/group%2Fsub%2Fproject/merge_requests/1/notes":
+			json.NewEncoder(w).Encode([]gitlabNote{{Body: "LGTM"}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := &GitLabSource{
+		Project:       "group/sub/project",
+		Types:         []string{"issues", "merge_requests"},
+		Labels:        []string{"bug"},
+		ExcludeLabels: []string{"wontfix"},
+		Token:         "glpat",
+		BaseURL:       server.URL,
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotQuery != "labels=bug&per_page=100&state=opened" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d: %+v", len(items), items)
+	}
+	if items[0].ID != "1" || items[0].Kind != "Issue" || items[0].Body != "Crashes" || items[0].Comments != "still broken" {
+		t.Errorf("unexpected issue item: %+v", items[0])
+	}
+	if items[0].CreatedAt != "2026-01-01T10:00:00.000Z" || items[0].Reactions != 2 {
+		t.Errorf("expected creation time and upvotes, got %q and %d", items[0].CreatedAt, items[0].Reactions)
+	}
+	if items[1].ID != "mr-1" || items[1].Kind != "MR" || items[1].Number != 1 || items[1].Comments != "LGTM" {
+		t.Errorf("unexpected merge request item: %+v", items[1])
+	}
+}
+
+func TestGitLabDiscoverTypesDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fproject/issues":
+			json.NewEncoder(w).Encode([]gitlabItem{{IID: 3, Title: "Issue"}})
+		case "/projects/group%2Fproject/issues/3/notes":
+			json.NewEncoder(w).Encode([]gitlabNote{})
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	s := &GitLabSource{Project: "group/project", BaseURL: server.URL}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "3" {
+		t.Errorf("expected only issue 3, got %+v", items)
+	}
+}
+
+func TestGitLabDiscoverPagination(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fproject/issues":
+			if r.URL.Query().Get("page") == "2" {
+				json.NewEncoder(w).Encode([]gitlabItem{{IID: 2}})
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/projects/group%%2Fproject/issues?page=2>; rel="next"`, serverURL))
+			json.NewEncoder(w).Encode([]gitlabItem{{IID: 1}})
+		default:
+			json.NewEncoder(w).Encode([]gitlabNote{})
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	s := &GitLabSource{Project: "group/project", BaseURL: server.URL}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("expected 2 items across pages, got %d", len(items))
+	}
+}
+
+func TestGitLabDiscoverNotesPagination(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fproject/issues":
+			json.NewEncoder(w).Encode([]gitlabItem{{IID: 1}})
+		case "/projects/group%2Fproject/issues/1/notes":
+			if r.URL.Query().Get("page") == "2" {
+				json.NewEncoder(w).Encode([]gitlabNote{{Body: "newest"}})
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/projects/group%%2Fproject/issues/1/notes?page=2>; rel="next"`, serverURL))
+			json.NewEncoder(w).Encode([]gitlabNote{{Body: "oldest"}})
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	s := &GitLabSource{Project: "group/project", BaseURL: server.URL, Types: []string{"issues"}}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Comments != "oldest\n---\nnewest" {
+		t.Errorf("expected the notes of both pages, got %+v", items)
+	}
+}
+
+func TestGitLabDiscoverAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"401 Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	s := &GitLabSource{Project: "group/project", BaseURL: server.URL}
+	if _, err := s.Discover(context.Background()); err == nil {
+		t.Fatal("expected error for API failure")
+	}
+}
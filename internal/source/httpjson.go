@@ -0,0 +1,366 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// HTTPJSONSource discovers items from an HTTP API returning JSON, using
+// JSONPath expressions to locate the items, the next page and the fields
+// of each item.
+type HTTPJSONSource struct {
+	URL string
+	// Headers are sent with every request to the scheme and host of URL,
+	// such as an Authorization header. They are not sent to next pages on
+	// other hosts.
+	Headers map[string]string
+	// ItemsPath selects the items in a response, as an array or several
+	// values. If empty, the response is the array of items.
+	ItemsPath string
+	// NextPath selects the URL of the next page. If empty, only the first
+	// page is fetched.
+	NextPath string
+	Fields   HTTPJSONFields
+	// Extra maps names to paths of additional fields, available in the
+	// prompt as {{.Extra.<name>}}.
+	Extra  map[string]string
+	Client *http.Client
+}
+
+// HTTPJSONFields holds the paths of the work item fields in an item.
+type HTTPJSONFields struct {
+	ID     string
+	Title  string
+	Body   string
+	URL    string
+	Labels string
+}
+
+// invalidIDChars matches the characters of an item ID that are not allowed
+// in Task names.
+var invalidIDChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// maxItemKeyIDLength bounds the length of the readable part of the keys
+// derived from item IDs, to keep the names of their Tasks short.
+const maxItemKeyIDLength = 30
+
+// ParseJSONPath parses a JSONPath expression as used by the HTTPJSON
+// source, with or without the enclosing braces (e.g. ".data.items").
+func ParseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New("path").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", path, err)
+	}
+	return jp, nil
+}
+
+func (s *HTTPJSONSource) httpClient() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// Discover fetches the items of every page, following NextPath.
+func (s *HTTPJSONSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	paths, err := s.parsePaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []WorkItem
+	pageURL := s.URL
+	seen := make(map[string]bool)
+	for page := 0; page < maxPages && pageURL != "" && !seen[pageURL]; page++ {
+		seen[pageURL] = true
+		resp, err := s.fetch(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := []any{resp}
+		if paths.items != nil {
+			if raw, err = findValues(paths.items, resp); err != nil {
+				return nil, fmt.Errorf("selecting items: %w", err)
+			}
+		}
+		for _, v := range raw {
+			elems, ok := v.([]any)
+			if !ok {
+				elems = []any{v}
+			}
+			for _, elem := range elems {
+				item, err := s.workItem(paths, elem)
+				if err != nil {
+					return nil, err
+				}
+				if item.ID != "" {
+					items = append(items, item)
+				}
+			}
+		}
+
+		if paths.next == nil {
+			break
+		}
+		next, err := findString(paths.next, resp)
+		if err != nil {
+			return nil, fmt.Errorf("selecting next page: %w", err)
+		}
+		if next == "" {
+			break
+		}
+		if pageURL, err = resolveURL(pageURL, next); err != nil {
+			return nil, fmt.Errorf("resolving next page %q: %w", next, err)
+		}
+	}
+
+	return items, nil
+}
+
+// httpJSONPaths holds the parsed paths of an HTTPJSONSource. Unset paths
+// are nil.
+type httpJSONPaths struct {
+	items, next                  *jsonpath.JSONPath
+	id, title, body, url, labels *jsonpath.JSONPath
+	extra                        map[string]*jsonpath.JSONPath
+}
+
+func (s *HTTPJSONSource) parsePaths() (*httpJSONPaths, error) {
+	paths := &httpJSONPaths{extra: make(map[string]*jsonpath.JSONPath)}
+	for _, p := range []struct {
+		path string
+		dst  **jsonpath.JSONPath
+	}{
+		{s.ItemsPath, &paths.items},
+		{s.NextPath, &paths.next},
+		{s.Fields.ID, &paths.id},
+		{s.Fields.Title, &paths.title},
+		{s.Fields.Body, &paths.body},
+		{s.Fields.URL, &paths.url},
+		{s.Fields.Labels, &paths.labels},
+	} {
+		if p.path == "" {
+			continue
+		}
+		jp, err := ParseJSONPath(p.path)
+		if err != nil {
+			return nil, err
+		}
+		*p.dst = jp
+	}
+	if paths.id == nil {
+		return nil, fmt.Errorf("no ID path configured")
+	}
+	for name, path := range s.Extra {
+		jp, err := ParseJSONPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("extra field %s: %w", name, err)
+		}
+		paths.extra[name] = jp
+	}
+	return paths, nil
+}
+
+func (s *HTTPJSONSource) workItem(paths *httpJSONPaths, elem any) (WorkItem, error) {
+	var item WorkItem
+	for _, f := range []struct {
+		path *jsonpath.JSONPath
+		dst  *string
+	}{
+		{paths.id, &item.ID},
+		{paths.title, &item.Title},
+		{paths.body, &item.Body},
+		{paths.url, &item.URL},
+	} {
+		if f.path == nil {
+			continue
+		}
+		v, err := findString(f.path, elem)
+		if err != nil {
+			return WorkItem{}, fmt.Errorf("selecting item field: %w", err)
+		}
+		*f.dst = v
+	}
+
+	if n, err := strconv.Atoi(item.ID); err == nil {
+		item.Number = n
+	}
+	if item.ID != "" {
+		item.ID = itemKey(item.ID)
+	}
+	item.Kind = "Item"
+
+	if paths.labels != nil {
+		values, err := findValues(paths.labels, elem)
+		if err != nil {
+			return WorkItem{}, fmt.Errorf("selecting item labels: %w", err)
+		}
+		for _, v := range values {
+			if list, ok := v.([]any); ok {
+				for _, l := range list {
+					item.Labels = append(item.Labels, jsonString(l))
+				}
+			} else if v != nil {
+				item.Labels = append(item.Labels, jsonString(v))
+			}
+		}
+	}
+
+	if len(paths.extra) > 0 {
+		item.Extra = make(map[string]string, len(paths.extra))
+		for name, jp := range paths.extra {
+			v, err := findString(jp, elem)
+			if err != nil {
+				return WorkItem{}, fmt.Errorf("selecting extra field %s: %w", name, err)
+			}
+			item.Extra[name] = v
+		}
+	}
+	return item, nil
+}
+
+// itemKey returns a key for an item ID that is valid in Task names: the
+// ID lowercased, with the characters not allowed in Task names replaced by
+// "-" and truncated, followed by a hash of the raw ID. The hash keeps IDs
+// that differ only in those characters, such as "OPS/7" and "ops_7",
+// apart, and since it ends the key, a key never looks like the name of a
+// retriggered Task of another item.
+func itemKey(id string) string {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+
+	prefix := invalidIDChars.ReplaceAllString(strings.ToLower(id), "-")
+	if len(prefix) > maxItemKeyIDLength {
+		prefix = prefix[:maxItemKeyIDLength]
+	}
+	if prefix = strings.Trim(prefix, "-"); prefix == "" {
+		return fmt.Sprintf("%08x", h.Sum32())
+	}
+	return fmt.Sprintf("%s-%08x", prefix, h.Sum32())
+}
+
+// findValues returns the values selected by jp in data.
+func findValues(jp *jsonpath.JSONPath, data any) ([]any, error) {
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+	var values []any
+	for _, r := range results {
+		for _, v := range r {
+			if v.IsValid() && !(v.Kind() == reflect.Interface && v.IsNil()) {
+				values = append(values, v.Interface())
+			}
+		}
+	}
+	return values, nil
+}
+
+// findString returns the first value selected by jp in data as a string,
+// or "" if it selects nothing.
+func findString(jp *jsonpath.JSONPath, data any) (string, error) {
+	values, err := findValues(jp, data)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return jsonString(values[0]), nil
+}
+
+// jsonString formats a decoded JSON value: strings as is, json.Numbers as
+// written in the response, other numbers without exponent, and objects
+// and arrays as JSON.
+func jsonString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// resolveURL resolves ref, which may be relative, against base.
+func resolveURL(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
+
+// sameOrigin reports whether u has the scheme and host of base, so that
+// the credentials configured for base may be sent to it.
+func sameOrigin(base, u string) bool {
+	b, err := url.Parse(base)
+	if err != nil {
+		return false
+	}
+	p, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(b.Scheme, p.Scheme) && strings.EqualFold(b.Host, p.Host)
+}
+
+func (s *HTTPJSONSource) fetch(ctx context.Context, pageURL string) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if sameOrigin(s.URL, pageURL) {
+		for name, value := range s.Headers {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching items: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("HTTP API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Numbers are decoded as json.Number so that large numeric IDs are
+	// not rounded.
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	var data any
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,185 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newHTTPJSONTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Write([]byte(`{"data": [
+				{"id": 101, "summary": "Disk full", "description": "db-1 is at 98%", "link": "https://tickets.example.com/101", "tags": [{"name": "ops"}, {"name": "urgent"}], "severity": "critical", "owner": {"team": "storage"}},
+				{"summary": "No ID"}
+			], "links": {"next": "/tickets?page=2"}}`))
+		case "2":
+			w.Write([]byte(`{"data": [{"id": "OPS/7", "summary": "Cert expiry", "tags": []}], "links": {"next": null}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPJSONDiscover(t *testing.T) {
+	server := newHTTPJSONTestServer(t)
+	s := &HTTPJSONSource{
+		URL:       server.URL + "/tickets",
+		Headers:   map[string]string{"X-Api-Key": "secret"},
+		ItemsPath: ".data",
+		NextPath:  "{.links.next}",
+		Fields: HTTPJSONFields{
+			ID:     ".id",
+			Title:  ".summary",
+			Body:   ".description",
+			URL:    ".link",
+			Labels: ".tags[*].name",
+		},
+		Extra: map[string]string{"severity": ".severity", "owner": ".owner", "missing": ".nope"},
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items with an ID across both pages, got %d: %+v", len(items), items)
+	}
+
+	first := items[0]
+	if first.ID != itemKey("101") || first.Number != 101 || first.Title != "Disk full" || first.Body != "db-1 is at 98%" || first.URL != "https://tickets.example.com/101" {
+		t.Errorf("unexpected item fields: %+v", first)
+	}
+	if !slices.Equal(first.Labels, []string{"ops", "urgent"}) {
+		t.Errorf("expected labels [ops urgent], got %v", first.Labels)
+	}
+	if first.Extra["severity"] != "critical" || first.Extra["owner"] != `{"team":"storage"}` || first.Extra["missing"] != "" {
+		t.Errorf("unexpected extra fields: %v", first.Extra)
+	}
+
+	if items[1].ID != itemKey("OPS/7") || !strings.HasPrefix(items[1].ID, "ops-7-") || items[1].Number != 0 || len(items[1].Labels) != 0 {
+		t.Errorf("expected a sanitized ID and no labels, got %+v", items[1])
+	}
+}
+
+func TestHTTPJSONDiscoverCrossHostNextPage(t *testing.T) {
+	var leaked string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("Authorization")
+		w.Write([]byte(`{"data": [{"id": "b"}]}`))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "a"}], "next": "` + other.URL + `/page2"}`))
+	}))
+	defer server.Close()
+
+	s := &HTTPJSONSource{
+		URL:       server.URL,
+		Headers:   map[string]string{"Authorization": "Bearer secret"},
+		ItemsPath: ".data",
+		NextPath:  ".next",
+		Fields:    HTTPJSONFields{ID: ".id"},
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected items from both hosts, got %+v", items)
+	}
+	if leaked != "" {
+		t.Errorf("expected the headers not to be sent to another host, got Authorization %q", leaked)
+	}
+}
+
+func TestHTTPJSONDiscoverRootArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"key": "a"}, {"key": "b"}]`))
+	}))
+	defer server.Close()
+
+	s := &HTTPJSONSource{URL: server.URL, Fields: HTTPJSONFields{ID: ".key"}}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != itemKey("a") || items[1].ID != itemKey("b") {
+		t.Errorf("unexpected items %+v", items)
+	}
+}
+
+func TestHTTPJSONDiscoverLargeNumericIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 9007199254740993, "score": 1.5}, {"id": 9007199254740992}]`))
+	}))
+	defer server.Close()
+
+	s := &HTTPJSONSource{URL: server.URL, Fields: HTTPJSONFields{ID: ".id"}, Extra: map[string]string{"score": ".score"}}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != itemKey("9007199254740993") || items[1].ID != itemKey("9007199254740992") {
+		t.Fatalf("expected distinct items for IDs above 2^53, got %+v", items)
+	}
+	if items[0].Number != 9007199254740993 || items[0].Extra["score"] != "1.5" {
+		t.Errorf("unexpected item fields: %+v", items[0])
+	}
+}
+
+func TestItemKey(t *testing.T) {
+	keys := make(map[string]string)
+	for _, id := range []string{"OPS/7", "ops_7", "Ops-7", "ops-7", "7", "7-2"} {
+		key := itemKey(id)
+		if !strings.HasPrefix(key, "ops-7-") && !strings.HasPrefix(key, "7-") {
+			t.Errorf("expected a readable prefix for %q, got %q", id, key)
+		}
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected distinct keys for %q and %q, got %q", id, other, key)
+		}
+		keys[key] = id
+	}
+
+	if key := itemKey("///"); len(key) != 8 {
+		t.Errorf("expected only a hash for an ID without allowed characters, got %q", key)
+	}
+	if long := itemKey(strings.Repeat("a", 100)); len(long) != maxItemKeyIDLength+9 {
+		t.Errorf("expected long IDs to be truncated, got %q", long)
+	}
+}
+
+func TestHTTPJSONDiscoverAPIError(t *testing.T) {
+	server := newHTTPJSONTestServer(t)
+	s := &HTTPJSONSource{URL: server.URL, Fields: HTTPJSONFields{ID: ".id"}}
+
+	_, err := s.Discover(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("expected an error with the status, got %v", err)
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	for _, path := range []string{".data", "{.data[*].id}", `.items[?(@.open==true)]`} {
+		if _, err := ParseJSONPath(path); err != nil {
+			t.Errorf("ParseJSONPath(%q): unexpected error: %v", path, err)
+		}
+	}
+	if _, err := ParseJSONPath(".data[unclosed"); err == nil {
+		t.Error("expected an error for an invalid path")
+	}
+}
@@ -0,0 +1,243 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// jiraPageSize is the number of issues requested per Jira search page.
+const jiraPageSize = 100
+
+// JiraSource discovers issues matching a JQL query from a Jira instance.
+type JiraSource struct {
+	BaseURL string
+	JQL     string
+	// Cloud selects the Jira Cloud search API (/rest/api/3/search/jql,
+	// paginated by nextPageToken) instead of the Jira Server and Data
+	// Center one (/rest/api/2/search, paginated by startAt).
+	Cloud bool
+	// User is the account email for Jira Cloud API tokens. If empty, Token
+	// is sent as a bearer personal access token.
+	User   string
+	Token  string
+	Client *http.Client
+}
+
+type jiraSearchResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jiraIssue `json:"issues"`
+	// NextPageToken and IsLast paginate Jira Cloud searches.
+	NextPageToken string `json:"nextPageToken"`
+	IsLast        bool   `json:"isLast"`
+}
+
+// jiraIssue is an issue of a search response. Description and comment
+// bodies are strings in the v2 API and Atlassian Document Format nodes in
+// the v3 API, and are converted to text with jiraText.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"`
+		Labels      []string        `json:"labels"`
+		Comment     struct {
+			Comments []jiraComment `json:"comments"`
+		} `json:"comment"`
+	} `json:"fields"`
+}
+
+type jiraComment struct {
+	Body json.RawMessage `json:"body"`
+}
+
+// adfNode is a node of an Atlassian Document Format document.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+	Attrs   struct {
+		Text string `json:"text"`
+		URL  string `json:"url"`
+	} `json:"attrs"`
+}
+
+func (s *JiraSource) httpClient() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// Discover runs the JQL query and returns the matching issues as WorkItems.
+// Issue keys are lowercased in IDs (e.g. "proj-123") so that they can be
+// used in Task names.
+func (s *JiraSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	var items []WorkItem
+
+	startAt := 0
+	pageToken := ""
+	for page := 0; page < maxPages; page++ {
+		resp, err := s.search(ctx, startAt, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range resp.Issues {
+			items = append(items, s.workItem(issue))
+		}
+		if s.Cloud {
+			pageToken = resp.NextPageToken
+			if resp.IsLast || pageToken == "" {
+				break
+			}
+			continue
+		}
+		startAt += len(resp.Issues)
+		if len(resp.Issues) == 0 || startAt >= resp.Total {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+func (s *JiraSource) workItem(issue jiraIssue) WorkItem {
+	var parts []string
+	totalBytes := 0
+	for _, c := range issue.Fields.Comment.Comments {
+		body := jiraText(c.Body)
+		totalBytes += len(body)
+		if totalBytes > maxCommentBytes {
+			break
+		}
+		parts = append(parts, body)
+	}
+
+	number := 0
+	if i := strings.LastIndex(issue.Key, "-"); i >= 0 {
+		number, _ = strconv.Atoi(issue.Key[i+1:])
+	}
+
+	return WorkItem{
+		ID:       strings.ToLower(issue.Key),
+		Number:   number,
+		Title:    issue.Fields.Summary,
+		Body:     jiraText(issue.Fields.Description),
+		URL:      strings.TrimSuffix(s.BaseURL, "/") + "/browse/" + issue.Key,
+		Labels:   issue.Fields.Labels,
+		Comments: strings.Join(parts, "\n---\n"),
+		Kind:     "Issue",
+	}
+}
+
+// search requests a page of issues, selected by startAt on Jira Server and
+// Data Center and by pageToken on Jira Cloud.
+func (s *JiraSource) search(ctx context.Context, startAt int, pageToken string) (*jiraSearchResponse, error) {
+	params := url.Values{}
+	params.Set("jql", s.JQL)
+	params.Set("maxResults", strconv.Itoa(jiraPageSize))
+	params.Set("fields", "summary,description,labels,comment")
+	path := "/rest/api/2/search"
+	if s.Cloud {
+		path = "/rest/api/3/search/jql"
+		if pageToken != "" {
+			params.Set("nextPageToken", pageToken)
+		}
+	} else {
+		params.Set("startAt", strconv.Itoa(startAt))
+	}
+	u := strings.TrimSuffix(s.BaseURL, "/") + path + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	if s.Token != "" {
+		if s.User != "" {
+			req.SetBasicAuth(s.User, s.Token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+s.Token)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("searching issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result jiraSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding search results: %w", err)
+	}
+	return &result, nil
+}
+
+// IsJiraCloudURL reports whether baseURL is the URL of a Jira Cloud site.
+func IsJiraCloudURL(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(strings.ToLower(u.Hostname()), ".atlassian.net")
+}
+
+// jiraText returns the plain text of a Jira rich text field, which is a
+// string in the v2 API and an Atlassian Document Format document in the v3
+// API.
+func jiraText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var b strings.Builder
+	writeADF(&b, &doc)
+	return strings.TrimSpace(b.String())
+}
+
+// writeADF writes the text of n and its children to b, ending block nodes
+// with a newline.
+func writeADF(b *strings.Builder, n *adfNode) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+		return
+	case "hardBreak":
+		b.WriteString("\n")
+		return
+	case "mention", "emoji":
+		b.WriteString(n.Attrs.Text)
+		return
+	case "inlineCard":
+		b.WriteString(n.Attrs.URL)
+		return
+	}
+	for i := range n.Content {
+		writeADF(b, &n.Content[i])
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock":
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,183 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/axon-core/axon/internal/source/jiratest"
+)
+
+func TestJiraDiscover(t *testing.T) {
+	server := jiratest.NewServer(
+		jiratest.Issue{Key: "PROJ-12", Summary: "Login fails", Description: "Steps to reproduce", Labels: []string{"axon"}, Comments: []string{"Seen on prod", "Also on staging"}},
+		jiratest.Issue{Key: "PROJ-7", Summary: "Add dark mode"},
+	)
+	defer server.Close()
+
+	s := &JiraSource{BaseURL: server.URL + "/", JQL: "project = PROJ AND labels = axon", Token: "pat"}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := server.Queries(); len(got) != 1 || got[0] != "project = PROJ AND labels = axon" {
+		t.Errorf("unexpected queries %q", got)
+	}
+	if got := server.Authorization(); got != "Bearer pat" {
+		t.Errorf("expected bearer authorization, got %q", got)
+	}
+	if got := server.Paths(); len(got) != 1 || got[0] != "/rest/api/2/search" {
+		t.Errorf("expected a search of the v2 API, got %q", got)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	want := WorkItem{
+		ID:       "proj-12",
+		Number:   12,
+		Title:    "Login fails",
+		Body:     "Steps to reproduce",
+		URL:      server.URL + "/browse/PROJ-12",
+		Labels:   []string{"axon"},
+		Comments: "Seen on prod\n---\nAlso on staging",
+		Kind:     "Issue",
+	}
+	if fmt.Sprintf("%+v", items[0]) != fmt.Sprintf("%+v", want) {
+		t.Errorf("item[0] = %+v, want %+v", items[0], want)
+	}
+	if items[1].ID != "proj-7" || items[1].Number != 7 {
+		t.Errorf("unexpected item[1]: %+v", items[1])
+	}
+}
+
+func TestJiraDiscoverBasicAuth(t *testing.T) {
+	server := jiratest.NewServer()
+	defer server.Close()
+
+	s := &JiraSource{BaseURL: server.URL, JQL: "project = PROJ", User: "bot@example.com", Token: "api-token"}
+	if _, err := s.Discover(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := &http.Request{Header: http.Header{"Authorization": {server.Authorization()}}}
+	user, token, ok := req.BasicAuth()
+	if !ok || user != "bot@example.com" || token != "api-token" {
+		t.Errorf("expected basic authorization, got %q", server.Authorization())
+	}
+}
+
+func TestJiraDiscoverPagination(t *testing.T) {
+	var issues []jiratest.Issue
+	for i := 1; i <= jiraPageSize+5; i++ {
+		issues = append(issues, jiratest.Issue{Key: fmt.Sprintf("PROJ-%d", i)})
+	}
+	server := jiratest.NewServer(issues...)
+	defer server.Close()
+
+	s := &JiraSource{BaseURL: server.URL, JQL: "project = PROJ"}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != jiraPageSize+5 {
+		t.Errorf("expected %d items, got %d", jiraPageSize+5, len(items))
+	}
+	if len(server.Queries()) != 2 {
+		t.Errorf("expected 2 search requests, got %d", len(server.Queries()))
+	}
+}
+
+func TestJiraDiscoverCloud(t *testing.T) {
+	issues := []jiratest.Issue{
+		{Key: "PROJ-1", Summary: "Login fails", Description: "Steps to reproduce\nOpen the app", Comments: []string{"Seen on prod"}},
+	}
+	for i := 2; i <= jiraPageSize+5; i++ {
+		issues = append(issues, jiratest.Issue{Key: fmt.Sprintf("PROJ-%d", i)})
+	}
+	server := jiratest.NewServer(issues...)
+	defer server.Close()
+
+	s := &JiraSource{BaseURL: server.URL, JQL: "project = PROJ", Cloud: true, User: "bot@example.com", Token: "api-token"}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paths := server.Paths()
+	if len(paths) != 2 || paths[0] != "/rest/api/3/search/jql" || paths[1] != "/rest/api/3/search/jql" {
+		t.Errorf("expected 2 searches of the v3 API, got %q", paths)
+	}
+	if len(items) != jiraPageSize+5 {
+		t.Fatalf("expected %d items, got %d", jiraPageSize+5, len(items))
+	}
+	if items[0].Body != "Steps to reproduce\nOpen the app" {
+		t.Errorf("expected the description as text, got %q", items[0].Body)
+	}
+	if items[0].Comments != "Seen on prod" {
+		t.Errorf("expected the comments as text, got %q", items[0].Comments)
+	}
+}
+
+func TestJiraText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "v2 string", raw: `"Steps to reproduce"`, want: "Steps to reproduce"},
+		{name: "null", raw: `null`, want: ""},
+		{
+			name: "document",
+			raw: `{"type": "doc", "version": 1, "content": [
+				{"type": "heading", "content": [{"type": "text", "text": "Crash"}]},
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "Reported by "},
+					{"type": "mention", "attrs": {"id": "1", "text": "@alice"}},
+					{"type": "hardBreak"},
+					{"type": "inlineCard", "attrs": {"url": "https://example.com/log"}}
+				]},
+				{"type": "bulletList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "on prod"}]}]}
+				]}
+			]}`,
+			want: "Crash\nReported by @alice\nhttps://example.com/log\non prod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jiraText([]byte(tt.raw)); got != tt.want {
+				t.Errorf("jiraText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsJiraCloudURL(t *testing.T) {
+	for url, want := range map[string]bool{
+		"https://example.atlassian.net":  true,
+		"https://Example.Atlassian.net/": true,
+		"https://jira.example.com":       false,
+		"https://atlassian.net.evil.com": false,
+	} {
+		if got := IsJiraCloudURL(url); got != want {
+			t.Errorf("IsJiraCloudURL(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
+
+func TestJiraDiscoverAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages":["Error in the JQL Query"]}`))
+	}))
+	defer server.Close()
+
+	s := &JiraSource{BaseURL: server.URL, JQL: "project ="}
+	if _, err := s.Discover(context.Background()); err == nil {
+		t.Fatal("expected error for API failure")
+	}
+}
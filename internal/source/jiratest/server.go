@@ -0,0 +1,168 @@
+// Package jiratest provides a local stand-in for the Jira REST API, for
+// testing the Jira source without a Jira instance.
+package jiratest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Issue is a Jira issue served by Server.
+type Issue struct {
+	Key         string
+	Summary     string
+	Description string
+	Labels      []string
+	Comments    []string
+}
+
+// Server serves the issue search endpoints of the Jira REST API from an
+// in-memory list of issues: the Jira Server and Data Center endpoint
+// (/rest/api/2/search), paginated by startAt and maxResults, and the Jira
+// Cloud endpoint (/rest/api/3/search/jql), paginated by nextPageToken and
+// maxResults, which returns descriptions and comments in Atlassian Document
+// Format. The JQL query is not evaluated: every search returns all issues.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	issues        []Issue
+	queries       []string
+	paths         []string
+	authorization string
+}
+
+// NewServer starts a Server serving issues. The caller must call Close
+// when finished.
+func NewServer(issues ...Issue) *Server {
+	s := &Server{issues: issues}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetIssues replaces the issues served by s.
+func (s *Server) SetIssues(issues ...Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues = issues
+}
+
+// Queries returns the JQL queries received by s, in order.
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+// Paths returns the URL paths of the searches received by s, in order.
+func (s *Server) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.paths...)
+}
+
+// Authorization returns the Authorization header of the last search.
+func (s *Server) Authorization() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authorization
+}
+
+type comment struct {
+	Body any `json:"body"`
+}
+
+type fields struct {
+	Summary     string   `json:"summary"`
+	Description any      `json:"description"`
+	Labels      []string `json:"labels"`
+	Comment     struct {
+		Comments []comment `json:"comments"`
+	} `json:"comment"`
+}
+
+type issue struct {
+	Key    string `json:"key"`
+	Fields fields `json:"fields"`
+}
+
+// adfDocument returns text as an Atlassian Document Format document with a
+// paragraph per line.
+func adfDocument(text string) map[string]any {
+	var paragraphs []any
+	for _, line := range strings.Split(text, "\n") {
+		paragraphs = append(paragraphs, map[string]any{
+			"type":    "paragraph",
+			"content": []any{map[string]any{"type": "text", "text": line}},
+		})
+	}
+	return map[string]any{"type": "doc", "version": 1, "content": paragraphs}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	cloud := r.URL.Path == "/rest/api/3/search/jql"
+	if !cloud && r.URL.Path != "/rest/api/2/search" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	s.queries = append(s.queries, r.URL.Query().Get("jql"))
+	s.paths = append(s.paths, r.URL.Path)
+	s.authorization = r.Header.Get("Authorization")
+	all := s.issues
+	s.mu.Unlock()
+
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	if cloud {
+		startAt, _ = strconv.Atoi(r.URL.Query().Get("nextPageToken"))
+	}
+	maxResults, err := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if err != nil || maxResults <= 0 {
+		maxResults = 50
+	}
+	startAt = min(max(startAt, 0), len(all))
+	end := min(startAt+maxResults, len(all))
+
+	page := make([]issue, 0, end-startAt)
+	for _, in := range all[startAt:end] {
+		out := issue{Key: in.Key}
+		out.Fields.Summary = in.Summary
+		out.Fields.Description = in.Description
+		if cloud {
+			out.Fields.Description = adfDocument(in.Description)
+		}
+		out.Fields.Labels = in.Labels
+		for _, c := range in.Comments {
+			var body any = c
+			if cloud {
+				body = adfDocument(c)
+			}
+			out.Fields.Comment.Comments = append(out.Fields.Comment.Comments, comment{Body: body})
+		}
+		page = append(page, out)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if cloud {
+		resp := map[string]any{
+			"issues": page,
+			"isLast": end >= len(all),
+		}
+		if end < len(all) {
+			resp["nextPageToken"] = strconv.Itoa(end)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(all),
+		"issues":     page,
+	})
+}
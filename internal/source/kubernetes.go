@@ -0,0 +1,327 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxObjectEvents is the number of most recent Events included with an
+	// item.
+	maxObjectEvents = 10
+	// maxLogPods is the number of Pods whose logs are included with an item
+	// for an object selecting Pods, such as a Deployment.
+	maxLogPods = 3
+	// maxLogBytes limits the logs of a container included with an item.
+	maxLogBytes = 16 * 1024
+	// maxObjectKeyNameLength limits the object name in item IDs, so that
+	// Task names stay short.
+	maxObjectKeyNameLength = 20
+)
+
+// KubernetesSource discovers objects of a kind in a namespace that match a
+// label selector and a field predicate. Objects of kind Event are turned
+// into items for the object they involve.
+type KubernetesSource struct {
+	Client client.Reader
+	// Clientset reads container logs. If nil, logs are not included.
+	Clientset kubernetes.Interface
+	Namespace string
+	GVK       schema.GroupVersionKind
+	// LabelSelector selects the objects. If nil, all objects are.
+	LabelSelector labels.Selector
+	// PredicatePath selects values of an object. If empty, all objects
+	// selected by LabelSelector match.
+	PredicatePath string
+	// PredicateValues are the values PredicatePath must select one of. If
+	// empty, it must select a non-empty value.
+	PredicateValues []string
+	// TailLines is the number of lines of logs per container. If zero,
+	// logs are not included.
+	TailLines int64
+
+	// skipped are the errors of the Events skipped in the last discovery
+	// because their involved object could not be read.
+	skipped []error
+}
+
+// Discover lists the objects and returns those matching the predicate as
+// WorkItems. IDs identify the object and its generation, or, for Events,
+// the involved object and the reason, so that an occurrence is discovered
+// once however often the object is listed.
+func (s *KubernetesSource) Discover(ctx context.Context) ([]WorkItem, error) {
+	s.skipped = nil
+	var predicate *jsonpath.JSONPath
+	if s.PredicatePath != "" {
+		jp, err := ParseJSONPath(s.PredicatePath)
+		if err != nil {
+			return nil, err
+		}
+		predicate = jp
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(s.GVK.GroupVersion().WithKind(s.GVK.Kind + "List"))
+	opts := []client.ListOption{client.InNamespace(s.Namespace)}
+	if s.LabelSelector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: s.LabelSelector})
+	}
+	if err := s.Client.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("listing %s: %w", s.GVK.Kind, err)
+	}
+
+	var events corev1.EventList
+	if err := s.Client.List(ctx, &events, client.InNamespace(s.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing Events: %w", err)
+	}
+	eventsByUID := make(map[string][]corev1.Event)
+	for _, e := range events.Items {
+		uid := string(e.InvolvedObject.UID)
+		eventsByUID[uid] = append(eventsByUID[uid], e)
+	}
+
+	var items []WorkItem
+	seen := make(map[string]bool)
+	for i := range list.Items {
+		obj := &list.Items[i]
+		var matched []string
+		if predicate != nil {
+			values, err := findValues(predicate, obj.Object)
+			if err != nil {
+				return nil, fmt.Errorf("evaluating predicate on %s %s: %w", s.GVK.Kind, obj.GetName(), err)
+			}
+			matched = s.match(values)
+			if len(matched) == 0 {
+				continue
+			}
+		}
+
+		item, err := s.workItem(ctx, obj, matched, eventsByUID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || seen[item.ID] {
+			continue
+		}
+		seen[item.ID] = true
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+// match returns the values matching the predicate.
+func (s *KubernetesSource) match(values []any) []string {
+	var matched []string
+	for _, v := range values {
+		str := jsonString(v)
+		if str == "" || slices.Contains(matched, str) {
+			continue
+		}
+		if len(s.PredicateValues) == 0 || slices.Contains(s.PredicateValues, str) {
+			matched = append(matched, str)
+		}
+	}
+	return matched
+}
+
+func (s *KubernetesSource) isEvent() bool {
+	return s.GVK.Group == "" && s.GVK.Kind == "Event"
+}
+
+// SkippedErrors returns the errors of the Events skipped by the last call
+// to Discover because the spawner may not read their involved object, such
+// as a Node, or does not know its kind.
+func (s *KubernetesSource) SkippedErrors() []error {
+	return s.skipped
+}
+
+// workItem returns the item for obj, or nil if obj is an Event whose
+// involved object no longer exists or cannot be read.
+func (s *KubernetesSource) workItem(ctx context.Context, obj *unstructured.Unstructured, matched []string, eventsByUID map[string][]corev1.Event) (*WorkItem, error) {
+	subject := obj
+	occurrence := fmt.Sprintf("%s/%d", obj.GetUID(), obj.GetGeneration())
+	summary := strings.Join(matched, ", ")
+	createdAt := obj.GetCreationTimestamp().Time
+
+	if s.isEvent() {
+		var event corev1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &event); err != nil {
+			return nil, fmt.Errorf("decoding Event %s: %w", obj.GetName(), err)
+		}
+		involved := &unstructured.Unstructured{}
+		involved.SetAPIVersion(event.InvolvedObject.APIVersion)
+		involved.SetKind(event.InvolvedObject.Kind)
+		key := client.ObjectKey{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}
+		if err := s.Client.Get(ctx, key, involved); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			err = fmt.Errorf("fetching %s %s of Event %s: %w", event.InvolvedObject.Kind, key.Name, obj.GetName(), err)
+			if apierrors.IsForbidden(err) || meta.IsNoMatchError(err) {
+				s.skipped = append(s.skipped, err)
+				return nil, nil
+			}
+			return nil, err
+		}
+		subject = involved
+		occurrence = fmt.Sprintf("%s/%s", event.InvolvedObject.UID, event.Reason)
+		summary = fmt.Sprintf("%s: %s", event.Reason, event.Message)
+		if t := eventTime(event); !t.IsZero() {
+			createdAt = t
+		}
+	}
+
+	title := fmt.Sprintf("%s %s", subject.GetKind(), subject.GetName())
+	if summary != "" {
+		title += ": " + summary
+	}
+
+	objectYAML, err := objectYAML(subject)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s %s: %w", subject.GetKind(), subject.GetName(), err)
+	}
+
+	item := &WorkItem{
+		ID:        objectKey(subject.GetKind(), subject.GetName(), occurrence),
+		Title:     title,
+		Body:      objectYAML,
+		Kind:      subject.GetKind(),
+		CreatedAt: createdAt.UTC().Format(time.RFC3339),
+		Object:    objectYAML,
+		Events:    formatEvents(eventsByUID[string(subject.GetUID())]),
+	}
+	if s.Clientset != nil && s.TailLines > 0 {
+		item.Logs = s.logs(ctx, subject)
+	}
+	return item, nil
+}
+
+// objectKey returns an ID for an occurrence of an object of kind named
+// name: the lowercased kind and name, truncated, and a hash of occurrence.
+func objectKey(kind, name, occurrence string) string {
+	short := invalidIDChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(short) > maxObjectKeyNameLength {
+		short = short[:maxObjectKeyNameLength]
+	}
+	short = strings.Trim(short, "-")
+	h := fnv.New32a()
+	h.Write([]byte(occurrence))
+	return fmt.Sprintf("%s-%s-%08x", strings.ToLower(kind), short, h.Sum32())
+}
+
+// objectYAML returns obj as YAML without its managed fields.
+func objectYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// eventTime returns the time an Event last occurred.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// formatEvents returns the most recent events, oldest first, one per line.
+func formatEvents(events []corev1.Event) string {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b corev1.Event) int {
+		return eventTime(a).Compare(eventTime(b))
+	})
+	if len(events) > maxObjectEvents {
+		events = events[len(events)-maxObjectEvents:]
+	}
+	var lines []string
+	for _, e := range events {
+		line := fmt.Sprintf("%s %s %s: %s", eventTime(e).UTC().Format(time.RFC3339), e.Type, e.Reason, e.Message)
+		if e.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logs returns the logs of the containers of obj if it is a Pod, or of up
+// to maxLogPods Pods matching its spec.selector.
+func (s *KubernetesSource) logs(ctx context.Context, obj *unstructured.Unstructured) string {
+	var pods []corev1.Pod
+	if obj.GetAPIVersion() == "v1" && obj.GetKind() == "Pod" {
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+			return ""
+		}
+		pods = append(pods, pod)
+	} else {
+		matchLabels, found, err := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+		if err != nil || !found || len(matchLabels) == 0 {
+			return ""
+		}
+		var list corev1.PodList
+		if err := s.Client.List(ctx, &list, client.InNamespace(obj.GetNamespace()), client.MatchingLabels(matchLabels)); err != nil {
+			return ""
+		}
+		pods = list.Items
+		slices.SortFunc(pods, func(a, b corev1.Pod) int { return strings.Compare(a.Name, b.Name) })
+		if len(pods) > maxLogPods {
+			pods = pods[:maxLogPods]
+		}
+	}
+
+	var parts []string
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			parts = append(parts, fmt.Sprintf("==> %s/%s <==\n%s", pod.Name, cs.Name, s.containerLogs(ctx, &pod, cs)))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// containerLogs returns the last lines of logs of a container, from its
+// previous instance if it restarted.
+func (s *KubernetesSource) containerLogs(ctx context.Context, pod *corev1.Pod, cs corev1.ContainerStatus) string {
+	tailLines := s.TailLines
+	limitBytes := int64(maxLogBytes)
+	req := s.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  cs.Name,
+		Previous:   cs.RestartCount > 0,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	})
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return fmt.Sprintf("(logs unavailable: %v)", err)
+	}
+	defer stream.Close()
+	data, err := io.ReadAll(io.LimitReader(stream, maxLogBytes))
+	if err != nil {
+		return fmt.Sprintf("(logs unavailable: %v)", err)
+	}
+	return strings.TrimRight(string(data), "\n")
+}
@@ -0,0 +1,243 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newTestPod(name, uid, waitingReason string, restarts int32) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: k8stypes.UID("uid-" + uid), Labels: map[string]string{"app": "web"}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+	}
+	return pod
+}
+
+func newTestEvent(name string, involved corev1.ObjectReference, reason, message string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: involved,
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(at),
+		Count:          3,
+	}
+}
+
+func TestKubernetesSourceDiscover(t *testing.T) {
+	crashing := newTestPod("web-1", "1", "CrashLoopBackOff", 4)
+	healthy := newTestPod("web-2", "2", "", 0)
+	other := newTestPod("db-1", "3", "CrashLoopBackOff", 1)
+	other.Labels = map[string]string{"app": "db"}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ref := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-1", UID: crashing.UID}
+	cl := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		crashing, healthy, other,
+		newTestEvent("web-1.a", ref, "BackOff", "Back-off restarting failed container", at),
+		newTestEvent("web-1.b", ref, "Pulled", "Image pulled", at.Add(-time.Minute)),
+	).Build()
+
+	s := &KubernetesSource{
+		Client:          cl,
+		Clientset:       kubefake.NewClientset(),
+		Namespace:       "default",
+		GVK:             schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		LabelSelector:   labels.SelectorFromSet(labels.Set{"app": "web"}),
+		PredicatePath:   ".status.containerStatuses[*].state.waiting.reason",
+		PredicateValues: []string{"CrashLoopBackOff"},
+		TailLines:       50,
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected only the crashing Pod with the selected label, got %d: %+v", len(items), items)
+	}
+
+	item := items[0]
+	if !strings.HasPrefix(item.ID, "pod-web-1-") || item.Kind != "Pod" || item.Title != "Pod web-1: CrashLoopBackOff" {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Object, "name: web-1") || item.Body != item.Object {
+		t.Errorf("expected the YAML of the Pod, got %q", item.Object)
+	}
+	wantEvents := "2026-03-01T11:59:00Z Warning Pulled: Image pulled (x3)\n2026-03-01T12:00:00Z Warning BackOff: Back-off restarting failed container (x3)"
+	if item.Events != wantEvents {
+		t.Errorf("Events = %q, want %q", item.Events, wantEvents)
+	}
+	if item.Logs != "==> web-1/app <==\nfake logs" {
+		t.Errorf("unexpected logs %q", item.Logs)
+	}
+
+	// Listing again yields the same ID, so the occurrence is deduplicated.
+	again, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(again) != 1 || again[0].ID != item.ID {
+		t.Errorf("expected the same item ID on every discovery, got %+v", again)
+	}
+}
+
+func TestKubernetesSourceDiscoverGenerations(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web", Generation: 3},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(deploy, newTestPod("web-1", "1", "", 0)).Build()
+	s := &KubernetesSource{
+		Client:          cl,
+		Clientset:       kubefake.NewClientset(),
+		Namespace:       "default",
+		GVK:             appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		PredicatePath:   `{.status.conditions[?(@.type=="Progressing")].reason}`,
+		PredicateValues: []string{"ProgressDeadlineExceeded"},
+		TailLines:       10,
+	}
+
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "Deployment web: ProgressDeadlineExceeded" {
+		t.Fatalf("unexpected items %+v", items)
+	}
+	if items[0].Logs != "==> web-1/app <==\nfake logs" {
+		t.Errorf("expected the logs of the Pods of the Deployment, got %q", items[0].Logs)
+	}
+
+	// A new rollout is a new occurrence.
+	var updated appsv1.Deployment
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(deploy), &updated); err != nil {
+		t.Fatal(err)
+	}
+	updated.Generation = 4
+	if err := cl.Update(context.Background(), &updated); err != nil {
+		t.Fatal(err)
+	}
+	next, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(next) != 1 || next[0].ID == items[0].ID {
+		t.Errorf("expected a new ID for a new generation, got %+v", next)
+	}
+}
+
+func TestKubernetesSourceDiscoverEvents(t *testing.T) {
+	pod := newTestPod("web-1", "1", "", 2)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ref := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-1", UID: pod.UID}
+	gone := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-0", UID: "uid-0"}
+	cl := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		pod,
+		newTestEvent("web-1.a", ref, "BackOff", "Back-off restarting failed container", at),
+		newTestEvent("web-1.b", ref, "BackOff", "Back-off restarting failed container", at.Add(time.Minute)),
+		newTestEvent("web-1.c", ref, "Pulled", "Image pulled", at),
+		newTestEvent("web-0.a", gone, "BackOff", "Back-off restarting failed container", at),
+	).Build()
+
+	s := &KubernetesSource{
+		Client:          cl,
+		Namespace:       "default",
+		GVK:             schema.GroupVersionKind{Version: "v1", Kind: "Event"},
+		PredicatePath:   ".reason",
+		PredicateValues: []string{"BackOff"},
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected a single item for the existing Pod, got %d: %+v", len(items), items)
+	}
+	item := items[0]
+	if !strings.HasPrefix(item.ID, "pod-web-1-") || item.Kind != "Pod" || item.Title != "Pod web-1: BackOff: Back-off restarting failed container" {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Object, "kind: Pod") || strings.Count(item.Events, "\n") != 2 || item.Logs != "" {
+		t.Errorf("expected the Pod YAML, its 3 Events and no logs without a clientset, got %+v", item)
+	}
+}
+
+func TestKubernetesSourceDiscoverEventsSkipsUnreadableObjects(t *testing.T) {
+	pod := newTestPod("web-1", "1", "", 2)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ref := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-1", UID: pod.UID}
+	node := corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: "node-1", UID: "uid-node-1"}
+	widget := corev1.ObjectReference{APIVersion: "example.com/v1", Kind: "Widget", Namespace: "default", Name: "w", UID: "uid-w"}
+	cl := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		pod,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "uid-node-1"}},
+		newTestEvent("node-1.a", node, "NodeNotReady", "Node node-1 status is now: NodeNotReady", at),
+		newTestEvent("w.a", widget, "Failed", "Widget failed", at),
+		newTestEvent("web-1.a", ref, "BackOff", "Back-off restarting failed container", at),
+	).WithInterceptorFuncs(interceptor.Funcs{
+		// The spawner's Role does not grant access to cluster-scoped
+		// objects, and the Widget kind is not installed.
+		Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			switch gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind {
+			case "Node":
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, key.Name, errors.New("access denied"))
+			case "Widget":
+				return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+			}
+			return cl.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+
+	s := &KubernetesSource{
+		Client:    cl,
+		Namespace: "default",
+		GVK:       schema.GroupVersionKind{Version: "v1", Kind: "Event"},
+	}
+	items, err := s.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != "Pod" {
+		t.Fatalf("expected a single item for the Pod, got %+v", items)
+	}
+	if skipped := s.SkippedErrors(); len(skipped) != 2 {
+		t.Errorf("expected the Events of the Node and the Widget to be skipped, got %v", skipped)
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	key := objectKey("Pod", "Web.Frontend-7d9f8cabcdef", "uid/1")
+	if !strings.HasPrefix(key, "pod-web-frontend-7d9f8ca-") || len(key) != len("pod-")+maxObjectKeyNameLength+9 {
+		t.Errorf("unexpected key %q", key)
+	}
+	if objectKey("Pod", "Web.Frontend-7d9f8cabcdef", "uid/2") == key {
+		t.Error("expected different occurrences to have different keys")
+	}
+}
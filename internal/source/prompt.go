@@ -14,19 +14,19 @@ const defaultPromptTemplate = `{{.Kind}} #{{.Number}}: {{.Title}}
 
 Comments:
 {{.Comments}}
+{{- end}}
+{{- if .ReviewComments}}
+
+Review comments:
+{{.ReviewComments}}
 {{- end}}`
 
 // RenderPrompt renders a prompt for the given work item using the provided template.
 // If promptTemplate is empty, a default template is used.
 func RenderPrompt(promptTemplate string, item WorkItem) (string, error) {
-	tmplStr := promptTemplate
-	if tmplStr == "" {
-		tmplStr = defaultPromptTemplate
-	}
-
-	tmpl, err := template.New("prompt").Parse(tmplStr)
+	tmpl, err := ParsePromptTemplate(promptTemplate)
 	if err != nil {
-		return "", fmt.Errorf("parsing prompt template: %w", err)
+		return "", err
 	}
 
 	kind := item.Kind
@@ -43,8 +43,18 @@ func RenderPrompt(promptTemplate string, item WorkItem) (string, error) {
 		Labels   string
 		Comments string
 		Kind     string
+		Author   string
+		Repo     string
 		Time     string
 		Schedule string
+		Extra    map[string]string
+		Object   string
+		Events   string
+		Logs     string
+
+		ReviewComments string
+		DiffHunks      string
+		HeadBranch     string
 	}{
 		ID:       item.ID,
 		Number:   item.Number,
@@ -54,8 +64,18 @@ func RenderPrompt(promptTemplate string, item WorkItem) (string, error) {
 		Labels:   strings.Join(item.Labels, ", "),
 		Comments: item.Comments,
 		Kind:     kind,
+		Author:   item.Author,
+		Repo:     item.Repo,
 		Time:     item.Time,
 		Schedule: item.Schedule,
+		Extra:    item.Extra,
+		Object:   item.Object,
+		Events:   item.Events,
+		Logs:     item.Logs,
+
+		ReviewComments: item.ReviewComments,
+		DiffHunks:      item.DiffHunks,
+		HeadBranch:     item.HeadBranch,
 	}
 
 	var buf bytes.Buffer
@@ -65,3 +85,18 @@ func RenderPrompt(promptTemplate string, item WorkItem) (string, error) {
 
 	return buf.String(), nil
 }
+
+// ParsePromptTemplate parses a prompt template. If promptTemplate is empty,
+// the default template is parsed.
+func ParsePromptTemplate(promptTemplate string) (*template.Template, error) {
+	tmplStr := promptTemplate
+	if tmplStr == "" {
+		tmplStr = defaultPromptTemplate
+	}
+
+	tmpl, err := template.New("prompt").Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing prompt template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -128,15 +128,25 @@ func TestRenderPromptAllVariables(t *testing.T) {
 		Labels:   []string{"a", "b"},
 		Comments: "C",
 		Kind:     "PR",
+		Author:   "A",
+		Repo:     "O/R",
+		Extra:    map[string]string{"severity": "S"},
+		Object:   "OY",
+		Events:   "EV",
+		Logs:     "LG",
+
+		ReviewComments: "RC",
+		DiffHunks:      "DH",
+		HeadBranch:     "HB",
 	}
 
-	tmpl := "{{.ID}} {{.Number}} {{.Title}} {{.Body}} {{.URL}} {{.Labels}} {{.Comments}} {{.Kind}}"
+	tmpl := "{{.ID}} {{.Number}} {{.Title}} {{.Body}} {{.URL}} {{.Labels}} {{.Comments}} {{.Kind}} {{.Author}} {{.Repo}} {{.Extra.severity}} {{.Object}} {{.Events}} {{.Logs}} {{.ReviewComments}} {{.DiffHunks}} {{.HeadBranch}}"
 	result, err := RenderPrompt(tmpl, item)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	expected := "99 99 T B U a, b C PR"
+	expected := "99 99 T B U a, b C PR A O/R S OY EV LG RC DH HB"
 	if result != expected {
 		t.Errorf("expected %q, got %q", expected, result)
 	}
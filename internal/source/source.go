@@ -1,6 +1,15 @@
 package source
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultPollInterval is how often a source is polled when the TaskSpawner
+// does not set a poll interval.
+const DefaultPollInterval = 5 * time.Minute
 
 // WorkItem represents a discovered work item from an external source.
 type WorkItem struct {
@@ -12,11 +21,63 @@ type WorkItem struct {
 	Labels   []string
 	Comments string
 	Kind     string // "Issue" or "PR"
+	Author   string // Login of the user who opened the item
+	Repo     string // Repository of the item as "owner/name", if discovered from several
 	Time     string // Cron trigger time (RFC3339)
 	Schedule string // Cron schedule expression
+
+	// Pull request fields.
+	ReviewComments string // Inline review comments with their file and line
+	DiffHunks      string // Diff hunks the review comments are attached to
+	HeadBranch     string // Head branch of the pull request
+
+	// Extra holds additional fields of items from an HTTPJSON source, by
+	// name.
+	Extra map[string]string
+
+	// Kubernetes fields, set by the KubernetesSource.
+	Object string // YAML of the object
+	Events string // Recent Events of the object, one per line
+	Logs   string // Recent logs of the object's containers
+
+	// Ref is the git ref that Tasks for the item check out instead of the
+	// Workspace's ref, if set.
+	Ref string
+	// Ordering fields, used to prioritize items.
+	CreatedAt string // Creation time of the item (RFC3339)
+	Reactions int    // Number of reactions (upvotes on GitLab)
+
+	// Activity fields, used to re-trigger Tasks when an item changes.
+	UpdatedAt     string // Last update time of the item (RFC3339)
+	LastCommentID string // ID of the latest comment by someone other than the bot, passing the author filters
+	// UpdatedByIgnoredAuthor reports whether the latest update of the item
+	// is a comment by the bot or by an author that the author filters
+	// exclude.
+	UpdatedByIgnoredAuthor bool
 }
 
 // Source discovers work items from an external system.
 type Source interface {
 	Discover(ctx context.Context) ([]WorkItem, error)
 }
+
+// ParsePollInterval parses a TaskSpawner poll interval, either as a Go
+// duration (e.g. "5m") or as a plain number of seconds. An empty string
+// yields DefaultPollInterval.
+func ParsePollInterval(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultPollInterval, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		n, atoiErr := strconv.Atoi(s)
+		if atoiErr != nil {
+			return 0, fmt.Errorf("invalid poll interval %q: must be a duration (e.g. \"5m\") or a number of seconds", s)
+		}
+		d = time.Duration(n) * time.Second
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid poll interval %q: must be positive", s)
+	}
+	return d, nil
+}
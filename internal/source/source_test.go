@@ -0,0 +1,40 @@
+package source
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePollInterval(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: DefaultPollInterval},
+		{input: "30s", want: 30 * time.Second},
+		{input: "1h", want: time.Hour},
+		{input: "120", want: 2 * time.Minute},
+		{input: "0", wantErr: true},
+		{input: "-5m", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePollInterval(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePollInterval(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
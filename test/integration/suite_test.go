@@ -85,6 +85,7 @@ var _ = BeforeSuite(func() {
 		Scheme:      mgr.GetScheme(),
 		JobBuilder:  controller.NewJobBuilder(),
 		TokenClient: tokenClient,
+		Recorder:    mgr.GetEventRecorder("axon-controller"),
 	}).SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -92,6 +93,19 @@ var _ = BeforeSuite(func() {
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
 		DeploymentBuilder: controller.NewDeploymentBuilder(),
+		Recorder:          mgr.GetEventRecorder("axon-controller"),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&controller.WorkspaceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&controller.AgentConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
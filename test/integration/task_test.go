@@ -568,6 +568,82 @@ var _ = Describe("Task Controller", func() {
 		})
 	})
 
+	Context("When creating a Task whose workspace ref overrides the git ref", func() {
+		It("Should create a Job cloning the overriding ref", func() {
+			By("Creating a namespace")
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-task-workspace-ref-override",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ns)).Should(Succeed())
+
+			By("Creating a Secret with API key")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anthropic-api-key",
+					Namespace: ns.Name,
+				},
+				StringData: map[string]string{
+					"ANTHROPIC_API_KEY": "test-api-key",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
+			By("Creating a Workspace resource with ref main")
+			ws := &axonv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-workspace",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.WorkspaceSpec{
+					Repo: "https://github.com/example/repo.git",
+					Ref:  "main",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ws)).Should(Succeed())
+
+			By("Creating a Task whose workspace ref checks out a pull request branch")
+			task := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-workspace-ref-override",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:   "claude-code",
+					Prompt: "Address the review comments",
+					Credentials: axonv1alpha1.Credentials{
+						Type: axonv1alpha1.CredentialTypeAPIKey,
+						SecretRef: axonv1alpha1.SecretReference{
+							Name: "anthropic-api-key",
+						},
+					},
+					WorkspaceRef: &axonv1alpha1.WorkspaceReference{
+						Name: "test-workspace",
+						Ref:  "fix-login",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
+
+			By("Verifying a Job is created")
+			jobLookupKey := types.NamespacedName{Name: task.Name, Namespace: ns.Name}
+			createdJob := &batchv1.Job{}
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, jobLookupKey, createdJob)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			By("Verifying the init container clones the overriding ref")
+			Expect(createdJob.Spec.Template.Spec.InitContainers).To(HaveLen(1))
+			Expect(createdJob.Spec.Template.Spec.InitContainers[0].Args).To(Equal([]string{
+				"clone", "--branch", "fix-login", "--no-single-branch", "--depth", "1",
+				"--", "https://github.com/example/repo.git", "/workspace/repo",
+			}))
+		})
+	})
+
 	Context("When creating a Task with TTL", func() {
 		It("Should delete the Task after TTL expires", func() {
 			By("Creating a namespace")
@@ -1417,4 +1493,278 @@ var _ = Describe("Task Controller", func() {
 			Expect(githubTokenEnv.ValueFrom.SecretKeyRef.Key).To(Equal("GITHUB_TOKEN"))
 		})
 	})
+
+	Context("When creating a Task that depends on another Task", func() {
+		It("Should wait for the dependency to succeed before creating a Job", func() {
+			By("Creating a namespace")
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-task-depends-on",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ns)).Should(Succeed())
+
+			By("Creating a Secret with API key")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anthropic-api-key",
+					Namespace: ns.Name,
+				},
+				StringData: map[string]string{
+					"ANTHROPIC_API_KEY": "test-api-key",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
+			credentials := axonv1alpha1.Credentials{
+				Type: axonv1alpha1.CredentialTypeAPIKey,
+				SecretRef: axonv1alpha1.SecretReference{
+					Name: "anthropic-api-key",
+				},
+			}
+
+			By("Creating the dependent Task first")
+			dependent := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "implement",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:        "claude-code",
+					Prompt:      "Implement the plan",
+					Credentials: credentials,
+					DependsOn:   []string{"plan"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, dependent)).Should(Succeed())
+
+			dependentKey := types.NamespacedName{Name: dependent.Name, Namespace: ns.Name}
+			createdDependent := &axonv1alpha1.Task{}
+
+			By("Verifying the dependent Task is Waiting")
+			Eventually(func() axonv1alpha1.TaskPhase {
+				if err := k8sClient.Get(ctx, dependentKey, createdDependent); err != nil {
+					return ""
+				}
+				return createdDependent.Status.Phase
+			}, timeout, interval).Should(Equal(axonv1alpha1.TaskPhaseWaiting))
+
+			By("Verifying no Job is created for the dependent Task")
+			Consistently(func() bool {
+				err := k8sClient.Get(ctx, dependentKey, &batchv1.Job{})
+				return err != nil
+			}, 2*time.Second, interval).Should(BeTrue())
+
+			By("Creating the dependency Task")
+			dependency := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "plan",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:        "claude-code",
+					Prompt:      "Write a plan",
+					Credentials: credentials,
+				},
+			}
+			Expect(k8sClient.Create(ctx, dependency)).Should(Succeed())
+
+			By("Simulating completion of the dependency Job")
+			dependencyJobKey := types.NamespacedName{Name: dependency.Name, Namespace: ns.Name}
+			dependencyJob := &batchv1.Job{}
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, dependencyJobKey, dependencyJob); err != nil {
+					return err
+				}
+				dependencyJob.Status.Succeeded = 1
+				return k8sClient.Status().Update(ctx, dependencyJob)
+			}, timeout, interval).Should(Succeed())
+
+			By("Verifying a Job is created for the dependent Task")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, dependentKey, &batchv1.Job{})
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Should fail when a dependency fails", func() {
+			By("Creating a namespace")
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-task-depends-on-failed",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ns)).Should(Succeed())
+
+			By("Creating a Secret with API key")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anthropic-api-key",
+					Namespace: ns.Name,
+				},
+				StringData: map[string]string{
+					"ANTHROPIC_API_KEY": "test-api-key",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
+			credentials := axonv1alpha1.Credentials{
+				Type: axonv1alpha1.CredentialTypeAPIKey,
+				SecretRef: axonv1alpha1.SecretReference{
+					Name: "anthropic-api-key",
+				},
+			}
+
+			By("Creating the dependency and dependent Tasks")
+			dependency := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "plan",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:        "claude-code",
+					Prompt:      "Write a plan",
+					Credentials: credentials,
+				},
+			}
+			Expect(k8sClient.Create(ctx, dependency)).Should(Succeed())
+
+			dependent := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "implement",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:        "claude-code",
+					Prompt:      "Implement the plan",
+					Credentials: credentials,
+					DependsOn:   []string{"plan"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, dependent)).Should(Succeed())
+
+			By("Simulating failure of the dependency Job")
+			dependencyJobKey := types.NamespacedName{Name: dependency.Name, Namespace: ns.Name}
+			dependencyJob := &batchv1.Job{}
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, dependencyJobKey, dependencyJob); err != nil {
+					return err
+				}
+				dependencyJob.Status.Failed = 1
+				return k8sClient.Status().Update(ctx, dependencyJob)
+			}, timeout, interval).Should(Succeed())
+
+			By("Verifying the dependent Task fails")
+			dependentKey := types.NamespacedName{Name: dependent.Name, Namespace: ns.Name}
+			createdDependent := &axonv1alpha1.Task{}
+			Eventually(func() axonv1alpha1.TaskPhase {
+				if err := k8sClient.Get(ctx, dependentKey, createdDependent); err != nil {
+					return ""
+				}
+				return createdDependent.Status.Phase
+			}, timeout, interval).Should(Equal(axonv1alpha1.TaskPhaseFailed))
+			Expect(createdDependent.Status.Message).To(ContainSubstring("plan"))
+
+			By("Verifying no Job is created for the dependent Task")
+			Expect(k8sClient.Get(ctx, dependentKey, &batchv1.Job{})).NotTo(Succeed())
+		})
+	})
+
+	Context("When creating a Task with a retry policy", func() {
+		It("Should create a fresh Job per attempt and fail after the last attempt", func() {
+			By("Creating a namespace")
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-task-retry",
+				},
+			}
+			Expect(k8sClient.Create(ctx, ns)).Should(Succeed())
+
+			By("Creating a Secret with API key")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anthropic-api-key",
+					Namespace: ns.Name,
+				},
+				StringData: map[string]string{
+					"ANTHROPIC_API_KEY": "test-api-key",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
+			By("Creating a Task with two attempts and no backoff")
+			backoff := int32(0)
+			task := &axonv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-task-retry",
+					Namespace: ns.Name,
+				},
+				Spec: axonv1alpha1.TaskSpec{
+					Type:   "claude-code",
+					Prompt: "Create a hello world program",
+					Credentials: axonv1alpha1.Credentials{
+						Type: axonv1alpha1.CredentialTypeAPIKey,
+						SecretRef: axonv1alpha1.SecretReference{
+							Name: "anthropic-api-key",
+						},
+					},
+					RetryPolicy: &axonv1alpha1.RetryPolicy{
+						MaxAttempts:    2,
+						BackoffSeconds: &backoff,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
+
+			taskLookupKey := types.NamespacedName{Name: task.Name, Namespace: ns.Name}
+			createdTask := &axonv1alpha1.Task{}
+
+			By("Simulating failure of the first attempt")
+			firstJobKey := types.NamespacedName{Name: task.Name, Namespace: ns.Name}
+			firstJob := &batchv1.Job{}
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, firstJobKey, firstJob); err != nil {
+					return err
+				}
+				firstJob.Status.Failed = 1
+				return k8sClient.Status().Update(ctx, firstJob)
+			}, timeout, interval).Should(Succeed())
+
+			By("Verifying a Job is created for the second attempt")
+			secondJobKey := types.NamespacedName{Name: task.Name + "-attempt-2", Namespace: ns.Name}
+			secondJob := &batchv1.Job{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, secondJobKey, secondJob)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, taskLookupKey, createdTask); err != nil {
+					return ""
+				}
+				return createdTask.Status.JobName
+			}, timeout, interval).Should(Equal(secondJobKey.Name))
+			Expect(createdTask.Status.Phase).NotTo(Equal(axonv1alpha1.TaskPhaseFailed))
+			Expect(createdTask.Status.Attempts).To(HaveLen(2))
+			Expect(createdTask.Status.Attempts[0].Phase).To(Equal(axonv1alpha1.TaskPhaseFailed))
+
+			By("Simulating failure of the second attempt")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, secondJobKey, secondJob); err != nil {
+					return err
+				}
+				secondJob.Status.Failed = 1
+				return k8sClient.Status().Update(ctx, secondJob)
+			}, timeout, interval).Should(Succeed())
+
+			By("Verifying the Task fails once the attempts are used up")
+			Eventually(func() axonv1alpha1.TaskPhase {
+				if err := k8sClient.Get(ctx, taskLookupKey, createdTask); err != nil {
+					return ""
+				}
+				return createdTask.Status.Phase
+			}, timeout, interval).Should(Equal(axonv1alpha1.TaskPhaseFailed))
+			Expect(createdTask.Status.Message).To(ContainSubstring("2 attempts"))
+		})
+	})
 })